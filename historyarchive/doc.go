@@ -0,0 +1,5 @@
+// Package historyarchive provides access to the history archives published by
+// stellar-core: their state files, checkpoint files and buckets, whether stored
+// on a local filesystem, in S3 or behind HTTP.  It is used by the
+// stellar-archivist tool and by horizon's ingestion system.
+package historyarchive
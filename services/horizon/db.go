@@ -10,6 +10,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/stellar/go/historyarchive"
 	"github.com/stellar/go/services/horizon/internal/db2/schema"
 	"github.com/stellar/go/services/horizon/internal/ingest"
 	hlog "github.com/stellar/go/services/horizon/internal/log"
@@ -142,42 +143,60 @@ var dbReingestCmd = &cobra.Command{
 
 		i := ingestSystem()
 		i.SkipCursorUpdate = true
-		logStatus := func(stage string) {
-			count := i.Metrics.IngestLedgerTimer.Count()
-			rate := i.Metrics.IngestLedgerTimer.RateMean()
-			loadMean := time.Duration(i.Metrics.LoadLedgerTimer.Mean())
-			ingestMean := time.Duration(i.Metrics.IngestLedgerTimer.Mean())
-			clearMean := time.Duration(i.Metrics.IngestLedgerTimer.Mean())
-			hlog.
-				WithField("count", count).
-				WithField("rate", rate).
-				WithField("means", fmt.Sprintf("load: %s clear: %s ingest: %s", loadMean, clearMean, ingestMean)).
-				Infof("reingest: %s", stage)
-		}
-
-		done := make(chan error, 1)
-
-		// run ingestion in separate goroutine
-		go func() {
-			_, err := reingest(i, args)
-			done <- err
-			logStatus("complete")
-		}()
-
-		// output metrics
-		metrics := time.Tick(2 * time.Second)
-		for {
-			select {
-			case <-metrics:
-				logStatus("status")
-
-			case err := <-done:
-				if err != nil {
-					log.Fatal(err)
-				}
-				os.Exit(0)
-			}
+		useHistoryArchive(i)
+
+		runReingestion(i, func() (int, error) {
+			return reingest(i, args)
+		})
+	},
+}
+
+var (
+	reingestArchiveURL string
+	reingestParallel   int
+	reingestChunkSize  int32
+	reingestForce      bool
+)
+
+var dbReingestRangeCmd = &cobra.Command{
+	Use:   "range [FROM] [TO]",
+	Short: "reingests a range of ledgers in parallel",
+	Long: "range splits the ledgers from FROM to TO, inclusive, into chunks that " +
+		"are reingested concurrently, each committed atomically. Chunks that were " +
+		"already ingested with the current version are skipped unless --force is " +
+		"set, so an interrupted run resumes where it stopped when run again.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 2 {
+			cmd.Usage()
+			os.Exit(1)
 		}
+
+		from, err := strconv.ParseInt(args[0], 10, 32)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		to, err := strconv.ParseInt(args[1], 10, 32)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		initConfig()
+		hlog.DefaultLogger.Logger.Level = config.LogLevel
+
+		i := ingestSystem()
+		i.SkipCursorUpdate = true
+		useHistoryArchive(i)
+
+		runReingestion(i, func() (int, error) {
+			return i.ReingestRangeParallel(
+				int32(from),
+				int32(to),
+				reingestChunkSize,
+				reingestParallel,
+				reingestForce,
+			)
+		})
 	},
 }
 
@@ -188,6 +207,32 @@ func init() {
 	dbCmd.AddCommand(dbReapCmd)
 	dbCmd.AddCommand(dbReingestCmd)
 	dbCmd.AddCommand(dbRebaseCmd)
+
+	dbReingestCmd.PersistentFlags().StringVar(
+		&reingestArchiveURL,
+		"history-archive-url",
+		"",
+		"reingest ledgers from the history archive at this url (file://, s3:// or http://) instead of the stellar-core database",
+	)
+	dbReingestRangeCmd.Flags().IntVar(
+		&reingestParallel,
+		"parallel",
+		1,
+		"number of chunks to reingest concurrently",
+	)
+	dbReingestRangeCmd.Flags().Int32Var(
+		&reingestChunkSize,
+		"chunk-size",
+		1000,
+		"number of ledgers reingested and committed together",
+	)
+	dbReingestRangeCmd.Flags().BoolVar(
+		&reingestForce,
+		"force",
+		false,
+		"reingest chunks that were already ingested with the current version",
+	)
+	dbReingestCmd.AddCommand(dbReingestRangeCmd)
 }
 
 func ingestSystem() *ingest.System {
@@ -207,9 +252,34 @@ func ingestSystem() *ingest.System {
 	}
 
 	i := ingest.New(passphrase, config.StellarCoreURL, cdb, hdb)
+
+	i.Filter, err = ingest.NewFilter(
+		config.FilterAccounts,
+		config.FilterAssets,
+		config.FilterOperationTypes,
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	return i
 }
 
+// useHistoryArchive configures `i` to reingest ledgers from the history
+// archive at --history-archive-url, if one was provided.
+func useHistoryArchive(i *ingest.System) {
+	if reingestArchiveURL == "" {
+		return
+	}
+
+	archive, err := historyarchive.Connect(reingestArchiveURL, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	i.HistoryArchive = archive
+}
+
 func reingest(i *ingest.System, args []string) (int, error) {
 	if len(args) == 0 {
 		count, err := i.ReingestAll()
@@ -234,3 +304,44 @@ func reingest(i *ingest.System, args []string) (int, error) {
 	}
 	return len(args), nil
 }
+
+// runReingestion runs `fn` while periodically logging the ingestion metrics of
+// `i`, exiting the process once it completes.
+func runReingestion(i *ingest.System, fn func() (int, error)) {
+	logStatus := func(stage string) {
+		count := i.Metrics.IngestLedgerTimer.Count()
+		rate := i.Metrics.IngestLedgerTimer.RateMean()
+		loadMean := time.Duration(i.Metrics.LoadLedgerTimer.Mean())
+		ingestMean := time.Duration(i.Metrics.IngestLedgerTimer.Mean())
+		clearMean := time.Duration(i.Metrics.IngestLedgerTimer.Mean())
+		hlog.
+			WithField("count", count).
+			WithField("rate", rate).
+			WithField("means", fmt.Sprintf("load: %s clear: %s ingest: %s", loadMean, clearMean, ingestMean)).
+			Infof("reingest: %s", stage)
+	}
+
+	done := make(chan error, 1)
+
+	// run ingestion in separate goroutine
+	go func() {
+		_, err := fn()
+		done <- err
+		logStatus("complete")
+	}()
+
+	// output metrics
+	metrics := time.Tick(2 * time.Second)
+	for {
+		select {
+		case <-metrics:
+			logStatus("status")
+
+		case err := <-done:
+			if err != nil {
+				log.Fatal(err)
+			}
+			os.Exit(0)
+		}
+	}
+}
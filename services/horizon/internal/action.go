@@ -1,18 +1,26 @@
 package horizon
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/stellar/go/services/horizon/internal/actions"
+	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/httpx"
 	"github.com/stellar/go/services/horizon/internal/ledger"
 	"github.com/stellar/go/services/horizon/internal/log"
+	"github.com/stellar/go/services/horizon/internal/render"
+	"github.com/stellar/go/services/horizon/internal/render/export"
 	"github.com/stellar/go/services/horizon/internal/render/problem"
+	"github.com/stellar/go/services/horizon/internal/resource"
 	"github.com/stellar/go/services/horizon/internal/toid"
+	"github.com/stellar/go/xdr"
 	"github.com/zenazn/goji/web"
 )
 
@@ -123,6 +131,154 @@ func (action *Action) ValidateCursorWithinHistory() {
 	}
 }
 
+// GetHistoryLedger retrieves a ledger sequence from the named parameter,
+// defaulting to the latest ledger in the history database when it is absent.
+// A 400 is returned for ledgers that have not yet been ingested and a 410 for
+// ledgers that precede the history database.
+func (action *Action) GetHistoryLedger(name string) int32 {
+	if action.Err != nil {
+		return 0
+	}
+
+	ls := ledger.CurrentState()
+	seq := action.GetInt32(name)
+	if action.Err != nil {
+		return 0
+	}
+
+	if seq == 0 {
+		return ls.HistoryLatest
+	}
+
+	if seq > ls.HistoryLatest {
+		action.SetInvalidField(name, errors.New("ledger has not been ingested yet"))
+		return 0
+	}
+
+	if seq < ls.HistoryElder {
+		action.Err = &problem.BeforeHistory
+		return 0
+	}
+
+	return seq
+}
+
+// GetLedgerRange retrieves the range of ledgers requested by the `from_ledger`
+// and `to_ledger` parameters, narrowed to the ledgers closed between the
+// `start_time` (inclusive) and `end_time` (exclusive) parameters.  A zero bound
+// leaves that end of the range open.  A 410 is returned for ranges that end
+// before the history database begins.
+func (action *Action) GetLedgerRange() (from int32, to int32) {
+	if action.Err != nil {
+		return
+	}
+
+	from = action.GetInt32("from_ledger")
+	to = action.GetInt32("to_ledger")
+	startTime := action.GetTimeMillis("start_time")
+	endTime := action.GetTimeMillis("end_time")
+	if action.Err != nil {
+		return
+	}
+
+	if from < 0 {
+		action.SetInvalidField("from_ledger", errors.New("ledger must not be negative"))
+		return
+	}
+
+	if to < 0 {
+		action.SetInvalidField("to_ledger", errors.New("ledger must not be negative"))
+		return
+	}
+
+	ls := ledger.CurrentState()
+
+	if !startTime.IsNil() {
+		var seq int32
+		err := action.HistoryQ().FirstLedgerClosedAtOrAfter(&seq, startTime.ToTime())
+
+		// When no ledger has closed since `start_time`, the range begins with
+		// the next ledger to be ingested.
+		if action.HistoryQ().NoRows(err) {
+			seq, err = ls.HistoryLatest+1, nil
+		}
+
+		if err != nil {
+			action.Err = err
+			return
+		}
+
+		if seq > from {
+			from = seq
+		}
+	}
+
+	if !endTime.IsNil() {
+		var seq int32
+		err := action.HistoryQ().LastLedgerClosedBefore(&seq, endTime.ToTime())
+		if action.HistoryQ().NoRows(err) {
+			action.Err = &problem.BeforeHistory
+			return
+		}
+
+		if err != nil {
+			action.Err = err
+			return
+		}
+
+		if to == 0 || seq < to {
+			to = seq
+		}
+	}
+
+	if to != 0 && to < ls.HistoryElder {
+		action.Err = &problem.BeforeHistory
+		return
+	}
+
+	return
+}
+
+// GetOperationTypes retrieves the operation types named by the comma separated
+// list in the parameter `name`.  Returns nil when the parameter is absent.
+func (action *Action) GetOperationTypes(name string) []xdr.OperationType {
+	if action.Err != nil || action.GetString(name) == "" {
+		return nil
+	}
+
+	var result []xdr.OperationType
+	for _, n := range action.GetStringList(name, db2.MaxPageSize) {
+		typ, ok := resource.OperationTypeFromName(n)
+		if !ok {
+			action.SetInvalidField(name, fmt.Errorf("unknown operation type: %s", n))
+			return nil
+		}
+		result = append(result, typ)
+	}
+
+	return result
+}
+
+// GetEffectTypes retrieves the effect types named by the comma separated list
+// in the parameter `name`.  Returns nil when the parameter is absent.
+func (action *Action) GetEffectTypes(name string) []history.EffectType {
+	if action.Err != nil || action.GetString(name) == "" {
+		return nil
+	}
+
+	var result []history.EffectType
+	for _, n := range action.GetStringList(name, db2.MaxPageSize) {
+		typ, ok := resource.EffectTypeFromName(n)
+		if !ok {
+			action.SetInvalidField(name, fmt.Errorf("unknown effect type: %s", n))
+			return nil
+		}
+		result = append(result, typ)
+	}
+
+	return result
+}
+
 // EnsureHistoryFreshness halts processing and raises
 func (action *Action) EnsureHistoryFreshness() {
 	if action.Err != nil {
@@ -140,6 +296,44 @@ func (action *Action) EnsureHistoryFreshness() {
 	}
 }
 
+// PrepareExport readies `page` and `stream` for an export: records are loaded
+// in pages of the largest size allowed, the stream is capped at the configured
+// maximum number of exported records, and CSV exports have the fixed
+// `columns`.
+func (action *Action) PrepareExport(stream export.Stream, page *db2.PageQuery, columns []string) {
+	page.Limit = db2.MaxPageSize
+	stream.SetLimit(int(action.App.config.ExportLimit))
+	stream.SetColumns(columns)
+}
+
+// AdvanceExport moves `page` past the `count` records that were just loaded
+// for `stream`, finishing the export once a short page shows that there are no
+// records left.
+func (action *Action) AdvanceExport(stream export.Stream, page *db2.PageQuery, count int) {
+	if count < int(page.Limit) {
+		stream.Done()
+		return
+	}
+
+	page.Cursor = stream.Cursor()
+}
+
+// WriteXDR writes the XDR encodings of `values`, one after another, as the
+// body of a raw response.
+func (action *Action) WriteXDR(values ...interface{}) {
+	var buf bytes.Buffer
+	for _, v := range values {
+		_, err := xdr.Marshal(&buf, v)
+		if err != nil {
+			action.Err = err
+			return
+		}
+	}
+
+	action.W.Header().Set("Content-Type", render.MimeRaw)
+	action.W.Write(buf.Bytes())
+}
+
 // FullURL returns the full url for this request
 func (action *Action) FullURL() *url.URL {
 	result := action.baseURL()
@@ -6,6 +6,7 @@ import (
 	gctx "github.com/goji/context"
 
 	"github.com/stellar/go/services/horizon/internal/render"
+	"github.com/stellar/go/services/horizon/internal/render/export"
 	"github.com/stellar/go/services/horizon/internal/render/problem"
 	"github.com/stellar/go/services/horizon/internal/render/sse"
 	"github.com/zenazn/goji/web"
@@ -90,6 +91,41 @@ func (base *Base) Execute(action interface{}) {
 				//no-op, continue onto the next iteration
 			}
 		}
+	case render.MimeCSV, render.MimeNDJSON:
+		action, ok := action.(Export)
+		if !ok {
+			goto NotAcceptable
+		}
+
+		stream := export.NewStream(base.Ctx, base.W, contentType)
+
+		for {
+			action.Export(stream)
+
+			if base.Err != nil {
+				// as with event streams, an error raised before any record has been
+				// exported is rendered as a normal problem response.
+				if stream.SentCount() == 0 {
+					problem.Render(base.Ctx, base.W, base.Err)
+					return
+				}
+
+				stream.Err(base.Err)
+			}
+
+			stream.Flush()
+
+			if stream.IsDone() {
+				return
+			}
+
+			select {
+			case <-base.Ctx.Done():
+				return
+			default:
+				//no-op, continue onto the next page
+			}
+		}
 	case render.MimeRaw:
 		action, ok := action.(Raw)
 
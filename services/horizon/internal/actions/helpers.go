@@ -4,6 +4,7 @@ import (
 	"mime"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/stellar/go/amount"
 	"github.com/stellar/go/services/horizon/internal/assets"
@@ -76,6 +77,38 @@ func (base *Base) GetString(name string) string {
 	return base.R.URL.Query().Get(name)
 }
 
+// GetStringList retrieves a comma separated list of strings from the action
+// parameter of the given name.  Populates err if the list is missing, has a
+// blank entry or has more than `max` entries.
+func (base *Base) GetStringList(name string, max int) []string {
+	if base.Err != nil {
+		return nil
+	}
+
+	asStr := base.GetString(name)
+
+	if asStr == "" {
+		base.SetInvalidField(name, errors.New("no values provided"))
+		return nil
+	}
+
+	list := strings.Split(asStr, ",")
+
+	if len(list) > max {
+		base.SetInvalidField(name, errors.Errorf("more than %d values provided", max))
+		return nil
+	}
+
+	for _, s := range list {
+		if s == "" {
+			base.SetInvalidField(name, errors.New("blank value provided"))
+			return nil
+		}
+	}
+
+	return list
+}
+
 // GetInt64 retrieves an int64 from the action parameter of the given name.
 // Populates err if the value is not a valid int64
 func (base *Base) GetInt64(name string) int64 {
@@ -316,6 +349,92 @@ func (base *Base) MaybeGetAsset(prefix string) (xdr.Asset, bool) {
 	return base.GetAsset(prefix), true
 }
 
+// GetCanonicalAsset decodes an asset from the field `name`, given in its
+// canonical form: either `native` or `CODE:ISSUER`.
+func (base *Base) GetCanonicalAsset(name string) (result xdr.Asset) {
+	if base.Err != nil {
+		return
+	}
+
+	raw := base.GetString(name)
+	if base.Err != nil {
+		return
+	}
+
+	if raw == "native" {
+		result, _ = xdr.NewAsset(xdr.AssetTypeAssetTypeNative, nil)
+		return
+	}
+
+	parts := strings.SplitN(raw, ":", 2)
+	if len(parts) != 2 {
+		base.SetInvalidField(name, errors.New("must be native or of the form CODE:ISSUER"))
+		return
+	}
+
+	key, err := strkey.Decode(strkey.VersionByteAccountID, parts[1])
+	if err != nil {
+		base.SetInvalidField(name, err)
+		return
+	}
+
+	var issuer xdr.Uint256
+	copy(issuer[:], key)
+	accountID, err := xdr.NewAccountId(xdr.PublicKeyTypePublicKeyTypeEd25519, issuer)
+	if err != nil {
+		base.SetInvalidField(name, err)
+		return
+	}
+
+	result, err = creditAsset(parts[0], accountID)
+	if err != nil {
+		base.SetInvalidField(name, err)
+	}
+	return
+}
+
+// GetCreditAsset retrieves a credit asset whose code and issuer are the
+// action parameters `codeName` and `issuerName`.  The asset type is implied by
+// the length of the code.
+func (base *Base) GetCreditAsset(codeName string, issuerName string) (result xdr.Asset) {
+	if base.Err != nil {
+		return
+	}
+
+	code := base.GetString(codeName)
+	issuer := base.GetAccountID(issuerName)
+	if base.Err != nil {
+		return
+	}
+
+	result, err := creditAsset(code, issuer)
+	if err != nil {
+		base.SetInvalidField(codeName, err)
+	}
+	return
+}
+
+// creditAsset returns the credit asset with `code` issued by `issuer`.
+func creditAsset(code string, issuer xdr.AccountId) (xdr.Asset, error) {
+	var t xdr.AssetType
+	var value interface{}
+
+	switch {
+	case len(code) >= 1 && len(code) <= 4:
+		a := xdr.AssetAlphaNum4{Issuer: issuer}
+		copy(a.AssetCode[:], []byte(code))
+		t, value = xdr.AssetTypeAssetTypeCreditAlphanum4, a
+	case len(code) >= 5 && len(code) <= 12:
+		a := xdr.AssetAlphaNum12{Issuer: issuer}
+		copy(a.AssetCode[:], []byte(code))
+		t, value = xdr.AssetTypeAssetTypeCreditAlphanum12, a
+	default:
+		return xdr.Asset{}, errors.New("asset code length is invalid")
+	}
+
+	return xdr.NewAsset(t, value)
+}
+
 // GetTimeMillis retrieves a TimeMillis from the action parameter of the given name.
 // Populates err if the value is not a valid TimeMillis
 func (base *Base) GetTimeMillis(name string) (timeMillis time.Millis) {
@@ -73,6 +73,52 @@ func TestGetAssetType(t *testing.T) {
 		tt.Assert.Equal(xdr.AssetTypeAssetTypeCreditAlphanum12, ts)
 	}
 }
+func TestGetCanonicalAsset(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	action := makeTestAction()
+
+	ts := action.GetCanonicalAsset("native_canonical")
+	if tt.Assert.NoError(action.Err) {
+		tt.Assert.Equal(xdr.AssetTypeAssetTypeNative, ts.Type)
+	}
+
+	ts = action.GetCanonicalAsset("4_canonical")
+	if tt.Assert.NoError(action.Err) {
+		tt.Assert.Equal(xdr.AssetTypeAssetTypeCreditAlphanum4, ts.Type)
+		tt.Assert.Equal("credit_alphanum4/USD/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H", ts.String())
+	}
+
+	ts = action.GetCanonicalAsset("12_canonical")
+	if tt.Assert.NoError(action.Err) {
+		tt.Assert.Equal(xdr.AssetTypeAssetTypeCreditAlphanum12, ts.Type)
+	}
+
+	// bad path
+	action.GetCanonicalAsset("4_asset_code")
+	tt.Assert.Error(action.Err)
+}
+
+func TestGetCreditAsset(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	action := makeTestAction()
+
+	ts := action.GetCreditAsset("4_asset_code", "4_asset_issuer")
+	if tt.Assert.NoError(action.Err) {
+		tt.Assert.Equal("credit_alphanum4/USD/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H", ts.String())
+	}
+
+	ts = action.GetCreditAsset("long_asset_code", "4_asset_issuer")
+	if tt.Assert.NoError(action.Err) {
+		tt.Assert.Equal(xdr.AssetTypeAssetTypeCreditAlphanum12, ts.Type)
+	}
+
+	// bad path
+	action.GetCreditAsset("4_asset_code", "4_asset_code")
+	tt.Assert.Error(action.Err)
+}
+
 func TestGetCursor(t *testing.T) {
 	tt := test.Start(t)
 	defer tt.Finish()
@@ -249,6 +295,31 @@ func TestGetString(t *testing.T) {
 	tt.Assert.Equal("goodbye", action.GetString("cursor"))
 }
 
+func TestGetStringList(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	// happy path
+	action := makeAction("/?ids=a,b,c", nil)
+	list := action.GetStringList("ids", 3)
+	if tt.Assert.NoError(action.Err) {
+		tt.Assert.Equal([]string{"a", "b", "c"}, list)
+	}
+
+	// invalids
+	action = makeAction("/", nil)
+	_ = action.GetStringList("ids", 3)
+	tt.Assert.Error(action.Err)
+
+	action = makeAction("/?ids=a,,c", nil)
+	_ = action.GetStringList("ids", 3)
+	tt.Assert.Error(action.Err)
+
+	action = makeAction("/?ids=a,b,c,d", nil)
+	_ = action.GetStringList("ids", 3)
+	tt.Assert.Error(action.Err)
+}
+
 func TestPath(t *testing.T) {
 	tt := test.Start(t)
 	defer tt.Finish()
@@ -290,5 +361,9 @@ func testURLParams() map[string]string {
 		"12_asset_type":     "credit_alphanum12",
 		"12_asset_code":     "USD",
 		"12_asset_issuer":   "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+		"native_canonical":  "native",
+		"4_canonical":       "USD:GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+		"12_canonical":      "EURT2:GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+		"long_asset_code":   "EURT2",
 	}
 }
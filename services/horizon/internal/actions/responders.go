@@ -1,6 +1,9 @@
 package actions
 
-import "github.com/stellar/go/services/horizon/internal/render/sse"
+import (
+	"github.com/stellar/go/services/horizon/internal/render/export"
+	"github.com/stellar/go/services/horizon/internal/render/sse"
+)
 
 // JSON implementors can respond to a request whose response type was negotiated
 // to be MimeHal or MimeJSON.
@@ -19,3 +22,10 @@ type Raw interface {
 type SSE interface {
 	SSE(sse.Stream)
 }
+
+// Export implementors can respond to a request whose response type was
+// negotiated to be MimeCSV or MimeNDJSON.  Export is called repeatedly, writing
+// one page of records to the stream on each call, until the stream is done.
+type Export interface {
+	Export(export.Stream)
+}
@@ -1,16 +1,19 @@
 package horizon
 
 import (
+	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
 	"github.com/stellar/go/services/horizon/internal/render/sse"
 	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/strkey"
 )
 
 // This file contains the actions:
 //
 // AccountShowAction: details for single account (including stellar-core state)
+// AccountBatchAction: details for a list of accounts
 
 // AccountShowAction renders a account summary found by its address.
 type AccountShowAction struct {
@@ -49,6 +52,23 @@ func (action *AccountShowAction) SSE(stream sse.Stream) {
 	)
 }
 
+// Raw is a method for actions.Raw
+func (action *AccountShowAction) Raw() {
+	action.Do(
+		action.loadParams,
+		action.loadCoreEntry,
+		func() {
+			entry, err := action.CoreRecord.Entry(action.CoreSigners)
+			if err != nil {
+				action.Err = err
+				return
+			}
+
+			action.WriteXDR(entry)
+		},
+	)
+}
+
 func (action *AccountShowAction) loadParams() {
 	action.Address = action.GetString("id")
 }
@@ -92,6 +112,19 @@ func (action *AccountShowAction) loadRecord() {
 	}
 }
 
+// loadCoreEntry loads the stellar-core rows that make up the account's ledger
+// entry.
+func (action *AccountShowAction) loadCoreEntry() {
+	action.Err = action.CoreQ().
+		AccountByAddress(&action.CoreRecord, action.Address)
+	if action.Err != nil {
+		return
+	}
+
+	action.Err = action.CoreQ().
+		SignersByAddress(&action.CoreSigners, action.Address)
+}
+
 func (action *AccountShowAction) loadResource() {
 	action.Err = action.Resource.Populate(
 		action.Ctx,
@@ -102,3 +135,124 @@ func (action *AccountShowAction) loadResource() {
 		action.HistoryRecord,
 	)
 }
+
+// AccountBatchAction renders the accounts found by a list of addresses, in the
+// order they were requested.  An address that cannot be found is rendered as a
+// resource.NotFound.
+type AccountBatchAction struct {
+	Action
+	Addresses      []string
+	HistoryRecords []history.Account
+	CoreData       []core.AccountData
+	CoreRecords    []core.Account
+	CoreSigners    []core.Signer
+	CoreTrustlines []core.Trustline
+	Page           hal.BasePage
+}
+
+// JSON is a method for actions.JSON
+func (action *AccountBatchAction) JSON() {
+	action.Do(
+		action.loadParams,
+		action.loadRecords,
+		action.loadPage,
+		func() {
+			hal.Render(action.W, action.Page)
+		},
+	)
+}
+
+func (action *AccountBatchAction) loadParams() {
+	action.Addresses = action.GetStringList("ids", db2.MaxPageSize)
+
+	for _, addy := range action.Addresses {
+		_, err := strkey.Decode(strkey.VersionByteAccountID, addy)
+		if err != nil {
+			action.SetInvalidField("ids", err)
+			return
+		}
+	}
+}
+
+func (action *AccountBatchAction) loadRecords() {
+	action.Err = action.CoreQ().
+		AccountsByAddresses(&action.CoreRecords, action.Addresses)
+	if action.Err != nil {
+		return
+	}
+
+	action.Err = action.CoreQ().
+		AllDataByAddresses(&action.CoreData, action.Addresses)
+	if action.Err != nil {
+		return
+	}
+
+	action.Err = action.CoreQ().
+		SignersByAddresses(&action.CoreSigners, action.Addresses)
+	if action.Err != nil {
+		return
+	}
+
+	action.Err = action.CoreQ().
+		TrustlinesByAddresses(&action.CoreTrustlines, action.Addresses)
+	if action.Err != nil {
+		return
+	}
+
+	// Accounts created outside of our known history range have no history
+	// record, which is not an error.
+	action.Err = action.HistoryQ().
+		AccountsByAddresses(&action.HistoryRecords, action.Addresses)
+}
+
+func (action *AccountBatchAction) loadPage() {
+	accounts := map[string]core.Account{}
+	for _, ca := range action.CoreRecords {
+		accounts[ca.Accountid] = ca
+	}
+
+	data := map[string][]core.AccountData{}
+	for _, cd := range action.CoreData {
+		data[cd.Accountid] = append(data[cd.Accountid], cd)
+	}
+
+	signers := map[string][]core.Signer{}
+	for _, cs := range action.CoreSigners {
+		signers[cs.Accountid] = append(signers[cs.Accountid], cs)
+	}
+
+	trustlines := map[string][]core.Trustline{}
+	for _, ct := range action.CoreTrustlines {
+		trustlines[ct.Accountid] = append(trustlines[ct.Accountid], ct)
+	}
+
+	histories := map[string]history.Account{}
+	for _, ha := range action.HistoryRecords {
+		histories[ha.Address] = ha
+	}
+
+	action.Page.Init()
+	for _, addy := range action.Addresses {
+		ca, ok := accounts[addy]
+		if !ok {
+			var res resource.NotFound
+			res.Populate(addy)
+			action.Page.Add(res)
+			continue
+		}
+
+		var res resource.Account
+		action.Err = res.Populate(
+			action.Ctx,
+			ca,
+			data[addy],
+			signers[addy],
+			trustlines[addy],
+			histories[addy],
+		)
+		if action.Err != nil {
+			return
+		}
+		action.Page.Add(res)
+	}
+}
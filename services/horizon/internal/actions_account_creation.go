@@ -0,0 +1,97 @@
+package horizon
+
+import (
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/services/horizon/internal/resource"
+)
+
+// This file contains the actions:
+//
+// AccountCreatedByAction: the account that created an account
+// AccountCreatedAccountsAction: the accounts created by an account
+
+// AccountCreatedByAction renders the creation of an account, identifying the
+// account that funded it.
+type AccountCreatedByAction struct {
+	Action
+	Address  string
+	Record   history.AccountCreation
+	Resource resource.AccountCreation
+}
+
+// JSON is a method for actions.JSON
+func (action *AccountCreatedByAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecord,
+		action.loadResource,
+		func() {
+			hal.Render(action.W, action.Resource)
+		},
+	)
+}
+
+func (action *AccountCreatedByAction) loadParams() {
+	action.Address = action.GetString("account_id")
+}
+
+func (action *AccountCreatedByAction) loadRecord() {
+	action.Err = action.HistoryQ().
+		AccountCreationByAddress(&action.Record, action.Address)
+}
+
+func (action *AccountCreatedByAction) loadResource() {
+	action.Resource.Populate(action.Ctx, action.Record)
+}
+
+// AccountCreatedAccountsAction renders a page of the creations of the accounts
+// funded by an account, ordered by the operations that created them.
+type AccountCreatedAccountsAction struct {
+	Action
+	Address      string
+	PagingParams db2.PageQuery
+	Records      []history.AccountCreation
+	Page         hal.Page
+}
+
+// JSON is a method for actions.JSON
+func (action *AccountCreatedAccountsAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecords,
+		action.loadPage,
+		func() {
+			hal.Render(action.W, action.Page)
+		},
+	)
+}
+
+func (action *AccountCreatedAccountsAction) loadParams() {
+	action.Address = action.GetString("account_id")
+	action.PagingParams = action.GetPageQuery()
+}
+
+func (action *AccountCreatedAccountsAction) loadRecords() {
+	action.Err = action.HistoryQ().AccountCreations().
+		CreatedBy(action.Address).
+		Page(action.PagingParams).
+		Select(&action.Records)
+}
+
+func (action *AccountCreatedAccountsAction) loadPage() {
+	for _, record := range action.Records {
+		var res resource.AccountCreation
+		res.Populate(action.Ctx, record)
+		action.Page.Add(res)
+	}
+
+	action.Page.FullURL = action.FullURL()
+	action.Page.Limit = action.PagingParams.Limit
+	action.Page.Cursor = action.PagingParams.Cursor
+	action.Page.Order = action.PagingParams.Order
+	action.Page.PopulateLinks()
+}
@@ -0,0 +1,46 @@
+package horizon
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/resource"
+)
+
+func TestAccountCreationActions(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	var result resource.AccountCreation
+	w := ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/created_by")
+	if ht.Assert.Equal(200, w.Code) {
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+		ht.Assert.Equal("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H", result.CreatedBy)
+		ht.Assert.Equal("8589938689", result.PT)
+		ht.Assert.Equal("", result.MergedInto)
+	}
+
+	// the root account was not created by any other account
+	w = ht.Get("/accounts/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H/created_by")
+	ht.Assert.Equal(404, w.Code)
+
+	var records []resource.AccountCreation
+	w = ht.Get("/accounts/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H/created_accounts")
+	if ht.Assert.Equal(200, w.Code) && ht.Assert.PageOf(3, w.Body) {
+		ht.UnmarshalPage(w.Body, &records)
+		ht.Assert.Equal("GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU", records[0].AccountID)
+		ht.Assert.Equal("GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON", records[2].AccountID)
+	}
+
+	w = ht.Get("/accounts/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H/created_accounts?order=desc&limit=1")
+	if ht.Assert.Equal(200, w.Code) && ht.Assert.PageOf(1, w.Body) {
+		ht.UnmarshalPage(w.Body, &records)
+		ht.Assert.Equal("GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON", records[0].AccountID)
+	}
+
+	w = ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/created_accounts")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/xdr"
 )
 
 func TestAccountActions_Show(t *testing.T) {
@@ -28,6 +30,24 @@ func TestAccountActions_Show(t *testing.T) {
 	ht.Assert.Equal(404, w.Code)
 }
 
+func TestAccountActions_ShowRaw(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	address := "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+	w := ht.Get("/accounts/"+address, test.RequestHelperRaw)
+	if ht.Assert.Equal(200, w.Code) {
+		var entry xdr.AccountEntry
+		err := xdr.SafeUnmarshal(w.Body.Bytes(), &entry)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(address, entry.AccountId.Address())
+		ht.Assert.Equal(xdr.SequenceNumber(3), entry.SeqNum)
+	}
+
+	w = ht.Get("/accounts/100?format=xdr")
+	ht.Assert.Equal(404, w.Code)
+}
+
 func TestAccountActions_ShowRegressions(t *testing.T) {
 	ht := StartHTTPTest(t, "base")
 	defer ht.Finish()
@@ -56,3 +76,43 @@ func TestAccountActions_ShowRegressions(t *testing.T) {
 	ht.Assert.Equal(200, w.Code)
 
 }
+
+func TestAccountActions_Batch(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// records are rendered in request order, with missing accounts marked
+	w := ht.Get("/accounts?ids=" +
+		"GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU," +
+		"GBM2VDOBYSWWXWHB7Z6SLBT34MYJEQZFVIZGQ4GPQ7KBY3XNACZH2MXS," +
+		"GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+	)
+	if ht.Assert.Equal(200, w.Code) {
+		var records []struct {
+			resource.Account
+			NotFound bool `json:"not_found"`
+		}
+		ht.UnmarshalPage(w.Body, &records)
+
+		if ht.Assert.Len(records, 3) {
+			ht.Assert.Equal("GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU", records[0].ID)
+			ht.Assert.False(records[0].NotFound)
+			ht.Assert.Equal("8589934593", records[0].Sequence)
+
+			ht.Assert.Equal("GBM2VDOBYSWWXWHB7Z6SLBT34MYJEQZFVIZGQ4GPQ7KBY3XNACZH2MXS", records[1].ID)
+			ht.Assert.True(records[1].NotFound)
+
+			ht.Assert.Equal("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H", records[2].ID)
+			ht.Assert.False(records[2].NotFound)
+			ht.Assert.Equal("3", records[2].Sequence)
+		}
+	}
+
+	// missing ids
+	w = ht.Get("/accounts")
+	ht.Assert.Equal(400, w.Code)
+
+	// invalid address
+	w = ht.Get("/accounts?ids=GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU,100")
+	ht.Assert.Equal(400, w.Code)
+}
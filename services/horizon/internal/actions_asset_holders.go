@@ -0,0 +1,105 @@
+package horizon
+
+import (
+	"fmt"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/xdr"
+)
+
+// This file contains the actions:
+//
+// AssetHoldersAction: pages of the accounts holding an asset
+
+// AssetHoldersAction renders a page of the accounts that hold a trustline to
+// an asset, read from stellar-core's trustlines.
+type AssetHoldersAction struct {
+	Action
+	Asset        xdr.Asset
+	MinBalance   xdr.Int64
+	OrderBy      string
+	PagingParams db2.PageQuery
+	Records      []core.Trustline
+	Page         hal.Page
+}
+
+// JSON is a method for actions.JSON
+func (action *AssetHoldersAction) JSON() {
+	action.Do(
+		action.loadParams,
+		action.loadRecords,
+		action.loadPage,
+		func() {
+			hal.Render(action.W, action.Page)
+		},
+	)
+}
+
+func (action *AssetHoldersAction) loadParams() {
+	code := action.GetString("asset_code")
+	if len(code) == 0 || len(code) > maxAssetCodeLength {
+		action.SetInvalidField("asset_code", fmt.Errorf("length must be between 1 and %d", maxAssetCodeLength))
+		return
+	}
+
+	issuer := action.GetAccountID("asset_issuer")
+	if action.Err != nil {
+		return
+	}
+
+	err := action.Asset.SetCredit(code, issuer)
+	if err != nil {
+		action.SetInvalidField("asset_code", err)
+		return
+	}
+
+	if raw := action.GetString("min_balance"); raw != "" {
+		action.MinBalance, err = amount.Parse(raw)
+		if err != nil {
+			action.SetInvalidField("min_balance", err)
+			return
+		}
+	}
+
+	action.OrderBy = action.GetString("order_by")
+	switch action.OrderBy {
+	case "":
+		action.OrderBy = core.HoldersByAccountID
+	case core.HoldersByAccountID, core.HoldersByBalance:
+	default:
+		action.SetInvalidField("order_by", fmt.Errorf(
+			"must be %s or %s", core.HoldersByAccountID, core.HoldersByBalance,
+		))
+		return
+	}
+
+	action.PagingParams = action.GetPageQuery()
+}
+
+func (action *AssetHoldersAction) loadRecords() {
+	action.Err = action.CoreQ().HoldersForAsset(
+		&action.Records,
+		action.Asset,
+		action.MinBalance,
+		action.OrderBy,
+		action.PagingParams,
+	)
+}
+
+func (action *AssetHoldersAction) loadPage() {
+	for _, record := range action.Records {
+		var res resource.AssetHolder
+		res.Populate(action.Ctx, record, action.OrderBy)
+		action.Page.Add(res)
+	}
+
+	action.Page.FullURL = action.FullURL()
+	action.Page.Limit = action.PagingParams.Limit
+	action.Page.Cursor = action.PagingParams.Cursor
+	action.Page.Order = action.PagingParams.Order
+	action.Page.PopulateLinks()
+}
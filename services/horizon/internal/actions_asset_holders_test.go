@@ -0,0 +1,44 @@
+package horizon
+
+import (
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/resource"
+)
+
+func TestAssetHoldersAction(t *testing.T) {
+	ht := StartHTTPTest(t, "trades")
+	defer ht.Finish()
+
+	const path = "/assets/USD/GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4/holders"
+
+	w := ht.Get(path)
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(2, w.Body)
+	}
+
+	w = ht.Get(path + "?order_by=balance&order=desc&limit=1")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+
+		var records []resource.AssetHolder
+		ht.UnmarshalPage(w.Body, &records)
+		ht.Assert.Equal("GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU", records[0].AccountID)
+		ht.Assert.Equal("450.0000000", records[0].Balance)
+	}
+
+	w = ht.Get(path + "?min_balance=100")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	// invalid parameters
+	w = ht.Get(path + "?order_by=limit")
+	ht.Assert.Equal(400, w.Code)
+
+	w = ht.Get(path + "?min_balance=lots")
+	ht.Assert.Equal(400, w.Code)
+
+	w = ht.Get("/assets/USD/GUNK/holders")
+	ht.Assert.Equal(400, w.Code)
+}
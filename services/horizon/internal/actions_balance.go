@@ -0,0 +1,115 @@
+package horizon
+
+import (
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/xdr"
+)
+
+// This file contains the actions:
+//
+// AccountBalancesAction: the balances of an account as of a given ledger
+// AccountBalanceHistoryAction: the changes to one of an account's balances
+
+// AccountBalancesAction renders the balances held by an account at the close
+// of a ledger, as recorded in the history database.
+type AccountBalancesAction struct {
+	Action
+	Address  string
+	Ledger   int32
+	Records  []history.BalanceSnapshot
+	Resource resource.AccountBalances
+}
+
+// JSON is a method for actions.JSON
+func (action *AccountBalancesAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecords,
+		action.loadResource,
+		func() {
+			hal.Render(action.W, action.Resource)
+		},
+	)
+}
+
+func (action *AccountBalancesAction) loadParams() {
+	action.Address = action.GetString("account_id")
+	action.Ledger = action.GetHistoryLedger("at_ledger")
+}
+
+func (action *AccountBalancesAction) loadRecords() {
+	var account history.Account
+	action.Err = action.HistoryQ().AccountByAddress(&account, action.Address)
+	if action.Err != nil {
+		return
+	}
+
+	action.Err = action.HistoryQ().
+		BalancesAt(&action.Records, account.ID, action.Ledger)
+}
+
+func (action *AccountBalancesAction) loadResource() {
+	action.Resource.Populate(action.Ctx, action.Address, action.Ledger, action.Records)
+}
+
+// AccountBalanceHistoryAction renders a page of the snapshots of a single
+// asset balance of an account, one for each ledger in which it changed.
+type AccountBalanceHistoryAction struct {
+	Action
+	Address      string
+	Asset        xdr.Asset
+	PagingParams db2.PageQuery
+	Records      []history.BalanceSnapshot
+	Page         hal.Page
+}
+
+// JSON is a method for actions.JSON
+func (action *AccountBalanceHistoryAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecords,
+		action.loadPage,
+		func() {
+			hal.Render(action.W, action.Page)
+		},
+	)
+}
+
+func (action *AccountBalanceHistoryAction) loadParams() {
+	action.Address = action.GetString("account_id")
+	action.Asset = action.GetAsset("")
+	action.PagingParams = action.GetPageQuery()
+}
+
+func (action *AccountBalanceHistoryAction) loadRecords() {
+	var account history.Account
+	action.Err = action.HistoryQ().AccountByAddress(&account, action.Address)
+	if action.Err != nil {
+		return
+	}
+
+	action.Err = action.HistoryQ().BalanceSnapshots().
+		ForAccount(account.ID).
+		ForAsset(action.Asset).
+		Page(action.PagingParams).
+		Select(&action.Records)
+}
+
+func (action *AccountBalanceHistoryAction) loadPage() {
+	for _, record := range action.Records {
+		var res resource.BalanceHistoryPoint
+		res.Populate(action.Ctx, record)
+		action.Page.Add(res)
+	}
+
+	action.Page.FullURL = action.FullURL()
+	action.Page.Limit = action.PagingParams.Limit
+	action.Page.Cursor = action.PagingParams.Cursor
+	action.Page.Order = action.PagingParams.Order
+	action.Page.PopulateLinks()
+}
@@ -0,0 +1,85 @@
+package horizon
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/services/horizon/internal/ingest"
+	"github.com/stellar/go/services/horizon/internal/resource"
+)
+
+func TestAccountBalancesAction(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+	reingestBalances(ht)
+
+	// latest ledger
+	w := ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/balances")
+	if ht.Assert.Equal(200, w.Code) {
+		var result resource.AccountBalances
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(int32(3), result.Ledger)
+		if ht.Assert.Len(result.Balances, 1) {
+			ht.Assert.Equal("native", result.Balances[0].Type)
+			ht.Assert.Equal("94.9999900", result.Balances[0].Balance)
+		}
+	}
+
+	// prior to the payment
+	w = ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/balances?at_ledger=2")
+	if ht.Assert.Equal(200, w.Code) {
+		var result resource.AccountBalances
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+		if ht.Assert.Len(result.Balances, 1) {
+			ht.Assert.Equal("100.0000000", result.Balances[0].Balance)
+		}
+	}
+
+	// ledger that has not been ingested
+	w = ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/balances?at_ledger=100")
+	ht.Assert.Equal(400, w.Code)
+
+	// unknown account
+	w = ht.Get("/accounts/GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU/balances")
+	ht.Assert.Equal(404, w.Code)
+}
+
+func TestAccountBalanceHistoryAction(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+	reingestBalances(ht)
+
+	w := ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/balance_history?asset_type=native")
+	if ht.Assert.Equal(200, w.Code) {
+		// account creation, then the payment and its fee
+		ht.Assert.PageOf(2, w.Body)
+
+		var records []resource.BalanceHistoryPoint
+		ht.UnmarshalPage(w.Body, &records)
+		ht.Assert.Equal(int32(2), records[0].Ledger)
+		ht.Assert.Equal("100.0000000", records[0].Change)
+		ht.Assert.Equal(int32(3), records[1].Ledger)
+		ht.Assert.Equal("-5.0000100", records[1].Change)
+		ht.Assert.Equal("94.9999900", records[1].Balance)
+	}
+
+	w = ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/balance_history?asset_type=native&order=desc&limit=1")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	// missing asset
+	w = ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/balance_history")
+	ht.Assert.Equal(400, w.Code)
+}
+
+// reingestBalances reingests the scenario loaded by `ht`, whose history
+// predates balance snapshots, to record them.
+func reingestBalances(ht *HTTPT) {
+	sys := ingest.New(network.TestNetworkPassphrase, "", ht.CoreSession(), ht.HorizonSession())
+	_, err := sys.ReingestAll()
+	ht.Require.NoError(err)
+}
@@ -0,0 +1,89 @@
+package horizon
+
+import (
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/services/horizon/internal/toid"
+)
+
+// This file contains the actions:
+//
+// TransactionChangesAction: ledger entries changed by a transaction
+// OperationChangesAction: ledger entries changed by an operation
+
+// TransactionChangesAction renders the ledger entries changed by a
+// transaction, decoded from its meta.
+type TransactionChangesAction struct {
+	Action
+	Hash     string
+	Record   history.Transaction
+	Resource resource.TransactionChanges
+}
+
+// JSON is a method for actions.JSON
+func (action *TransactionChangesAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecord,
+		action.loadResource,
+		func() {
+			hal.Render(action.W, action.Resource)
+		},
+	)
+}
+
+func (action *TransactionChangesAction) loadParams() {
+	action.Hash = action.GetString("tx_id")
+}
+
+func (action *TransactionChangesAction) loadRecord() {
+	action.Err = action.HistoryQ().TransactionByHash(&action.Record, action.Hash)
+}
+
+func (action *TransactionChangesAction) loadResource() {
+	action.Err = action.Resource.Populate(action.Ctx, action.Record)
+}
+
+// OperationChangesAction renders the ledger entries changed by an operation,
+// decoded from the meta of its transaction.
+type OperationChangesAction struct {
+	Action
+	ID          int64
+	Record      history.Operation
+	Transaction history.Transaction
+	Resource    resource.OperationChanges
+}
+
+// JSON is a method for actions.JSON
+func (action *OperationChangesAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecord,
+		action.loadTransaction,
+		action.loadResource,
+		func() {
+			hal.Render(action.W, action.Resource)
+		},
+	)
+}
+
+func (action *OperationChangesAction) loadParams() {
+	action.ID = action.GetInt64("op_id")
+}
+
+func (action *OperationChangesAction) loadRecord() {
+	action.Err = action.HistoryQ().OperationByID(&action.Record, action.ID)
+}
+
+func (action *OperationChangesAction) loadTransaction() {
+	action.Err = action.HistoryQ().
+		TransactionByHash(&action.Transaction, action.Record.TransactionHash)
+}
+
+func (action *OperationChangesAction) loadResource() {
+	opidx := int(toid.Parse(action.Record.ID).OperationOrder) - 1
+	action.Err = action.Resource.Populate(action.Ctx, action.Transaction, opidx)
+}
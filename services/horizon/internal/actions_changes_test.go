@@ -0,0 +1,72 @@
+package horizon
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/resource"
+)
+
+func TestChangesActions(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// the transaction that funded GCXKG6RN...
+	hash := "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+
+	var txChanges resource.TransactionChanges
+	w := ht.Get("/transactions/" + hash + "/changes")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &txChanges))
+		ht.Assert.Equal(hash, txChanges.Hash)
+
+		// the fee is charged to the source account
+		if ht.Assert.Len(txChanges.FeeChanges, 1) {
+			change := txChanges.FeeChanges[0]
+			ht.Assert.Equal("updated", change.Type)
+			ht.Assert.Equal("account", change.EntryType)
+			ht.Require.NotNil(change.Before)
+			ht.Require.NotNil(change.After)
+			ht.Assert.NotEqual(change.Before.Account.Balance, change.After.Account.Balance)
+			ht.Assert.NotEmpty(change.After.XDR)
+		}
+
+		// the operation creates an account
+		if ht.Assert.Len(txChanges.Operations, 1) {
+			var created *resource.LedgerEntryChange
+			for i, change := range txChanges.Operations[0].Changes {
+				if change.Type == "created" {
+					created = &txChanges.Operations[0].Changes[i]
+				}
+			}
+
+			if ht.Assert.NotNil(created) {
+				ht.Assert.Nil(created.Before)
+				ht.Assert.Equal("account", created.After.Type)
+				ht.Assert.Equal(
+					"GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU",
+					created.After.Account.AccountID,
+				)
+			}
+		}
+	}
+
+	// a single operation
+	if len(txChanges.Operations) == 1 {
+		expected := txChanges.Operations[0]
+
+		var opChanges resource.OperationChanges
+		w = ht.Get("/operations/" + expected.ID + "/changes")
+		if ht.Assert.Equal(200, w.Code) {
+			ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &opChanges))
+			ht.Assert.Equal("8589938689", opChanges.ID)
+			ht.Assert.Equal(expected, opChanges)
+		}
+	}
+
+	w = ht.Get("/transactions/not_real/changes")
+	ht.Assert.Equal(404, w.Code)
+
+	w = ht.Get("/operations/100/changes")
+	ht.Assert.Equal(404, w.Code)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/render/export"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
 	"github.com/stellar/go/services/horizon/internal/render/sse"
 	"github.com/stellar/go/services/horizon/internal/resource"
@@ -24,6 +25,9 @@ type EffectIndexAction struct {
 	LedgerFilter      int32
 	TransactionFilter string
 	OperationFilter   int64
+	TypeFilter        []history.EffectType
+	FromLedger        int32
+	ToLedger          int32
 
 	PagingParams db2.PageQuery
 	Records      []history.Effect
@@ -76,6 +80,35 @@ func (action *EffectIndexAction) SSE(stream sse.Stream) {
 	)
 }
 
+// Export is a method for actions.Export
+func (action *EffectIndexAction) Export(stream export.Stream) {
+	action.Setup(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.ValidateCursorWithinHistory,
+		func() {
+			action.PrepareExport(stream, &action.PagingParams, resource.EffectColumns)
+		},
+	)
+	action.Records = nil
+	action.Do(
+		action.loadRecords,
+		func() {
+			for _, record := range action.Records {
+				var res hal.Pageable
+				res, action.Err = resource.NewEffect(action.Ctx, record)
+				if action.Err != nil {
+					return
+				}
+
+				stream.Send(res)
+			}
+
+			action.AdvanceExport(stream, &action.PagingParams, len(action.Records))
+		},
+	)
+}
+
 func (action *EffectIndexAction) loadParams() {
 	action.ValidateCursor()
 	action.PagingParams = action.GetPageQuery()
@@ -83,6 +116,8 @@ func (action *EffectIndexAction) loadParams() {
 	action.LedgerFilter = action.GetInt32("ledger_id")
 	action.TransactionFilter = action.GetString("tx_id")
 	action.OperationFilter = action.GetInt64("op_id")
+	action.TypeFilter = action.GetEffectTypes("type")
+	action.FromLedger, action.ToLedger = action.GetLedgerRange()
 }
 
 // loadRecords populates action.Records
@@ -100,6 +135,11 @@ func (action *EffectIndexAction) loadRecords() {
 		effects.ForTransaction(action.TransactionFilter)
 	}
 
+	if len(action.TypeFilter) > 0 {
+		effects.OfTypes(action.TypeFilter...)
+	}
+
+	effects.ForLedgerRange(action.FromLedger, action.ToLedger)
 	action.Err = effects.Page(action.PagingParams).Select(&action.Records)
 }
 
@@ -63,6 +63,36 @@ func TestEffectActions_Index(t *testing.T) {
 		ht.Assert.PageOf(3, w.Body)
 	}
 
+	// filtered by type
+	w = ht.Get("/effects?type=account_created")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	w = ht.Get("/effects?type=account_credited,account_debited")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(5, w.Body)
+	}
+
+	w = ht.Get("/accounts/GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2/effects?type=account_created")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/effects?type=bogus")
+	ht.Assert.Equal(400, w.Code)
+
+	// filtered by ledger range
+	w = ht.Get("/effects?limit=20&from_ledger=3")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(2, w.Body)
+	}
+
+	w = ht.Get("/effects?limit=20&to_ledger=2")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(9, w.Body)
+	}
+
 	// before history
 	ht.ReapHistory(1)
 	w = ht.Get("/effects?order=desc&cursor=8589938689-1")
@@ -0,0 +1,34 @@
+package horizon
+
+import (
+	"github.com/stellar/go/services/horizon/internal/feestats"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/services/horizon/internal/resource"
+)
+
+// This file contains the actions:
+//
+// FeeStatsAction: fee statistics for the most recently closed ledgers
+
+// FeeStatsAction renders the fees paid per operation by the transactions in
+// the most recently ingested ledgers.  The statistics are computed from the
+// history database by the app's tick and served from a cached snapshot.
+type FeeStatsAction struct {
+	Action
+	Resource resource.FeeStats
+}
+
+// JSON is a method for actions.JSON
+func (action *FeeStatsAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadResource,
+		func() {
+			hal.Render(action.W, action.Resource)
+		},
+	)
+}
+
+func (action *FeeStatsAction) loadResource() {
+	action.Resource.Populate(action.Ctx, feestats.CurrentState())
+}
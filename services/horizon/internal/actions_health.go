@@ -0,0 +1,48 @@
+package horizon
+
+import (
+	"time"
+
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/ingest"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/services/horizon/internal/resource"
+)
+
+// This file contains the actions:
+//
+// HealthGapsAction: gaps in the history database
+
+// HealthGapsAction renders the gaps in horizon's history database: ranges of
+// missing or incomplete ledgers, and ledgers whose hashes do not form a chain.
+// When this horizon's ingester searches for gaps, the gaps found by its most
+// recent search are rendered; otherwise the result of a search made for an
+// earlier request is rendered until it expires.
+type HealthGapsAction struct {
+	Action
+	CheckedAt time.Time
+	Gaps      []history.LedgerGap
+	Resource  resource.LedgerGaps
+}
+
+// JSON is a method for actions.JSON
+func (action *HealthGapsAction) JSON() {
+	action.Do(
+		action.loadRecords,
+		action.loadResource,
+		func() {
+			hal.Render(action.W, action.Resource)
+		},
+	)
+}
+
+func (action *HealthGapsAction) loadRecords() {
+	var report ingest.GapReport
+	report, action.Err = action.App.Gaps()
+	action.CheckedAt = report.CheckedAt
+	action.Gaps = report.Gaps
+}
+
+func (action *HealthGapsAction) loadResource() {
+	action.Resource.Populate(action.Ctx, action.CheckedAt, action.Gaps)
+}
@@ -0,0 +1,51 @@
+package horizon
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/services/horizon/internal/resource"
+)
+
+func TestHealthGapsAction(t *testing.T) {
+	ht := StartHTTPTest(t, "kahuna")
+	defer ht.Finish()
+
+	w := ht.Get("/health/gaps")
+	if ht.Assert.Equal(200, w.Code) {
+		var result resource.LedgerGaps
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &result))
+		ht.Assert.Empty(result.Gaps)
+		ht.Assert.False(result.CheckedAt.IsZero())
+	}
+
+	_, err := ht.HorizonSession().ExecRaw(
+		`DELETE FROM history_ledgers WHERE sequence BETWEEN 10 AND 12`,
+	)
+	ht.Require.NoError(err)
+	_, err = ht.HorizonSession().ExecRaw(
+		`UPDATE history_ledgers SET previous_ledger_hash = ? WHERE sequence = 30`,
+		"0000000000000000000000000000000000000000000000000000000000000000",
+	)
+	ht.Require.NoError(err)
+
+	// the result of the previous search is served until it expires
+	w = ht.Get("/health/gaps")
+	if ht.Assert.Equal(200, w.Code) {
+		var result resource.LedgerGaps
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &result))
+		ht.Assert.Empty(result.Gaps)
+	}
+
+	ht.App.gaps.CheckedAt = time.Now().Add(-gapsCacheTTL)
+	w = ht.Get("/health/gaps")
+	if ht.Assert.Equal(200, w.Code) {
+		var result resource.LedgerGaps
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &result))
+		ht.Assert.Equal([]resource.LedgerGap{
+			{Start: 10, End: 12, Reason: "missing"},
+			{Start: 29, End: 30, Reason: "hash_mismatch"},
+		}, result.Gaps)
+	}
+}
@@ -2,6 +2,7 @@ package horizon
 
 import (
 	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/ledger"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
@@ -86,8 +87,9 @@ func (action *LedgerIndexAction) loadPage() {
 // LedgerShowAction renders a ledger found by its sequence number.
 type LedgerShowAction struct {
 	Action
-	Sequence int32
-	Record   history.Ledger
+	Sequence   int32
+	Record     history.Ledger
+	CoreRecord core.LedgerHeader
 }
 
 // JSON is a method for actions.JSON
@@ -105,6 +107,17 @@ func (action *LedgerShowAction) JSON() {
 	)
 }
 
+// Raw is a method for actions.Raw
+func (action *LedgerShowAction) Raw() {
+	action.Do(
+		action.loadParams,
+		action.loadCoreRecord,
+		func() {
+			action.WriteXDR(action.CoreRecord.Data)
+		},
+	)
+}
+
 func (action *LedgerShowAction) loadParams() {
 	action.Sequence = action.GetInt32("id")
 }
@@ -114,6 +127,11 @@ func (action *LedgerShowAction) loadRecord() {
 		LedgerBySequence(&action.Record, action.Sequence)
 }
 
+func (action *LedgerShowAction) loadCoreRecord() {
+	action.Err = action.CoreQ().
+		LedgerHeaderBySequence(&action.CoreRecord, action.Sequence)
+}
+
 func (action *LedgerShowAction) verifyWithinHistory() {
 	if action.Sequence < ledger.CurrentState().HistoryElder {
 		action.Err = &problem.BeforeHistory
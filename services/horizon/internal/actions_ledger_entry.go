@@ -0,0 +1,118 @@
+package horizon
+
+import (
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/xdr"
+)
+
+// This file contains the actions:
+//
+// LedgerEntryShowAction: the current state of any ledger entry, by key
+// TrustlineShowAction: the current state of a single trustline
+
+// LedgerEntryShowAction renders the current state of the ledger entry whose
+// base64 encoded xdr.LedgerKey is the `key` query parameter.
+type LedgerEntryShowAction struct {
+	Action
+	Key      xdr.LedgerKey
+	Record   xdr.LedgerEntry
+	Resource resource.LedgerEntry
+}
+
+// JSON is a method for actions.JSON
+func (action *LedgerEntryShowAction) JSON() {
+	action.Do(
+		action.loadParams,
+		action.loadRecord,
+		action.loadResource,
+		func() {
+			hal.Render(action.W, action.Resource)
+		},
+	)
+}
+
+// Raw is a method for actions.Raw
+func (action *LedgerEntryShowAction) Raw() {
+	action.Do(
+		action.loadParams,
+		action.loadRecord,
+		func() {
+			action.WriteXDR(action.Record)
+		},
+	)
+}
+
+func (action *LedgerEntryShowAction) loadParams() {
+	key := action.GetString("key")
+	if action.Err != nil {
+		return
+	}
+
+	err := xdr.SafeUnmarshalBase64(key, &action.Key)
+	if err != nil {
+		action.SetInvalidField("key", err)
+	}
+}
+
+func (action *LedgerEntryShowAction) loadRecord() {
+	action.Err = action.CoreQ().LedgerEntryByKey(&action.Record, action.Key)
+}
+
+func (action *LedgerEntryShowAction) loadResource() {
+	action.Err = action.Resource.Populate(action.Ctx, action.Record)
+}
+
+// TrustlineShowAction renders the current state of the trustline held by an
+// account to a credit asset.
+type TrustlineShowAction struct {
+	Action
+	AccountID xdr.AccountId
+	Asset     xdr.Asset
+	Record    xdr.LedgerEntry
+	Resource  resource.LedgerEntry
+}
+
+// JSON is a method for actions.JSON
+func (action *TrustlineShowAction) JSON() {
+	action.Do(
+		action.loadParams,
+		action.loadRecord,
+		action.loadResource,
+		func() {
+			hal.Render(action.W, action.Resource)
+		},
+	)
+}
+
+// Raw is a method for actions.Raw
+func (action *TrustlineShowAction) Raw() {
+	action.Do(
+		action.loadParams,
+		action.loadRecord,
+		func() {
+			action.WriteXDR(action.Record)
+		},
+	)
+}
+
+func (action *TrustlineShowAction) loadParams() {
+	action.AccountID = action.GetAccountID("account_id")
+	action.Asset = action.GetCreditAsset("asset_code", "asset_issuer")
+}
+
+func (action *TrustlineShowAction) loadRecord() {
+	key := xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeTrustline,
+		TrustLine: &xdr.LedgerKeyTrustLine{
+			AccountId: action.AccountID,
+			Asset:     action.Asset,
+		},
+	}
+
+	action.Err = action.CoreQ().LedgerEntryByKey(&action.Record, key)
+}
+
+func (action *TrustlineShowAction) loadResource() {
+	action.Err = action.Resource.Populate(action.Ctx, action.Record)
+}
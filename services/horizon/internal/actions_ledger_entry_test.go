@@ -0,0 +1,105 @@
+package horizon
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/xdr"
+)
+
+func TestLedgerEntryActions_Show(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	address := "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+
+	var aid xdr.AccountId
+	ht.Require.NoError(aid.SetAddress(address))
+	key, err := xdr.MarshalBase64(xdr.LedgerKey{
+		Type:    xdr.LedgerEntryTypeAccount,
+		Account: &xdr.LedgerKeyAccount{AccountId: aid},
+	})
+	ht.Require.NoError(err)
+	path := "/ledger_entries?key=" + url.QueryEscape(key)
+
+	w := ht.Get(path)
+	if ht.Assert.Equal(200, w.Code) {
+		var result resource.LedgerEntry
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &result))
+		ht.Assert.Equal("account", result.Type)
+		if ht.Assert.NotNil(result.Account) {
+			ht.Assert.Equal(address, result.Account.AccountID)
+			ht.Assert.Equal("3", result.Account.Sequence)
+		}
+		ht.Assert.NotEmpty(result.XDR)
+	}
+
+	w = ht.Get(path, test.RequestHelperRaw)
+	if ht.Assert.Equal(200, w.Code) {
+		var entry xdr.LedgerEntry
+		ht.Require.NoError(xdr.SafeUnmarshal(w.Body.Bytes(), &entry))
+		account := entry.Data.MustAccount()
+		ht.Assert.Equal(address, account.AccountId.Address())
+	}
+
+	// missing entry
+	key, err = xdr.MarshalBase64(xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeData,
+		Data: &xdr.LedgerKeyData{AccountId: aid, DataName: "missing"},
+	})
+	ht.Require.NoError(err)
+	w = ht.Get("/ledger_entries?key=" + url.QueryEscape(key))
+	ht.Assert.Equal(404, w.Code)
+
+	// invalid key
+	w = ht.Get("/ledger_entries?key=wat")
+	ht.Assert.Equal(400, w.Code)
+
+	w = ht.Get("/ledger_entries")
+	ht.Assert.Equal(400, w.Code)
+}
+
+func TestTrustlineActions_Show(t *testing.T) {
+	ht := StartHTTPTest(t, "trades")
+	defer ht.Finish()
+
+	holder := "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2"
+	issuer := "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4"
+	path := "/trustlines/" + holder + "/USD/" + issuer
+
+	w := ht.Get(path)
+	if ht.Assert.Equal(200, w.Code) {
+		var result resource.LedgerEntry
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &result))
+		ht.Assert.Equal("trustline", result.Type)
+		if ht.Assert.NotNil(result.Trustline) {
+			ht.Assert.Equal(holder, result.Trustline.AccountID)
+			ht.Assert.Equal("USD", result.Trustline.Asset.Code)
+			ht.Assert.Equal(issuer, result.Trustline.Asset.Issuer)
+			ht.Assert.True(result.Trustline.Authorized)
+		}
+	}
+
+	w = ht.Get(path, test.RequestHelperRaw)
+	if ht.Assert.Equal(200, w.Code) {
+		var entry xdr.LedgerEntry
+		ht.Require.NoError(xdr.SafeUnmarshal(w.Body.Bytes(), &entry))
+		tl := entry.Data.MustTrustLine()
+		ht.Assert.Equal(holder, tl.AccountId.Address())
+	}
+
+	// no such trustline
+	w = ht.Get("/trustlines/" + holder + "/EUR/" + issuer)
+	ht.Assert.Equal(404, w.Code)
+
+	// invalid asset code
+	w = ht.Get("/trustlines/" + holder + "/ABCDEFGHIJKLM/" + issuer)
+	ht.Assert.Equal(400, w.Code)
+
+	// invalid issuer
+	w = ht.Get("/trustlines/" + holder + "/USD/wat")
+	ht.Assert.Equal(400, w.Code)
+}
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/xdr"
 )
 
 func TestLedgerActions_Index(t *testing.T) {
@@ -48,3 +50,19 @@ func TestLedgerActions_Show(t *testing.T) {
 	w = ht.Get("/ledgers/1")
 	ht.Assert.Equal(410, w.Code)
 }
+
+func TestLedgerActions_ShowRaw(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/ledgers/2", test.RequestHelperRaw)
+	if ht.Assert.Equal(200, w.Code) {
+		var header xdr.LedgerHeader
+		err := xdr.SafeUnmarshal(w.Body.Bytes(), &header)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(xdr.Uint32(2), header.LedgerSeq)
+	}
+
+	w = ht.Get("/ledgers/100", test.RequestHelperRaw)
+	ht.Assert.Equal(404, w.Code)
+}
@@ -3,12 +3,103 @@ package horizon
 import (
 	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
 	"github.com/stellar/go/services/horizon/internal/render/sse"
 	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/xdr"
 )
 
 // This file contains the actions:
+//
+// OfferIndexAction: pages of offers, filtered by asset pair and seller
+// OffersByAccountAction: pages of offers for a given account
+// OfferShowAction: details for a single offer, live or historical
+// OfferHistoryAction: pages of events in the lifecycle of an offer
+
+// OfferIndexAction renders a page of offer resources, optionally filtered by
+// the assets being sold and bought and by the account that made the offers.
+// These offers are present in the ledger as of the latest validated ledger.
+type OfferIndexAction struct {
+	Action
+	SellingFilter    xdr.Asset
+	HasSellingFilter bool
+	BuyingFilter     xdr.Asset
+	HasBuyingFilter  bool
+	SellerFilter     string
+	PageQuery        db2.PageQuery
+	Records          []core.Offer
+	Page             hal.Page
+}
+
+// JSON is a method for actions.JSON
+func (action *OfferIndexAction) JSON() {
+	action.Do(
+		action.loadParams,
+		action.loadRecords,
+		action.loadPage,
+		func() {
+			hal.Render(action.W, action.Page)
+		},
+	)
+}
+
+// SSE is a method for actions.SSE
+func (action *OfferIndexAction) SSE(stream sse.Stream) {
+	action.Do(
+		action.loadParams,
+		action.loadRecords,
+		func() {
+			stream.SetLimit(int(action.PageQuery.Limit))
+			for _, record := range action.Records[stream.SentCount():] {
+				var res resource.Offer
+				res.Populate(action.Ctx, record)
+				stream.Send(sse.Event{ID: res.PagingToken(), Data: res})
+			}
+		},
+	)
+}
+
+func (action *OfferIndexAction) loadParams() {
+	action.PageQuery = action.GetPageQuery()
+	action.SellingFilter, action.HasSellingFilter = action.MaybeGetAsset("selling_")
+	action.BuyingFilter, action.HasBuyingFilter = action.MaybeGetAsset("buying_")
+	if action.GetString("seller") != "" {
+		action.SellerFilter = action.GetAddress("seller")
+	}
+}
+
+func (action *OfferIndexAction) loadRecords() {
+	var selling, buying *xdr.Asset
+	if action.HasSellingFilter {
+		selling = &action.SellingFilter
+	}
+	if action.HasBuyingFilter {
+		buying = &action.BuyingFilter
+	}
+
+	action.Err = action.CoreQ().Offers(
+		&action.Records,
+		selling,
+		buying,
+		action.SellerFilter,
+		action.PageQuery,
+	)
+}
+
+func (action *OfferIndexAction) loadPage() {
+	for _, record := range action.Records {
+		var res resource.Offer
+		res.Populate(action.Ctx, record)
+		action.Page.Add(res)
+	}
+
+	action.Page.FullURL = action.FullURL()
+	action.Page.Limit = action.PageQuery.Limit
+	action.Page.Cursor = action.PageQuery.Cursor
+	action.Page.Order = action.PageQuery.Order
+	action.Page.PopulateLinks()
+}
 
 // OffersByAccountAction renders a page of offer resources, for a given
 // account.  These offers are present in the ledger as of the latest validated
@@ -75,3 +166,100 @@ func (action *OffersByAccountAction) loadPage() {
 	action.Page.Order = action.PageQuery.Order
 	action.Page.PopulateLinks()
 }
+
+// OfferShowAction renders a single offer found by its id.  Offers that are
+// present in the ledger are rendered using their current state, otherwise the
+// offer's last known state is loaded from its ingested history.
+type OfferShowAction struct {
+	Action
+	OfferID       int64
+	CoreRecord    core.Offer
+	HistoryRecord history.OfferEvent
+	Resource      resource.Offer
+}
+
+// JSON is a method for actions.JSON
+func (action *OfferShowAction) JSON() {
+	action.Do(
+		action.loadParams,
+		action.loadRecord,
+		func() {
+			hal.Render(action.W, action.Resource)
+		},
+	)
+}
+
+func (action *OfferShowAction) loadParams() {
+	action.OfferID = action.GetInt64("id")
+}
+
+func (action *OfferShowAction) loadRecord() {
+	action.Err = action.CoreQ().OfferByID(&action.CoreRecord, action.OfferID)
+	if action.Err == nil {
+		action.Resource.Populate(action.Ctx, action.CoreRecord)
+		return
+	}
+
+	// Fall back to the offer's history when it is no longer on the books. When
+	// no history exists either, the resulting sql.ErrNoRows renders as a 404.
+	if !action.CoreQ().NoRows(action.Err) {
+		return
+	}
+
+	action.Err = action.HistoryQ().LatestOfferEvent(&action.HistoryRecord, action.OfferID)
+	if action.Err != nil {
+		return
+	}
+
+	action.Resource.PopulateFromEvent(action.Ctx, action.HistoryRecord)
+}
+
+// OfferHistoryAction renders a page of offer event resources, describing the
+// lifecycle of a single offer.
+type OfferHistoryAction struct {
+	Action
+	OfferID   int64
+	PageQuery db2.PageQuery
+	Records   []history.OfferEvent
+	Page      hal.Page
+}
+
+// JSON is a method for actions.JSON
+func (action *OfferHistoryAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.ValidateCursorWithinHistory,
+		action.loadRecords,
+		action.loadPage,
+		func() {
+			hal.Render(action.W, action.Page)
+		},
+	)
+}
+
+func (action *OfferHistoryAction) loadParams() {
+	action.PageQuery = action.GetPageQuery()
+	action.OfferID = action.GetInt64("offer_id")
+}
+
+func (action *OfferHistoryAction) loadRecords() {
+	action.Err = action.HistoryQ().OfferEvents().
+		ForOffer(action.OfferID).
+		Page(action.PageQuery).
+		Select(&action.Records)
+}
+
+func (action *OfferHistoryAction) loadPage() {
+	for _, record := range action.Records {
+		var res resource.OfferEvent
+		res.Populate(action.Ctx, record)
+		action.Page.Add(res)
+	}
+
+	action.Page.FullURL = action.FullURL()
+	action.Page.Limit = action.PageQuery.Limit
+	action.Page.Cursor = action.PageQuery.Cursor
+	action.Page.Order = action.PageQuery.Order
+	action.Page.PopulateLinks()
+}
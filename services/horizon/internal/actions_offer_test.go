@@ -1,7 +1,13 @@
 package horizon
 
 import (
+	"encoding/json"
+	"net/url"
 	"testing"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/services/horizon/internal/ingest"
+	"github.com/stellar/go/services/horizon/internal/resource"
 )
 
 func TestOfferActions_Index(t *testing.T) {
@@ -16,3 +22,97 @@ func TestOfferActions_Index(t *testing.T) {
 		ht.Assert.PageOf(3, w.Body)
 	}
 }
+
+func TestOfferActions_All(t *testing.T) {
+	ht := StartHTTPTest(t, "trades")
+	defer ht.Finish()
+
+	w := ht.Get("/offers")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(4, w.Body)
+	}
+
+	// filtered by asset pair
+	q := make(url.Values)
+	q.Add("selling_asset_type", "credit_alphanum4")
+	q.Add("selling_asset_code", "EUR")
+	q.Add("selling_asset_issuer", "GCQPYGH4K57XBDENKKX55KDTWOTK5WDWRQOH2LHEDX3EKVIQRLMESGBG")
+	q.Add("buying_asset_type", "credit_alphanum4")
+	q.Add("buying_asset_code", "USD")
+	q.Add("buying_asset_issuer", "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
+	w = ht.GetWithParams("/offers", q)
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	// filtered by seller
+	w = ht.Get("/offers?seller=GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU")
+	if ht.Assert.Equal(200, w.Code) {
+		var records []resource.Offer
+		ht.UnmarshalPage(w.Body, &records)
+		if ht.Assert.Len(records, 1) {
+			ht.Assert.Equal(int64(4), records[0].ID)
+		}
+	}
+
+	// invalid seller
+	w = ht.Get("/offers?seller=GBAD")
+	ht.Assert.Equal(400, w.Code)
+}
+
+func TestOfferActions_Show(t *testing.T) {
+	ht := StartHTTPTest(t, "trades")
+	defer ht.Finish()
+
+	// live offer
+	w := ht.Get("/offers/2")
+	if ht.Assert.Equal(200, w.Code) {
+		var result resource.Offer
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(int64(2), result.ID)
+		ht.Assert.Equal("90.0000000", result.Amount)
+		ht.Assert.Equal("GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2", result.Seller)
+	}
+
+	// missing offer
+	w = ht.Get("/offers/100")
+	ht.Assert.Equal(404, w.Code)
+}
+
+func TestOfferActions_History(t *testing.T) {
+	ht := StartHTTPTest(t, "trades")
+	defer ht.Finish()
+
+	// the scenario's history predates offer events, so reingest it to record
+	// them.
+	sys := ingest.New(network.TestNetworkPassphrase, "", ht.CoreSession(), ht.HorizonSession())
+	_, err := sys.ReingestAll()
+	ht.Require.NoError(err)
+
+	history := func(path string) []string {
+		w := ht.Get(path)
+		if !ht.Assert.Equal(200, w.Code) {
+			return nil
+		}
+
+		var records []resource.OfferEvent
+		ht.UnmarshalPage(w.Body, &records)
+
+		types := []string{}
+		for _, record := range records {
+			types = append(types, record.Type)
+		}
+		return types
+	}
+
+	// offer 1 is partially filled by the offer that follows it
+	ht.Assert.Equal([]string{"created", "partially_filled"}, history("/offers/1/history"))
+	ht.Assert.Equal([]string{"created"}, history("/offers/2/history"))
+	ht.Assert.Equal([]string{"partially_filled", "created"}, history("/offers/1/history?order=desc"))
+	ht.Assert.Empty(history("/offers/100/history"))
+
+	// invalid cursor
+	w := ht.Get("/offers/2/history?cursor=bad")
+	ht.Assert.Equal(400, w.Code)
+}
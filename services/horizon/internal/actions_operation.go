@@ -3,21 +3,25 @@ package horizon
 import (
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/ledger"
+	"github.com/stellar/go/services/horizon/internal/render/export"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
 	"github.com/stellar/go/services/horizon/internal/render/problem"
 	"github.com/stellar/go/services/horizon/internal/render/sse"
 	"github.com/stellar/go/services/horizon/internal/resource"
 	"github.com/stellar/go/services/horizon/internal/toid"
+	"github.com/stellar/go/xdr"
 )
 
 // This file contains the actions:
 //
 // OperationIndexAction: pages of operations
 // OperationShowAction: single operation by id
+// OperationBatchAction: operations for a list of ids
 
 // OperationIndexAction renders a page of operations resources, identified by
 // a normal page query and optionally filtered by an account, ledger, or
@@ -27,6 +31,9 @@ type OperationIndexAction struct {
 	LedgerFilter      int32
 	AccountFilter     string
 	TransactionFilter string
+	TypeFilter        []xdr.OperationType
+	FromLedger        int32
+	ToLedger          int32
 	PagingParams      db2.PageQuery
 	Records           []history.Operation
 	Ledgers           history.LedgerCache
@@ -85,11 +92,50 @@ func (action *OperationIndexAction) SSE(stream sse.Stream) {
 
 }
 
+// Export is a method for actions.Export
+func (action *OperationIndexAction) Export(stream export.Stream) {
+	action.Setup(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.ValidateCursorWithinHistory,
+		func() {
+			action.PrepareExport(stream, &action.PagingParams, resource.OperationColumns)
+		},
+	)
+	action.Records = nil
+	action.Do(
+		action.loadRecords,
+		action.loadLedgers,
+		func() {
+			for _, record := range action.Records {
+				ledger, found := action.Ledgers.Records[record.LedgerSequence()]
+				if !found {
+					msg := fmt.Sprintf("could not find ledger data for sequence %d", record.LedgerSequence())
+					action.Err = errors.New(msg)
+					return
+				}
+
+				var res hal.Pageable
+				res, action.Err = resource.NewOperation(action.Ctx, record, ledger)
+				if action.Err != nil {
+					return
+				}
+
+				stream.Send(res)
+			}
+
+			action.AdvanceExport(stream, &action.PagingParams, len(action.Records))
+		},
+	)
+}
+
 func (action *OperationIndexAction) loadParams() {
 	action.ValidateCursorAsDefault()
 	action.AccountFilter = action.GetString("account_id")
 	action.LedgerFilter = action.GetInt32("ledger_id")
 	action.TransactionFilter = action.GetString("tx_id")
+	action.TypeFilter = action.GetOperationTypes("type")
+	action.FromLedger, action.ToLedger = action.GetLedgerRange()
 	action.PagingParams = action.GetPageQuery()
 }
 
@@ -106,6 +152,11 @@ func (action *OperationIndexAction) loadRecords() {
 		ops.ForTransaction(action.TransactionFilter)
 	}
 
+	if len(action.TypeFilter) > 0 {
+		ops.OfTypes(action.TypeFilter...)
+	}
+
+	ops.ForLedgerRange(action.FromLedger, action.ToLedger)
 	action.Err = ops.Page(action.PagingParams).Select(&action.Records)
 }
 
@@ -189,3 +240,87 @@ func (action *OperationShowAction) verifyWithinHistory() {
 		action.Err = &problem.BeforeHistory
 	}
 }
+
+// OperationBatchAction renders the operations found by a list of ids, in the
+// order they were requested.  An id that cannot be found is rendered as a
+// resource.NotFound.
+type OperationBatchAction struct {
+	Action
+	IDs     []int64
+	Records []history.Operation
+	Ledgers history.LedgerCache
+	Page    hal.BasePage
+}
+
+// JSON is a method for actions.JSON
+func (action *OperationBatchAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecords,
+		action.loadLedgers,
+		action.loadPage,
+		func() {
+			hal.Render(action.W, action.Page)
+		},
+	)
+}
+
+func (action *OperationBatchAction) loadParams() {
+	ids := action.GetStringList("ids", db2.MaxPageSize)
+
+	action.IDs = make([]int64, 0, len(ids))
+	for _, id := range ids {
+		parsed, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			action.SetInvalidField("ids", err)
+			return
+		}
+		action.IDs = append(action.IDs, parsed)
+	}
+}
+
+func (action *OperationBatchAction) loadRecords() {
+	action.Err = action.HistoryQ().OperationsByIDs(&action.Records, action.IDs)
+}
+
+// loadLedgers populates the ledger cache for this action
+func (action *OperationBatchAction) loadLedgers() {
+	for _, op := range action.Records {
+		action.Ledgers.Queue(op.LedgerSequence())
+	}
+
+	action.Err = action.Ledgers.Load(action.HistoryQ())
+}
+
+func (action *OperationBatchAction) loadPage() {
+	records := map[int64]history.Operation{}
+	for _, record := range action.Records {
+		records[record.ID] = record
+	}
+
+	action.Page.Init()
+	for _, id := range action.IDs {
+		record, ok := records[id]
+		if !ok {
+			var res resource.NotFound
+			res.Populate(strconv.FormatInt(id, 10))
+			action.Page.Add(res)
+			continue
+		}
+
+		ledger, found := action.Ledgers.Records[record.LedgerSequence()]
+		if !found {
+			msg := fmt.Sprintf("could not find ledger data for sequence %d", record.LedgerSequence())
+			action.Err = errors.New(msg)
+			return
+		}
+
+		var res hal.Pageable
+		res, action.Err = resource.NewOperation(action.Ctx, record, ledger)
+		if action.Err != nil {
+			return
+		}
+		action.Page.Add(res)
+	}
+}
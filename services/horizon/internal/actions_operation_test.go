@@ -72,6 +72,60 @@ func TestOperationActions_Index(t *testing.T) {
 	// missing ledger
 	w = ht.Get("/ledgers/100/operations")
 	ht.Assert.Equal(404, w.Code)
+
+	// filtered by type
+	w = ht.Get("/operations?type=payment")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/operations?type=create_account,payment")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(4, w.Body)
+	}
+
+	w = ht.Get("/ledgers/2/operations?type=payment")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	w = ht.Get("/operations?type=bogus")
+	ht.Assert.Equal(400, w.Code)
+
+	// filtered by ledger range
+	w = ht.Get("/operations?from_ledger=3")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/operations?from_ledger=2&to_ledger=2")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	w = ht.Get("/operations?to_ledger=-1")
+	ht.Assert.Equal(400, w.Code)
+
+	// filtered by time range; ledger 3 closed at 2017-11-30T02:23:16Z
+	w = ht.Get("/operations?start_time=1512008596000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/operations?end_time=1512008596000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	w = ht.Get("/operations?start_time=1512008597000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	// time range ending before history
+	ht.ReapHistory(1)
+	w = ht.Get("/operations?end_time=1512008596000")
+	ht.Assert.Equal(410, w.Code)
 }
 
 func TestOperationActions_Show(t *testing.T) {
@@ -98,6 +152,33 @@ func TestOperationActions_Show(t *testing.T) {
 	ht.Assert.Equal(410, w.Code)
 }
 
+func TestOperationActions_Batch(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/operations?ids=9589938689,8589938689")
+	if ht.Assert.Equal(200, w.Code) {
+		var records []struct {
+			operations.Base
+			NotFound bool `json:"not_found"`
+		}
+		ht.UnmarshalPage(w.Body, &records)
+
+		if ht.Assert.Len(records, 2) {
+			ht.Assert.Equal("9589938689", records[0].ID)
+			ht.Assert.True(records[0].NotFound)
+
+			ht.Assert.Equal("8589938689", records[1].ID)
+			ht.Assert.False(records[1].NotFound)
+			ht.Assert.Equal("2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d", records[1].TransactionHash)
+		}
+	}
+
+	// invalid id
+	w = ht.Get("/operations?ids=8589938689,foo")
+	ht.Assert.Equal(400, w.Code)
+}
+
 func TestOperationActions_Regressions(t *testing.T) {
 	ht := StartHTTPTest(t, "base")
 	defer ht.Finish()
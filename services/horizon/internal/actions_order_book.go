@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/ingest"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
 	"github.com/stellar/go/services/horizon/internal/render/problem"
 	"github.com/stellar/go/services/horizon/internal/render/sse"
@@ -28,16 +30,7 @@ func (action *OrderBookShowAction) LoadQuery() {
 	action.Limit = action.GetLimit("limit", 20, 200)
 
 	if action.Err != nil {
-		action.Err = &problem.P{
-			Type:   "invalid_order_book",
-			Title:  "Invalid Order Book Parameters",
-			Status: http.StatusBadRequest,
-			Detail: "The parameters that specify what order book to view are invalid in some way. " +
-				"Please ensure that your type parameters (selling_asset_type and buying_asset_type) are one the " +
-				"following valid values: native, credit_alphanum4, credit_alphanum12.  Also ensure that you " +
-				"have specified selling_asset_code and selling_asset_issuer if selling_asset_type is not 'native', as well " +
-				"as buying_asset_code and buying_asset_issuer if buying_asset_type is not 'native'",
-		}
+		action.Err = &invalidOrderBookProblem
 	}
 }
 
@@ -82,3 +75,90 @@ func (action *OrderBookShowAction) SSE(stream sse.Stream) {
 	})
 
 }
+
+// OrderBookHistoryAction renders the summary of an order book as it was
+// recorded at the close of a past ledger.
+type OrderBookHistoryAction struct {
+	Action
+	Selling  xdr.Asset
+	Buying   xdr.Asset
+	Ledger   int32
+	Limit    uint64
+	Asks     history.OrderBookSnapshot
+	Bids     history.OrderBookSnapshot
+	Resource resource.OrderBookSnapshot
+}
+
+// JSON is a method for actions.JSON
+func (action *OrderBookHistoryAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecords,
+		action.loadResource,
+		func() {
+			hal.Render(action.W, action.Resource)
+		},
+	)
+}
+
+func (action *OrderBookHistoryAction) loadParams() {
+	action.Selling = action.GetAsset("selling_")
+	action.Buying = action.GetAsset("buying_")
+	if action.Err != nil {
+		action.Err = &invalidOrderBookProblem
+		return
+	}
+
+	action.Ledger = action.GetHistoryLedger("at_ledger")
+	action.Limit = action.GetLimit("limit", 20, ingest.OrderBookSnapshotDepth)
+}
+
+// loadRecords loads the snapshots of both sides of the book.  Both sides of a
+// book are always snapshotted together.
+func (action *OrderBookHistoryAction) loadRecords() {
+	q := action.HistoryQ()
+
+	sellingID, err := q.GetAssetID(action.Selling)
+	if err != nil {
+		action.Err = err
+		return
+	}
+
+	buyingID, err := q.GetAssetID(action.Buying)
+	if err != nil {
+		action.Err = err
+		return
+	}
+
+	action.Err = q.OrderBookSnapshotAt(&action.Asks, sellingID, buyingID, action.Ledger)
+	if action.Err != nil {
+		return
+	}
+
+	action.Err = q.OrderBookSnapshotAt(&action.Bids, buyingID, sellingID, action.Ledger)
+}
+
+func (action *OrderBookHistoryAction) loadResource() {
+	action.Err = action.Resource.Populate(
+		action.Ctx,
+		action.Selling,
+		action.Buying,
+		action.Asks,
+		action.Bids,
+		action.Limit,
+	)
+}
+
+// invalidOrderBookProblem is rendered when the assets of an order book are
+// specified incorrectly.
+var invalidOrderBookProblem = problem.P{
+	Type:   "invalid_order_book",
+	Title:  "Invalid Order Book Parameters",
+	Status: http.StatusBadRequest,
+	Detail: "The parameters that specify what order book to view are invalid in some way. " +
+		"Please ensure that your type parameters (selling_asset_type and buying_asset_type) are one the " +
+		"following valid values: native, credit_alphanum4, credit_alphanum12.  Also ensure that you " +
+		"have specified selling_asset_code and selling_asset_issuer if selling_asset_type is not 'native', as well " +
+		"as buying_asset_code and buying_asset_issuer if buying_asset_type is not 'native'",
+}
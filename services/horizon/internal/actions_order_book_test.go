@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/xdr"
 )
 
 func TestOrderBookActions_Show(t *testing.T) {
@@ -118,3 +120,81 @@ func TestOrderBookActions_Show(t *testing.T) {
 		ht.Assert.Equal("10.0000000", result.Bids[0].Amount)
 	}
 }
+
+func TestOrderBookActions_History(t *testing.T) {
+	ht := StartHTTPTest(t, "trades")
+	defer ht.Finish()
+	q := &history.Q{Session: ht.HorizonSession()}
+
+	var eurIssuer, usdIssuer xdr.AccountId
+	ht.Require.NoError(eurIssuer.SetAddress("GCQPYGH4K57XBDENKKX55KDTWOTK5WDWRQOH2LHEDX3EKVIQRLMESGBG"))
+	ht.Require.NoError(usdIssuer.SetAddress("GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4"))
+
+	var eur, usd xdr.Asset
+	ht.Require.NoError(eur.SetCredit("EUR", eurIssuer))
+	ht.Require.NoError(usd.SetCredit("USD", usdIssuer))
+
+	ht.Require.NoError(q.InsertOrderBookSnapshot(3, eur, usd, []history.OrderBookLevel{
+		{Pricen: 1, Priced: 1, Amount: 500000000},
+	}))
+	ht.Require.NoError(q.InsertOrderBookSnapshot(3, usd, eur, []history.OrderBookLevel{
+		{Pricen: 2, Priced: 1, Amount: 100000000},
+	}))
+	ht.Require.NoError(q.InsertOrderBookSnapshot(5, eur, usd, []history.OrderBookLevel{
+		{Pricen: 1, Priced: 1, Amount: 500000000},
+		{Pricen: 5, Priced: 4, Amount: 800000000},
+	}))
+	ht.Require.NoError(q.InsertOrderBookSnapshot(5, usd, eur, nil))
+
+	book := "/order_book/history?" +
+		"selling_asset_type=credit_alphanum4&selling_asset_code=EUR&selling_asset_issuer=GCQPYGH4K57XBDENKKX55KDTWOTK5WDWRQOH2LHEDX3EKVIQRLMESGBG&" +
+		"buying_asset_type=credit_alphanum4&buying_asset_code=USD&buying_asset_issuer=GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4"
+
+	var result resource.OrderBookSnapshot
+
+	// the latest snapshot at or before the requested ledger
+	w := ht.Get(book + "&at_ledger=4")
+	if ht.Assert.Equal(200, w.Code) {
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+
+		ht.Assert.Equal(int32(3), result.Ledger)
+		ht.Assert.Equal("EUR", result.Selling.Code)
+		ht.Assert.Equal("USD", result.Buying.Code)
+		ht.Require.Len(result.Asks, 1)
+		ht.Require.Len(result.Bids, 1)
+		ht.Assert.Equal("1.0000000", result.Asks[0].Price)
+		ht.Assert.Equal("50.0000000", result.Asks[0].Amount)
+		ht.Assert.Equal("0.5000000", result.Bids[0].Price)
+		ht.Assert.Equal("10.0000000", result.Bids[0].Amount)
+	}
+
+	// defaults to the latest ledger, limited
+	w = ht.Get(book + "&limit=1")
+	if ht.Assert.Equal(200, w.Code) {
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+
+		ht.Assert.Equal(int32(5), result.Ledger)
+		ht.Require.Len(result.Asks, 1)
+		ht.Assert.Len(result.Bids, 0)
+	}
+
+	// before the first snapshot
+	w = ht.Get(book + "&at_ledger=2")
+	ht.Assert.Equal(404, w.Code)
+
+	// not yet ingested
+	w = ht.Get(book + "&at_ledger=100")
+	ht.Assert.Equal(400, w.Code)
+
+	// a book that was never snapshotted
+	w = ht.Get("/order_book/history?selling_asset_type=native&buying_asset_type=credit_alphanum4&buying_asset_code=USD&buying_asset_issuer=GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
+	ht.Assert.Equal(404, w.Code)
+
+	// invalid assets
+	w = ht.Get("/order_book/history?selling_asset_type=native")
+	if ht.Assert.Equal(400, w.Code) {
+		ht.Assert.ProblemType(w.Body, "invalid_order_book")
+	}
+}
@@ -1,11 +1,14 @@
 package horizon
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/render/export"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
 	"github.com/stellar/go/services/horizon/internal/render/sse"
 	"github.com/stellar/go/services/horizon/internal/resource"
@@ -18,6 +21,10 @@ type PaymentsIndexAction struct {
 	LedgerFilter      int32
 	AccountFilter     string
 	TransactionFilter string
+	MemoType          string
+	Memo              string
+	FromLedger        int32
+	ToLedger          int32
 	PagingParams      db2.PageQuery
 	Records           []history.Operation
 	Ledgers           history.LedgerCache
@@ -76,12 +83,95 @@ func (action *PaymentsIndexAction) SSE(stream sse.Stream) {
 		})
 }
 
+// Export is a method for actions.Export
+func (action *PaymentsIndexAction) Export(stream export.Stream) {
+	action.Setup(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.ValidateCursorWithinHistory,
+		func() {
+			action.PrepareExport(stream, &action.PagingParams, resource.PaymentColumns)
+		},
+	)
+	action.Records = nil
+	action.Do(
+		action.loadRecords,
+		action.loadLedgers,
+		func() {
+			for _, record := range action.Records {
+				ledger, found := action.Ledgers.Records[record.LedgerSequence()]
+				if !found {
+					msg := fmt.Sprintf("could not find ledger data for sequence %d", record.LedgerSequence())
+					action.Err = errors.New(msg)
+					return
+				}
+
+				var res hal.Pageable
+				res, action.Err = resource.NewOperation(action.Ctx, record, ledger)
+				if action.Err != nil {
+					return
+				}
+
+				stream.Send(res)
+			}
+
+			action.AdvanceExport(stream, &action.PagingParams, len(action.Records))
+		},
+	)
+}
+
 func (action *PaymentsIndexAction) loadParams() {
 	action.ValidateCursorAsDefault()
 	action.AccountFilter = action.GetString("account_id")
 	action.LedgerFilter = action.GetInt32("ledger_id")
 	action.TransactionFilter = action.GetString("tx_id")
+	action.FromLedger, action.ToLedger = action.GetLedgerRange()
 	action.PagingParams = action.GetPageQuery()
+	action.loadMemo()
+}
+
+// loadMemo validates the `memo` and `memo_type` params, normalizing the memo to
+// the form recorded in the history database.
+func (action *PaymentsIndexAction) loadMemo() {
+	action.MemoType = action.GetString("memo_type")
+	action.Memo = action.GetString("memo")
+	if action.Err != nil {
+		return
+	}
+
+	if action.MemoType == "" && action.Memo == "" {
+		return
+	}
+
+	if action.Memo == "" {
+		action.SetInvalidField("memo", errors.New("memo is required with memo_type"))
+		return
+	}
+
+	switch action.MemoType {
+	case "text":
+		if len(action.Memo) > 28 {
+			action.SetInvalidField("memo", errors.New("text memos are at most 28 bytes"))
+		}
+	case "id":
+		id, err := strconv.ParseUint(action.Memo, 10, 64)
+		if err != nil {
+			action.SetInvalidField("memo", err)
+			return
+		}
+		action.Memo = strconv.FormatUint(id, 10)
+	case "hash", "return":
+		raw, err := base64.StdEncoding.DecodeString(action.Memo)
+		if err != nil {
+			action.SetInvalidField("memo", err)
+			return
+		}
+		if len(raw) != 32 {
+			action.SetInvalidField("memo", errors.New("hash memos are 32 bytes"))
+		}
+	default:
+		action.SetInvalidField("memo_type", errors.New("must be one of text, id, hash or return"))
+	}
 }
 
 func (action *PaymentsIndexAction) loadRecords() {
@@ -97,6 +187,11 @@ func (action *PaymentsIndexAction) loadRecords() {
 		ops.ForTransaction(action.TransactionFilter)
 	}
 
+	if action.Memo != "" {
+		ops.ForMemo(action.MemoType, action.Memo)
+	}
+
+	ops.ForLedgerRange(action.FromLedger, action.ToLedger)
 	action.Err = ops.Page(action.PagingParams).Select(&action.Records)
 }
 
@@ -1,11 +1,16 @@
 package horizon
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/resource/operations"
+	"github.com/stellar/go/services/horizon/internal/test"
 )
 
 func TestPaymentActions(t *testing.T) {
@@ -34,6 +39,17 @@ func TestPaymentActions(t *testing.T) {
 		ht.Assert.PageOf(1, w.Body)
 	}
 
+	// filtered by ledger and time range
+	w = ht.Get("/payments?from_ledger=3")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/payments?end_time=1512008596000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
 	// switch scenarios
 	ht.T.Scenario("pathed_payment")
 
@@ -53,6 +69,59 @@ func TestPaymentActions(t *testing.T) {
 	ht.Assert.Equal(400, w.Code)
 }
 
+func TestPaymentActions_Memo(t *testing.T) {
+	ht := StartHTTPTest(t, "kahuna")
+	defer ht.Finish()
+
+	memoAccount := "/accounts/GA46VRKBCLI2X6DXLX7AIEVRFLH3UA7XBE3NGNP6O74HQ5LXHMGTV2JB/payments"
+
+	w := ht.Get(memoAccount + "?memo_type=id&memo=123")
+	if ht.Assert.Equal(200, w.Code) {
+		var records []operations.Payment
+		ht.UnmarshalPage(w.Body, &records)
+		if ht.Assert.Len(records, 1) {
+			ht.Assert.Equal("dd74eee27a59843b28a05ad08abf65eaa231b7debe4d05550c0a7a424cca5929", records[0].TransactionHash)
+		}
+	}
+
+	// id memos are normalized
+	w = ht.Get(memoAccount + "?memo_type=id&memo=0123")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get(memoAccount + "?memo_type=text&memo=hello")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.GetWithParams(memoAccount, url.Values{
+		"memo_type": []string{"hash"},
+		"memo":      []string{"AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE="},
+	})
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get(memoAccount + "?memo_type=text&memo=goodbye")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	// invalid memos
+	w = ht.Get(memoAccount + "?memo_type=id&memo=hello")
+	ht.Assert.Equal(400, w.Code)
+
+	w = ht.Get(memoAccount + "?memo_type=hash&memo=AQEB")
+	ht.Assert.Equal(400, w.Code)
+
+	w = ht.Get(memoAccount + "?memo_type=bogus&memo=hello")
+	ht.Assert.Equal(400, w.Code)
+
+	w = ht.Get(memoAccount + "?memo_type=id")
+	ht.Assert.Equal(400, w.Code)
+}
+
 func TestPayment_CreatedAt(t *testing.T) {
 	ht := StartHTTPTest(t, "base")
 	defer ht.Finish()
@@ -67,3 +136,74 @@ func TestPayment_CreatedAt(t *testing.T) {
 
 	ht.Assert.WithinDuration(l.ClosedAt, records[0].LedgerCloseTime, 1*time.Second)
 }
+
+func TestPaymentActions_Export(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// every payment, regardless of the page size
+	w := ht.Get("/payments?limit=1", test.RequestHelperNDJSON)
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.Equal("application/x-ndjson; charset=utf-8", w.Header().Get("Content-Type"))
+
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		if ht.Assert.Len(lines, 4) {
+			var record operations.Base
+			ht.Require.NoError(json.Unmarshal([]byte(lines[3]), &record))
+			ht.Assert.NotEmpty(record.PT)
+		}
+	}
+
+	// a header row, and a row per payment
+	w = ht.Get("/ledgers/3/payments", test.RequestHelperCSV)
+	if ht.Assert.Equal(200, w.Code) {
+		rows, err := csv.NewReader(w.Body).ReadAll()
+		ht.Require.NoError(err)
+		if ht.Assert.Len(rows, 2) {
+			ht.Assert.Contains(rows[0], "paging_token")
+			ht.Assert.Contains(rows[0], "transaction_hash")
+		}
+	}
+
+	// create_account and payment operations share the columns of every type of
+	// payment, rather than those of the first exported record
+	w = ht.Get("/payments", test.RequestHelperCSV)
+	if ht.Assert.Equal(200, w.Code) {
+		rows, err := csv.NewReader(w.Body).ReadAll()
+		ht.Require.NoError(err)
+		ht.Require.Len(rows, 5)
+
+		col := map[string]int{}
+		for i, name := range rows[0] {
+			col[name] = i
+		}
+		for _, name := range []string{"type", "starting_balance", "amount", "asset_type", "asset_code"} {
+			ht.Require.Contains(col, name)
+		}
+
+		ht.Assert.Equal("create_account", rows[1][col["type"]])
+		ht.Assert.Equal("100.0000000", rows[1][col["starting_balance"]])
+		ht.Assert.Equal("", rows[1][col["amount"]])
+		ht.Assert.Equal("payment", rows[4][col["type"]])
+		ht.Assert.Equal("", rows[4][col["starting_balance"]])
+		ht.Assert.Equal("5.0000000", rows[4][col["amount"]])
+		ht.Assert.Equal("native", rows[4][col["asset_type"]])
+	}
+
+	// capped by the configured limit
+	ht.App.config.ExportLimit = 3
+	w = ht.Get("/payments", test.RequestHelperCSV)
+	if ht.Assert.Equal(200, w.Code) {
+		rows, err := csv.NewReader(w.Body).ReadAll()
+		ht.Require.NoError(err)
+		ht.Assert.Len(rows, 4)
+	}
+
+	// invalid params are still reported as problems
+	w = ht.Get("/payments?cursor=wat", test.RequestHelperCSV)
+	ht.Assert.Equal(400, w.Code)
+
+	// only page actions can be exported
+	w = ht.Get("/ledgers", test.RequestHelperCSV)
+	ht.Assert.Equal(406, w.Code)
+}
@@ -0,0 +1,120 @@
+package horizon
+
+import (
+	"database/sql"
+
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/services/horizon/internal/resource"
+)
+
+// This file contains the actions:
+//
+// AccountSignersAction: the signers and thresholds of an account as of a ledger
+// AccountSignerHistoryAction: the changes to an account's signers and thresholds
+
+// AccountSignersAction renders the signers and thresholds of an account at the
+// close of a ledger, as reconstructed from the history database.
+type AccountSignersAction struct {
+	Action
+	Address  string
+	Ledger   int32
+	Records  history.SignerHistory
+	Resource resource.AccountSigners
+}
+
+// JSON is a method for actions.JSON
+func (action *AccountSignersAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecords,
+		action.loadResource,
+		func() {
+			hal.Render(action.W, action.Resource)
+		},
+	)
+}
+
+func (action *AccountSignersAction) loadParams() {
+	action.Address = action.GetString("account_id")
+	action.Ledger = action.GetHistoryLedger("at_ledger")
+}
+
+func (action *AccountSignersAction) loadRecords() {
+	action.Err = action.HistoryQ().
+		SignerHistory(&action.Records, action.Address, action.Ledger)
+}
+
+func (action *AccountSignersAction) loadResource() {
+	// An account that had not yet been created, or had been merged, at the
+	// requested ledger has no signers; sql.ErrNoRows renders as a 404.
+	if len(action.Records) == 0 {
+		action.Err = sql.ErrNoRows
+		return
+	}
+
+	latest := action.Records[len(action.Records)-1]
+	if latest.Removed {
+		action.Err = sql.ErrNoRows
+		return
+	}
+
+	action.Resource.Populate(action.Ctx, action.Address, action.Ledger, latest)
+}
+
+// AccountSignerHistoryAction renders a page of the states of an account's
+// signers and thresholds, one for each operation that changed them.
+type AccountSignerHistoryAction struct {
+	Action
+	Address      string
+	PagingParams db2.PageQuery
+	Records      history.SignerHistory
+	Page         hal.Page
+}
+
+// JSON is a method for actions.JSON
+func (action *AccountSignerHistoryAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecords,
+		action.loadPage,
+		func() {
+			hal.Render(action.W, action.Page)
+		},
+	)
+}
+
+func (action *AccountSignerHistoryAction) loadParams() {
+	action.Address = action.GetString("account_id")
+	action.PagingParams = action.GetPageQuery()
+}
+
+func (action *AccountSignerHistoryAction) loadRecords() {
+	var account history.Account
+	action.Err = action.HistoryQ().AccountByAddress(&account, action.Address)
+	if action.Err != nil {
+		return
+	}
+
+	action.Err = action.HistoryQ().SignerStates().
+		ForAccount(account.ID).
+		Page(action.PagingParams).
+		Select(&action.Records)
+}
+
+func (action *AccountSignerHistoryAction) loadPage() {
+	for _, record := range action.Records {
+		var res resource.SignerHistoryEntry
+		res.Populate(action.Ctx, record)
+		action.Page.Add(res)
+	}
+
+	action.Page.FullURL = action.FullURL()
+	action.Page.Limit = action.PagingParams.Limit
+	action.Page.Cursor = action.PagingParams.Cursor
+	action.Page.Order = action.PagingParams.Order
+	action.Page.PopulateLinks()
+}
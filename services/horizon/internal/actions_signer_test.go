@@ -0,0 +1,70 @@
+package horizon
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/resource"
+)
+
+func TestAccountSignersAction(t *testing.T) {
+	ht := StartHTTPTest(t, "set_options")
+	defer ht.Finish()
+
+	// latest ledger
+	w := ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/signers")
+	if ht.Assert.Equal(200, w.Code) {
+		var result resource.AccountSigners
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(byte(2), result.Thresholds.HighThreshold)
+		if ht.Assert.Len(result.Signers, 1) {
+			ht.Assert.Equal(int32(2), result.Signers[0].Weight)
+		}
+	}
+
+	// while the additional signer was present
+	w = ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/signers?at_ledger=9")
+	if ht.Assert.Equal(200, w.Code) {
+		var result resource.AccountSigners
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(int32(9), result.Ledger)
+		if ht.Assert.Len(result.Signers, 2) {
+			ht.Assert.Equal("GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4", result.Signers[0].Key)
+			ht.Assert.Equal(int32(5), result.Signers[0].Weight)
+		}
+	}
+
+	// before the account was created
+	w = ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/signers?at_ledger=1")
+	ht.Assert.Equal(404, w.Code)
+
+	// ledger that has not been ingested
+	w = ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/signers?at_ledger=100")
+	ht.Assert.Equal(400, w.Code)
+}
+
+func TestAccountSignerHistoryAction(t *testing.T) {
+	ht := StartHTTPTest(t, "set_options")
+	defer ht.Finish()
+
+	w := ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/signer_history")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(6, w.Body)
+
+		var records []resource.SignerHistoryEntry
+		ht.UnmarshalPage(w.Body, &records)
+		ht.Assert.Equal(int32(2), records[0].Ledger)
+		ht.Assert.Len(records[3].Signers, 2)
+	}
+
+	w = ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/signer_history?order=desc&limit=1")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	// unknown account
+	w = ht.Get("/accounts/GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU/signer_history")
+	ht.Assert.Equal(404, w.Code)
+}
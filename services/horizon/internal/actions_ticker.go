@@ -0,0 +1,202 @@
+package horizon
+
+import (
+	"github.com/stellar/go/services/horizon/internal/assets"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/support/time"
+	"github.com/stellar/go/xdr"
+)
+
+// TickerIndexAction renders the tickers of every asset pair that has been
+// traded.
+type TickerIndexAction struct {
+	Action
+	Records    []history.Ticker
+	BestOffers map[string]core.PriceLevel
+	Page       hal.BasePage
+}
+
+// JSON is a method for actions.JSON
+func (action *TickerIndexAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadRecords,
+		action.loadBestOffers,
+		action.loadPage,
+		func() {
+			hal.Render(action.W, action.Page)
+		},
+	)
+}
+
+func (action *TickerIndexAction) loadRecords() {
+	action.Err = action.HistoryQ().Tickers(&action.Records, tickerWindowStart())
+}
+
+// loadBestOffers loads the cheapest offer on each side of every order book,
+// keyed by the selling and buying assets of the side.
+func (action *TickerIndexAction) loadBestOffers() {
+	var offers []core.BestOffer
+	action.Err = action.CoreQ().BestOffers(&offers)
+	if action.Err != nil {
+		return
+	}
+
+	action.BestOffers = make(map[string]core.PriceLevel, len(offers))
+	for _, offer := range offers {
+		selling, err := core.AssetFromDB(offer.SellingType, offer.SellingCode, offer.SellingIssuer)
+		if err != nil {
+			action.Err = err
+			return
+		}
+
+		buying, err := core.AssetFromDB(offer.BuyingType, offer.BuyingCode, offer.BuyingIssuer)
+		if err != nil {
+			action.Err = err
+			return
+		}
+
+		action.BestOffers[bookSideKey(selling, buying)] = offer.PriceLevel
+	}
+}
+
+func (action *TickerIndexAction) loadPage() {
+	action.Page.Init()
+	for _, record := range action.Records {
+		base, counter, err := tickerAssets(record)
+		if err != nil {
+			action.Err = err
+			return
+		}
+
+		// the best ask is the cheapest offer of the base asset, and the best bid
+		// is the cheapest offer of the counter asset, priced in the base asset.
+		var bestBid, bestAsk *core.PriceLevel
+		if ask, ok := action.BestOffers[bookSideKey(base, counter)]; ok {
+			bestAsk = &ask
+		}
+		if bid, ok := action.BestOffers[bookSideKey(counter, base)]; ok {
+			bestBid = &core.PriceLevel{
+				Pricen: bid.Priced,
+				Priced: bid.Pricen,
+				Pricef: bid.InvertPricef(),
+			}
+		}
+
+		var res resource.Ticker
+		action.Err = res.Populate(action.Ctx, record, bestBid, bestAsk)
+		if action.Err != nil {
+			return
+		}
+
+		action.Page.Add(res)
+	}
+}
+
+// TickerShowAction renders the ticker of a single asset pair, identified by
+// the canonical forms of its base and counter assets.
+type TickerShowAction struct {
+	Action
+	Base     xdr.Asset
+	Counter  xdr.Asset
+	Record   history.Ticker
+	Resource resource.Ticker
+}
+
+// JSON is a method for actions.JSON
+func (action *TickerShowAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecord,
+		action.loadResource,
+		func() {
+			hal.Render(action.W, action.Resource)
+		},
+	)
+}
+
+func (action *TickerShowAction) loadParams() {
+	action.Base = action.GetCanonicalAsset("base")
+	action.Counter = action.GetCanonicalAsset("counter")
+}
+
+func (action *TickerShowAction) loadRecord() {
+	historyQ := action.HistoryQ()
+
+	baseAssetID, err := historyQ.GetAssetID(action.Base)
+	if err != nil {
+		action.Err = err
+		return
+	}
+
+	counterAssetID, err := historyQ.GetAssetID(action.Counter)
+	if err != nil {
+		action.Err = err
+		return
+	}
+
+	action.Err = historyQ.TickerForAssetPair(
+		&action.Record,
+		baseAssetID,
+		counterAssetID,
+		tickerWindowStart(),
+	)
+}
+
+func (action *TickerShowAction) loadResource() {
+	var book core.OrderBookSummary
+	action.Err = action.CoreQ().GetOrderBookSummary(&book, action.Base, action.Counter, 1)
+	if action.Err != nil {
+		return
+	}
+
+	var bestBid, bestAsk *core.PriceLevel
+	if bids := book.Bids(); len(bids) > 0 {
+		bestBid = &bids[0].PriceLevel
+	}
+	if asks := book.Asks(); len(asks) > 0 {
+		bestAsk = &asks[0].PriceLevel
+	}
+
+	action.Err = action.Resource.Populate(
+		action.Ctx,
+		action.Record,
+		bestBid,
+		bestAsk,
+	)
+}
+
+// bookSideKey returns the key of the side of an order book that sells
+// `selling` for `buying`.
+func bookSideKey(selling xdr.Asset, buying xdr.Asset) string {
+	return selling.String() + "/" + buying.String()
+}
+
+// tickerAssets returns the base and counter assets of `record`.
+func tickerAssets(record history.Ticker) (base xdr.Asset, counter xdr.Asset, err error) {
+	baseType, err := assets.Parse(record.BaseAssetType)
+	if err != nil {
+		return
+	}
+	base, err = core.AssetFromDB(baseType, record.BaseAssetCode, record.BaseAssetIssuer)
+	if err != nil {
+		return
+	}
+
+	counterType, err := assets.Parse(record.CounterAssetType)
+	if err != nil {
+		return
+	}
+	counter, err = core.AssetFromDB(counterType, record.CounterAssetCode, record.CounterAssetIssuer)
+	return
+}
+
+// tickerWindowStart returns the time from which the trading statistics of
+// tickers are computed.
+func tickerWindowStart() time.Millis {
+	return time.MillisFromInt64(time.Now().ToInt64() - history.TickerWindow)
+}
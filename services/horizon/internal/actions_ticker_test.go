@@ -0,0 +1,83 @@
+package horizon
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/resource"
+	. "github.com/stellar/go/services/horizon/internal/test/trades"
+	"github.com/stellar/go/support/time"
+	"github.com/stellar/go/xdr"
+)
+
+func TestTickerActions(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	const minute = 60 * 1000
+	const hour = minute * 60
+	now := time.Now().ToInt64()
+
+	// ten trades within the past day, priced from 1 to 10
+	dbQ := &Q{ht.HorizonSession()}
+	ass1, ass2, err := PopulateTestTrades(dbQ, now-2*hour, 10, minute, 0)
+	ht.Require.NoError(err)
+
+	// a trade that is too old to be part of the statistics
+	err = IngestTestTrade(
+		dbQ, ass1, ass2, GetTestAccount(), GetTestAccount(),
+		100, 50, time.MillisFromInt64(now-30*hour), 100,
+	)
+	ht.Require.NoError(err)
+
+	var records []resource.Ticker
+	w := ht.Get("/ticker")
+	if ht.Assert.Equal(200, w.Code) && ht.Assert.PageOf(1, w.Body) {
+		ht.UnmarshalPage(w.Body, &records)
+		ht.Assert.Equal("usd", records[0].Base.Code)
+		ht.Assert.Equal("euro", records[0].Counter.Code)
+		ht.Assert.Equal("10.0000000", records[0].LastPrice)
+		ht.Assert.Equal(int64(10), records[0].TradeCount)
+		ht.Assert.Equal("0.0005500", records[0].BaseVolume)
+		ht.Assert.Equal("0.0038500", records[0].CounterVolume)
+		ht.Assert.Equal("1.0000000", records[0].Open)
+		ht.Assert.Equal("10.0000000", records[0].High)
+		ht.Assert.Equal("1.0000000", records[0].Low)
+		ht.Assert.Equal("10.0000000", records[0].Close)
+		ht.Assert.Equal("", records[0].BestBid)
+		ht.Assert.Equal("", records[0].BestAsk)
+	}
+
+	// a single pair, in reverse order
+	var result resource.Ticker
+	w = ht.Get("/ticker/" + canonicalAssetPath(ass2) + "/" + canonicalAssetPath(ass1))
+	if ht.Assert.Equal(200, w.Code) {
+		err = json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+		ht.Assert.Equal("euro", result.Base.Code)
+		ht.Assert.Equal("0.1000000", result.LastPrice)
+		ht.Assert.Equal("0.0038500", result.BaseVolume)
+		ht.Assert.Equal("0.1000000", result.Low)
+		ht.Assert.Equal("1.0000000", result.High)
+	}
+
+	// a pair that has never been traded
+	w = ht.Get("/ticker/native/" + canonicalAssetPath(ass1))
+	ht.Assert.Equal(404, w.Code)
+
+	w = ht.Get("/ticker/native/USD")
+	ht.Assert.Equal(400, w.Code)
+}
+
+// canonicalAssetPath returns `a` in the form used in the paths of the ticker
+// endpoints.
+func canonicalAssetPath(a xdr.Asset) string {
+	var typ, code, issuer string
+	a.MustExtract(&typ, &code, &issuer)
+	if a.Type == xdr.AssetTypeAssetTypeNative {
+		return typ
+	}
+
+	return code + ":" + issuer
+}
@@ -7,7 +7,9 @@ import (
 
 	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/render/export"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/services/horizon/internal/render/sse"
 	"github.com/stellar/go/services/horizon/internal/resource"
 	"github.com/stellar/go/support/time"
 	"github.com/stellar/go/xdr"
@@ -38,6 +40,65 @@ func (action *TradeIndexAction) JSON() {
 	)
 }
 
+// SSE is a method for actions.SSE
+func (action *TradeIndexAction) SSE(stream sse.Stream) {
+	action.Setup(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+	)
+
+	action.Do(
+		action.loadRecords,
+		func() {
+			stream.SetLimit(int(action.PagingParams.Limit))
+			records := action.Records[stream.SentCount():]
+
+			for _, record := range records {
+				var res resource.Trade
+
+				action.Err = res.Populate(action.Ctx, record)
+				if action.Err != nil {
+					return
+				}
+
+				stream.Send(sse.Event{
+					ID:   res.PagingToken(),
+					Data: res,
+				})
+			}
+		},
+	)
+}
+
+// Export is a method for actions.Export
+func (action *TradeIndexAction) Export(stream export.Stream) {
+	action.Setup(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		func() {
+			action.PrepareExport(stream, &action.PagingParams, resource.TradeColumns)
+		},
+	)
+	action.Records = nil
+	action.Do(
+		action.loadRecords,
+		func() {
+			for _, record := range action.Records {
+				var res resource.Trade
+
+				action.Err = res.Populate(action.Ctx, record)
+				if action.Err != nil {
+					return
+				}
+
+				stream.Send(res)
+			}
+
+			action.AdvanceExport(stream, &action.PagingParams, len(action.Records))
+		},
+	)
+}
+
 // loadParams sets action.Query from the request params
 func (action *TradeIndexAction) loadParams() {
 	action.PagingParams = action.GetPageQuery()
@@ -106,9 +167,14 @@ type TradeAggregateIndexAction struct {
 	StartTimeFilter    time.Millis
 	EndTimeFilter      time.Millis
 	ResolutionFilter   int64
+	OffsetFilter       int64
 	PagingParams       db2.PageQuery
 	Records            []history.TradeAggregation
 	Page               hal.Page
+
+	// sent tracks the buckets that have been streamed to the client, keyed by
+	// their timestamp, so that only new or updated buckets are resent.
+	sent map[int64]history.TradeAggregation
 }
 
 // JSON is a method for actions.JSON
@@ -124,6 +190,82 @@ func (action *TradeAggregateIndexAction) JSON() {
 	)
 }
 
+// SSE is a method for actions.SSE.  Each time a ledger is ingested, buckets
+// that are new or whose aggregate values have changed since they were last sent
+// are streamed to the client.  Buckets are always streamed in ascending order,
+// and the stream is closed once `limit` events have been sent.
+func (action *TradeAggregateIndexAction) SSE(stream sse.Stream) {
+	action.Setup(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadLastEventID,
+		func() {
+			action.PagingParams.Order = db2.OrderAscending
+			action.sent = map[int64]history.TradeAggregation{}
+		},
+	)
+
+	action.Do(
+		action.loadRecords,
+		func() {
+			stream.SetLimit(int(action.PagingParams.Limit))
+
+			for _, record := range action.Records {
+				if stream.IsDone() {
+					return
+				}
+
+				if prev, ok := action.sent[record.Timestamp]; ok && prev == record {
+					continue
+				}
+
+				var res resource.TradeAggregation
+				action.Err = res.Populate(action.Ctx, record)
+				if action.Err != nil {
+					return
+				}
+
+				stream.Send(sse.Event{
+					ID:   res.PagingToken(),
+					Data: res,
+				})
+				action.sent[record.Timestamp] = record
+			}
+
+			if len(action.Records) == 0 {
+				return
+			}
+
+			// Every bucket prior to the latest one is closed and can no longer
+			// change, so the next query starts at the latest bucket.
+			latest := action.Records[len(action.Records)-1].Timestamp
+			for ts := range action.sent {
+				if ts < latest {
+					delete(action.sent, ts)
+				}
+			}
+			action.StartTimeFilter = time.MillisFromInt64(latest)
+		},
+	)
+}
+
+// loadLastEventID resumes a stream from the bucket identified by the
+// Last-Event-ID header, which is sent by clients when reconnecting.
+func (action *TradeAggregateIndexAction) loadLastEventID() {
+	lei := action.R.Header.Get("Last-Event-ID")
+	if lei == "" {
+		return
+	}
+
+	ts, err := strconv.ParseInt(lei, 10, 64)
+	if err != nil {
+		action.SetInvalidField("Last-Event-ID", err)
+		return
+	}
+
+	action.StartTimeFilter = time.MillisFromInt64(ts)
+}
+
 func (action *TradeAggregateIndexAction) loadParams() {
 	action.PagingParams = action.GetPageQuery()
 	action.BaseAssetFilter = action.GetAsset("base_")
@@ -131,6 +273,20 @@ func (action *TradeAggregateIndexAction) loadParams() {
 	action.StartTimeFilter = action.GetTimeMillis("start_time")
 	action.EndTimeFilter = action.GetTimeMillis("end_time")
 	action.ResolutionFilter = action.GetInt64("resolution")
+	action.OffsetFilter = action.GetInt64("offset")
+	if action.Err != nil {
+		return
+	}
+
+	if action.ResolutionFilter <= 0 {
+		action.SetInvalidField("resolution", errors.New("must be greater than zero"))
+		return
+	}
+
+	if action.OffsetFilter < 0 || action.OffsetFilter >= action.ResolutionFilter {
+		action.SetInvalidField("offset", errors.New("must be at least zero and less than resolution"))
+		return
+	}
 }
 
 // loadRecords populates action.Records
@@ -151,7 +307,8 @@ func (action *TradeAggregateIndexAction) loadRecords() {
 
 	//initialize the query builder with required params
 	tradeAggregationsQ := historyQ.GetTradeAggregationsQ(
-		baseAssetId, counterAssetId, action.ResolutionFilter, action.PagingParams)
+		baseAssetId, counterAssetId, action.ResolutionFilter, action.PagingParams).
+		WithOffset(action.OffsetFilter)
 
 	//set time range if supplied
 	if !action.StartTimeFilter.IsNil() {
@@ -160,7 +317,7 @@ func (action *TradeAggregateIndexAction) loadRecords() {
 	if !action.EndTimeFilter.IsNil() {
 		tradeAggregationsQ.WithEndTime(action.EndTimeFilter)
 	}
-	historyQ.Select(&action.Records, tradeAggregationsQ.GetSql())
+	action.Err = historyQ.Select(&action.Records, tradeAggregationsQ.GetSql())
 }
 
 func (action *TradeAggregateIndexAction) loadPage() {
@@ -1,6 +1,7 @@
 package horizon
 
 import (
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	. "github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/services/horizon/internal/test"
 	. "github.com/stellar/go/services/horizon/internal/test/trades"
 	"github.com/stellar/go/xdr"
 )
@@ -93,6 +95,12 @@ func TestTradeActions_Index(t *testing.T) {
 	if ht.Assert.Equal(200, w.Code) {
 		ht.Assert.PageOf(1, w.Body)
 	}
+
+	// streaming
+	w = ht.Get("/trades?limit=1", test.RequestHelperStreaming)
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.Contains(w.Body.String(), "base_amount")
+	}
 }
 
 // setAssetQuery adds an asset filter with a given prefix to a query
@@ -228,6 +236,24 @@ func TestTradeActions_Aggregation(t *testing.T) {
 	if ht.Assert.Equal(200, w.Code) {
 		ht.Assert.PageOf(0, w.Body)
 	}
+	//test streaming, which always delivers buckets in ascending order
+	q.Set("limit", "2")
+	w = ht.GetWithParams(aggregationPath, q, test.RequestHelperStreaming)
+	if ht.Assert.Equal(200, w.Code) {
+		body := w.Body.String()
+		ht.Assert.Contains(body, "id: "+strconv.Itoa(start)+"\n")
+		ht.Assert.Contains(body, "id: "+strconv.Itoa(start+minute)+"\n")
+	}
+
+	//test resuming a stream
+	w = ht.GetWithParams(aggregationPath, q, test.RequestHelperStreaming, func(r *http.Request) {
+		r.Header.Set("Last-Event-ID", strconv.Itoa(start+minute))
+	})
+	if ht.Assert.Equal(200, w.Code) {
+		body := w.Body.String()
+		ht.Assert.NotContains(body, "id: "+strconv.Itoa(start)+"\n")
+		ht.Assert.Contains(body, "id: "+strconv.Itoa(start+minute)+"\n")
+	}
 }
 
 func TestTradeActions_IndexRegressions(t *testing.T) {
@@ -246,6 +272,66 @@ func TestTradeActions_IndexRegressions(t *testing.T) {
 	ht.Assert.Equal(404, w.Code) //This used to be 200 with length 0
 }
 
+// TestTradeActions_AggregationOffset checks that buckets are shifted by the
+// offset and that the volume weighted average price is reported
+func TestTradeActions_AggregationOffset(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	const minute = 60 * 1000
+	const hour = minute * 60
+	const start = 1510693200000
+
+	dbQ := &Q{ht.HorizonSession()}
+	ass1, ass2, err := PopulateTestTrades(dbQ, start, 10, minute, 0)
+	ht.Require.NoError(err)
+
+	q := make(url.Values)
+	setAssetQuery(&q, "base_", ass1)
+	setAssetQuery(&q, "counter_", ass2)
+
+	q.Add("start_time", strconv.FormatInt(start, 10))
+	q.Add("end_time", strconv.FormatInt(start+hour, 10))
+	q.Add("order", "asc")
+	q.Add("resolution", strconv.FormatInt(5*minute, 10))
+	q.Add("offset", strconv.FormatInt(2*minute, 10))
+
+	// the first two trades fall in a bucket that starts before start_time
+	var records []resource.TradeAggregation
+	w := ht.GetWithParams("/trade_aggregations", q)
+	if ht.Assert.Equal(200, w.Code) && ht.Assert.PageOf(2, w.Body) {
+		ht.UnmarshalPage(w.Body, &records)
+		ht.Assert.Equal(int64(start+2*minute), records[0].Timestamp)
+		ht.Assert.Equal(int64(5), records[0].TradeCount)
+		ht.Assert.Equal("5.0000000", records[0].Average)
+		ht.Assert.Equal("5.4000000", records[0].Vwap)
+		ht.Assert.Equal("3.0000000", records[0].Open)
+		ht.Assert.Equal("7.0000000", records[0].Close)
+		ht.Assert.Equal(int64(start+7*minute), records[1].Timestamp)
+		ht.Assert.Equal(int64(3), records[1].TradeCount)
+	}
+
+	// resolutions that no rollup divides are bucketed from the trades
+	q.Set("resolution", strconv.FormatInt(150*1000, 10))
+	q.Set("offset", "0")
+	w = ht.GetWithParams("/trade_aggregations", q)
+	if ht.Assert.Equal(200, w.Code) && ht.Assert.PageOf(4, w.Body) {
+		ht.UnmarshalPage(w.Body, &records)
+		ht.Assert.Equal(int64(3), records[0].TradeCount)
+		ht.Assert.Equal(int64(2), records[1].TradeCount)
+	}
+
+	q.Set("resolution", strconv.FormatInt(5*minute, 10))
+	q.Set("offset", strconv.FormatInt(5*minute, 10))
+	w = ht.GetWithParams("/trade_aggregations", q)
+	ht.Assert.Equal(400, w.Code)
+
+	q.Set("resolution", "0")
+	q.Set("offset", "0")
+	w = ht.GetWithParams("/trade_aggregations", q)
+	ht.Assert.Equal(400, w.Code)
+}
+
 // TestTradeActions_AggregationOrdering checks that open/close aggregation
 // fields are correct for multiple trades that occur in the same ledger
 // https://github.com/stellar/go/issues/215
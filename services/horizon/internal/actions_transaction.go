@@ -4,11 +4,14 @@ import (
 	"net/http"
 
 	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/render/export"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
 	"github.com/stellar/go/services/horizon/internal/render/problem"
 	"github.com/stellar/go/services/horizon/internal/render/sse"
 	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/services/horizon/internal/simulate"
 	"github.com/stellar/go/services/horizon/internal/txsub"
 )
 
@@ -16,6 +19,9 @@ import (
 //
 // TransactionIndexAction: pages of transactions
 // TransactionShowAction: single transaction by sequence, by hash or id
+// TransactionBatchAction: transactions for a list of hashes
+// TransactionCreateAction: submits a transaction to the network
+// TransactionSimulateAction: predicts the result of submitting a transaction
 
 // TransactionIndexAction renders a page of ledger resources, identified by
 // a normal page query.
@@ -23,6 +29,8 @@ type TransactionIndexAction struct {
 	Action
 	LedgerFilter  int32
 	AccountFilter string
+	FromLedger    int32
+	ToLedger      int32
 	PagingParams  db2.PageQuery
 	Records       []history.Transaction
 	Page          hal.Page
@@ -64,10 +72,36 @@ func (action *TransactionIndexAction) SSE(stream sse.Stream) {
 	)
 }
 
+// Export is a method for actions.Export
+func (action *TransactionIndexAction) Export(stream export.Stream) {
+	action.Setup(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.ValidateCursorWithinHistory,
+		func() {
+			action.PrepareExport(stream, &action.PagingParams, resource.TransactionColumns)
+		},
+	)
+	action.Records = nil
+	action.Do(
+		action.loadRecords,
+		func() {
+			for _, record := range action.Records {
+				var res resource.Transaction
+				res.Populate(action.Ctx, record)
+				stream.Send(res)
+			}
+
+			action.AdvanceExport(stream, &action.PagingParams, len(action.Records))
+		},
+	)
+}
+
 func (action *TransactionIndexAction) loadParams() {
 	action.ValidateCursorAsDefault()
 	action.AccountFilter = action.GetString("account_id")
 	action.LedgerFilter = action.GetInt32("ledger_id")
+	action.FromLedger, action.ToLedger = action.GetLedgerRange()
 	action.PagingParams = action.GetPageQuery()
 }
 
@@ -82,6 +116,7 @@ func (action *TransactionIndexAction) loadRecords() {
 		txs.ForLedger(action.LedgerFilter)
 	}
 
+	txs.ForLedgerRange(action.FromLedger, action.ToLedger)
 	action.Err = txs.Page(action.PagingParams).Select(&action.Records)
 }
 
@@ -102,9 +137,10 @@ func (action *TransactionIndexAction) loadPage() {
 // TransactionShowAction renders a ledger found by its sequence number.
 type TransactionShowAction struct {
 	Action
-	Hash     string
-	Record   history.Transaction
-	Resource resource.Transaction
+	Hash       string
+	Record     history.Transaction
+	CoreRecord core.Transaction
+	Resource   resource.Transaction
 }
 
 func (action *TransactionShowAction) loadParams() {
@@ -115,6 +151,10 @@ func (action *TransactionShowAction) loadRecord() {
 	action.Err = action.HistoryQ().TransactionByHash(&action.Record, action.Hash)
 }
 
+func (action *TransactionShowAction) loadCoreRecord() {
+	action.Err = action.CoreQ().TransactionByHash(&action.CoreRecord, action.Hash)
+}
+
 func (action *TransactionShowAction) loadResource() {
 	action.Resource.Populate(action.Ctx, action.Record)
 }
@@ -130,6 +170,75 @@ func (action *TransactionShowAction) JSON() {
 	)
 }
 
+// Raw is a method for actions.Raw
+func (action *TransactionShowAction) Raw() {
+	action.Do(
+		action.loadParams,
+		action.loadCoreRecord,
+		func() {
+			action.WriteXDR(
+				action.CoreRecord.Envelope,
+				action.CoreRecord.Result.Result,
+				action.CoreRecord.ResultMeta,
+			)
+		},
+	)
+}
+
+// TransactionBatchAction renders the transactions found by a list of hashes, in
+// the order they were requested.  A hash that cannot be found is rendered as a
+// resource.NotFound.
+type TransactionBatchAction struct {
+	Action
+	Hashes  []string
+	Records []history.Transaction
+	Page    hal.BasePage
+}
+
+// JSON is a method for actions.JSON
+func (action *TransactionBatchAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecords,
+		action.loadPage,
+		func() {
+			hal.Render(action.W, action.Page)
+		},
+	)
+}
+
+func (action *TransactionBatchAction) loadParams() {
+	action.Hashes = action.GetStringList("hashes", db2.MaxPageSize)
+}
+
+func (action *TransactionBatchAction) loadRecords() {
+	action.Err = action.HistoryQ().
+		TransactionsByHashes(&action.Records, action.Hashes)
+}
+
+func (action *TransactionBatchAction) loadPage() {
+	records := map[string]history.Transaction{}
+	for _, record := range action.Records {
+		records[record.TransactionHash] = record
+	}
+
+	action.Page.Init()
+	for _, hash := range action.Hashes {
+		record, ok := records[hash]
+		if !ok {
+			var res resource.NotFound
+			res.Populate(hash)
+			action.Page.Add(res)
+			continue
+		}
+
+		var res resource.Transaction
+		res.Populate(action.Ctx, record)
+		action.Page.Add(res)
+	}
+}
+
 // TransactionCreateAction submits a transaction to the stellar-core network
 // on behalf of the requesting client.
 type TransactionCreateAction struct {
@@ -203,20 +312,69 @@ func (action *TransactionCreateAction) loadResource() {
 			},
 		}
 	case *txsub.MalformedTransactionError:
-		action.Err = &problem.P{
-			Type:   "transaction_malformed",
-			Title:  "Transaction Malformed",
-			Status: http.StatusBadRequest,
-			Detail: "Horizon could not decode the transaction envelope in this " +
-				"request. A transaction should be an XDR TransactionEnvelope struct " +
-				"encoded using base64.  The envelope read from this request is " +
-				"echoed in the `extras.envelope_xdr` field of this response for your " +
-				"convenience.",
-			Extras: map[string]interface{}{
-				"envelope_xdr": err.EnvelopeXDR,
-			},
-		}
+		action.Err = transactionMalformedProblem(err)
 	default:
 		action.Err = err
 	}
 }
+
+// TransactionSimulateAction predicts the result of submitting a transaction
+// to the stellar-core network, without submitting it, by validating it against
+// the current state of the ledger.
+type TransactionSimulateAction struct {
+	Action
+	TX       string
+	Result   simulate.Result
+	Resource resource.TransactionSimulation
+}
+
+// JSON format action handler
+func (action *TransactionSimulateAction) JSON() {
+	action.Do(
+		action.loadTX,
+		action.loadResult,
+		action.loadResource,
+
+		func() {
+			hal.Render(action.W, action.Resource)
+		})
+}
+
+func (action *TransactionSimulateAction) loadTX() {
+	action.ValidateBodyType()
+	action.TX = action.GetString("tx")
+}
+
+func (action *TransactionSimulateAction) loadResult() {
+	sim := &simulate.Simulator{
+		Q:                 action.CoreQ(),
+		NetworkPassphrase: action.App.networkPassphrase,
+	}
+
+	action.Result, action.Err = sim.Simulate(action.TX)
+	if err, ok := action.Err.(*txsub.MalformedTransactionError); ok {
+		action.Err = transactionMalformedProblem(err)
+	}
+}
+
+func (action *TransactionSimulateAction) loadResource() {
+	action.Err = action.Resource.Populate(action.Ctx, action.Result)
+}
+
+// transactionMalformedProblem returns the problem rendered when a transaction
+// envelope cannot be decoded.
+func transactionMalformedProblem(err *txsub.MalformedTransactionError) *problem.P {
+	return &problem.P{
+		Type:   "transaction_malformed",
+		Title:  "Transaction Malformed",
+		Status: http.StatusBadRequest,
+		Detail: "Horizon could not decode the transaction envelope in this " +
+			"request. A transaction should be an XDR TransactionEnvelope struct " +
+			"encoded using base64.  The envelope read from this request is " +
+			"echoed in the `extras.envelope_xdr` field of this response for your " +
+			"convenience.",
+		Extras: map[string]interface{}{
+			"envelope_xdr": err.EnvelopeXDR,
+		},
+	}
+}
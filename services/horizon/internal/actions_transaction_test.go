@@ -1,13 +1,17 @@
 package horizon
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/url"
 	"testing"
 
+	"github.com/stellar/go/build"
 	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/services/horizon/internal/test"
 	"github.com/stellar/go/services/horizon/internal/txsub"
 	"github.com/stellar/go/services/horizon/internal/txsub/sequence"
+	"github.com/stellar/go/xdr"
 )
 
 func TestTransactionActions_Show(t *testing.T) {
@@ -32,6 +36,91 @@ func TestTransactionActions_Show(t *testing.T) {
 	ht.Assert.Equal(404, w.Code)
 }
 
+func TestTransactionActions_ShowRaw(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	path := "/transactions/2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+	w := ht.Get(path)
+	ht.Require.Equal(200, w.Code)
+	var expected resource.Transaction
+	ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &expected))
+
+	// the envelope, result and meta, one after another
+	w = ht.Get(path, test.RequestHelperRaw)
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.Equal("application/octet-stream", w.Header().Get("Content-Type"))
+
+		var (
+			envelope xdr.TransactionEnvelope
+			result   xdr.TransactionResult
+			meta     xdr.TransactionMeta
+		)
+		r := bytes.NewReader(w.Body.Bytes())
+		for _, v := range []interface{}{&envelope, &result, &meta} {
+			_, err := xdr.Unmarshal(r, v)
+			ht.Require.NoError(err)
+		}
+		ht.Assert.Equal(0, r.Len())
+
+		for _, c := range []struct {
+			v        interface{}
+			expected string
+		}{
+			{envelope, expected.EnvelopeXdr},
+			{result, expected.ResultXdr},
+			{meta, expected.ResultMetaXdr},
+		} {
+			actual, err := xdr.MarshalBase64(c.v)
+			ht.Require.NoError(err)
+			ht.Assert.Equal(c.expected, actual)
+		}
+	}
+
+	// the format param
+	w = ht.Get(path + "?format=xdr")
+	ht.Assert.Equal(200, w.Code)
+	ht.Assert.Equal("application/octet-stream", w.Header().Get("Content-Type"))
+
+	w = ht.Get("/transactions/not_real", test.RequestHelperRaw)
+	ht.Assert.Equal(404, w.Code)
+}
+
+func TestTransactionActions_Batch(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/transactions?hashes=" +
+		"164a5064eba64f2cdbadb856bf3448485fc626247ada3ed39cddf0f6902133b6," +
+		"not_real," +
+		"2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d",
+	)
+	if ht.Assert.Equal(200, w.Code) {
+		var records []struct {
+			resource.Transaction
+			NotFound bool `json:"not_found"`
+		}
+		ht.UnmarshalPage(w.Body, &records)
+
+		if ht.Assert.Len(records, 3) {
+			ht.Assert.Equal("164a5064eba64f2cdbadb856bf3448485fc626247ada3ed39cddf0f6902133b6", records[0].Hash)
+			ht.Assert.False(records[0].NotFound)
+
+			ht.Assert.Equal("not_real", records[1].ID)
+			ht.Assert.True(records[1].NotFound)
+
+			ht.Assert.Equal("2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d", records[2].Hash)
+			ht.Assert.False(records[2].NotFound)
+		}
+	}
+
+	// without hashes, the collection is rendered
+	w = ht.Get("/transactions")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(4, w.Body)
+	}
+}
+
 func TestTransactionActions_Index(t *testing.T) {
 	ht := StartHTTPTest(t, "base")
 	defer ht.Finish()
@@ -77,6 +166,17 @@ func TestTransactionActions_Index(t *testing.T) {
 		ht.Assert.PageOf(2, w.Body)
 	}
 
+	// filtered by ledger and time range
+	w = ht.Get("/transactions?from_ledger=2&to_ledger=2")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	w = ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/transactions?start_time=1512008596000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
 	// regression: https://github.com/stellar/go/services/horizon/internal/issues/365
 	w = ht.Get("/transactions?limit=200")
 	ht.Require.Equal(200, w.Code)
@@ -106,3 +206,37 @@ func TestTransactionActions_Post(t *testing.T) {
 	w = ht.Post("/transactions", form)
 	ht.Assert.Equal(503, w.Code)
 }
+
+func TestTransactionActions_Simulate(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	const scott = "SBZWG33UOQQCAIBAEAQCAIBAEAQCAIBAEAQCAIBAEAQCAIBAEAQCAPSA"
+
+	tx := build.Transaction(
+		build.SourceAccount{scott},
+		build.Sequence{8589934594},
+		build.Network{ht.App.networkPassphrase},
+		build.Payment(
+			build.Destination{"GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON"},
+			build.NativeAmount{"1000"},
+		),
+	)
+	env := tx.Sign(scott)
+	b64, err := env.Base64()
+	ht.Require.NoError(err)
+
+	w := ht.Post("/transactions/simulate", url.Values{"tx": []string{b64}})
+	if ht.Assert.Equal(200, w.Code) {
+		var actual resource.TransactionSimulation
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		ht.Assert.False(actual.Successful)
+		ht.Assert.Equal("tx_failed", actual.ResultCodes.TransactionCode)
+		ht.Assert.Equal([]string{"op_underfunded"}, actual.ResultCodes.OperationCodes)
+	}
+
+	// malformed envelope
+	w = ht.Post("/transactions/simulate", url.Values{"tx": []string{"AAAA"}})
+	ht.Assert.Equal(400, w.Code)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/stellar/go/build"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/feestats"
 	"github.com/stellar/go/services/horizon/internal/friendbot"
 	"github.com/stellar/go/services/horizon/internal/ingest"
 	"github.com/stellar/go/services/horizon/internal/ledger"
@@ -30,6 +31,10 @@ import (
 	graceful "gopkg.in/tylerb/graceful.v1"
 )
 
+// gapsCacheTTL is how long the result of a search for gaps made on behalf of a
+// request is served before the history database is searched again.
+const gapsCacheTTL = time.Minute
+
 // App represents the root of the state of a horizon instance.
 type App struct {
 	config            Config
@@ -50,6 +55,11 @@ type App struct {
 	reaper            *reap.System
 	ticks             *time.Ticker
 
+	// gaps caches the result of the most recent search for gaps made on behalf
+	// of a request, for horizons whose ingester does not search for them.
+	gapsLock sync.Mutex
+	gaps     ingest.GapReport
+
 	// metrics
 	metrics                  metrics.Registry
 	historyLatestLedgerGauge metrics.Gauge
@@ -119,6 +129,10 @@ func (a *App) Close() {
 	a.cancel()
 	a.ticks.Stop()
 
+	if a.ingester != nil {
+		a.ingester.Close()
+	}
+
 	a.historyQ.Session.DB.Close()
 	a.coreQ.Session.DB.Close()
 }
@@ -147,6 +161,35 @@ func (a *App) CoreQ() *core.Q {
 	return a.coreQ
 }
 
+// Gaps returns the gaps in the history database.  The report of the
+// ingester's most recent search is returned when there is one; otherwise the
+// history database is searched, at most once every gapsCacheTTL, so that
+// requests cannot trigger repeated scans of the ledgers table.
+func (a *App) Gaps() (ingest.GapReport, error) {
+	if a.ingester != nil {
+		report := a.ingester.Gaps()
+		if !report.CheckedAt.IsZero() {
+			return report, nil
+		}
+	}
+
+	a.gapsLock.Lock()
+	defer a.gapsLock.Unlock()
+
+	if time.Since(a.gaps.CheckedAt) < gapsCacheTTL {
+		return a.gaps, nil
+	}
+
+	report := ingest.GapReport{CheckedAt: time.Now().UTC()}
+	err := a.HistoryQ().LedgerGaps(&report.Gaps, ingest.ArchiveVersion)
+	if err != nil {
+		return ingest.GapReport{}, err
+	}
+
+	a.gaps = report
+	return report, nil
+}
+
 // IsHistoryStale returns true if the latest history ledger is more than
 // `StaleThreshold` ledgers behind the latest core ledger
 func (a *App) IsHistoryStale() bool {
@@ -189,6 +232,66 @@ Failed:
 
 }
 
+// UpdateFeeStatsState refreshes the cached fee statistics whenever a new ledger
+// has been ingested into the history database.
+func (a *App) UpdateFeeStatsState() {
+	ls := ledger.CurrentState()
+	if ls.HistoryLatest == 0 || ls.HistoryLatest == feestats.CurrentState().LastLedger {
+		return
+	}
+
+	count := int32(a.config.FeeStatsLedgerCount)
+	if count == 0 {
+		count = feestats.DefaultLedgerCount
+	}
+
+	first := ls.HistoryLatest - count + 1
+	if first < ls.HistoryElder {
+		first = ls.HistoryElder
+	}
+
+	var (
+		latest history.Ledger
+		stats  history.FeeStats
+	)
+
+	err := a.HistoryQ().LedgerBySequence(&latest, ls.HistoryLatest)
+	if err != nil {
+		goto Failed
+	}
+
+	err = a.HistoryQ().FeeStats(&stats, first, ls.HistoryLatest)
+	if err != nil {
+		goto Failed
+	}
+
+	feestats.SetState(feestats.State{
+		LastLedger:          ls.HistoryLatest,
+		LastLedgerBaseFee:   latest.BaseFee,
+		LedgerCount:         stats.LedgerCount,
+		LedgerCapacityUsage: stats.CapacityUsage(),
+		Min:                 stats.Min,
+		Mode:                stats.Mode,
+		P10:                 stats.P10,
+		P20:                 stats.P20,
+		P30:                 stats.P30,
+		P40:                 stats.P40,
+		P50:                 stats.P50,
+		P60:                 stats.P60,
+		P70:                 stats.P70,
+		P80:                 stats.P80,
+		P90:                 stats.P90,
+		P95:                 stats.P95,
+		P99:                 stats.P99,
+	})
+	return
+
+Failed:
+	log.WithStack(err).
+		WithField("err", err.Error()).
+		Error("failed to load fee stats")
+}
+
 // UpdateStellarCoreInfo updates the value of coreVersion and networkPassphrase
 // from the Stellar core API.
 func (a *App) UpdateStellarCoreInfo() {
@@ -250,7 +353,8 @@ func (a *App) Tick() {
 		go a.ingester.Tick()
 	}
 
-	wg.Add(2)
+	wg.Add(3)
+	go func() { a.UpdateFeeStatsState(); wg.Done() }()
 	go func() { a.reaper.Tick(); wg.Done() }()
 	go func() { a.submitter.Tick(a.ctx); wg.Done() }()
 	wg.Wait()
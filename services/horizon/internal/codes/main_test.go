@@ -18,6 +18,7 @@ func TestCodes(t *testing.T) {
 			{xdr.OperationResultCodeOpBadAuth, "op_bad_auth", nil},
 			{xdr.CreateAccountResultCodeCreateAccountLowReserve, "op_low_reserve", nil},
 			{xdr.PaymentResultCodePaymentSrcNoTrust, "op_src_no_trust", nil},
+			{xdr.ManageDataResultCodeManageDataNameNotFound, "op_data_name_not_found", nil},
 			{0, "", ErrUnknownCode},
 		}
 
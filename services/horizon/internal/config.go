@@ -1,6 +1,8 @@
 package horizon
 
 import (
+	"time"
+
 	"github.com/PuerkitoBio/throttled"
 	"github.com/sirupsen/logrus"
 )
@@ -37,7 +39,41 @@ type Config struct {
 	// requests.
 	StaleThreshold uint
 
+	// FeeStatsLedgerCount is the number of recent ledgers over which the fee
+	// statistics served by the /fee_stats endpoint are computed.
+	FeeStatsLedgerCount uint
+
+	// ExportLimit is the maximum number of records written by a single CSV or
+	// newline-delimited JSON export.  0 means exports are unlimited.
+	ExportLimit uint
+
 	// SkipCursorUpdate causes the ingestor to skip reporting the "last imported
 	// ledger" state to stellar-core.
 	SkipCursorUpdate bool
+
+	// GapCheckInterval is how often the ingestion system searches the history
+	// database for gaps.  0 disables the search.
+	GapCheckInterval time.Duration
+
+	// GapHealLimit is the most ledgers the ingestion system reingests to heal
+	// gaps after each search.  0 disables healing.
+	GapHealLimit int32
+
+	// IngestEventsFile, when set, is the path of a file that every ledger,
+	// transaction, operation, effect and trade ingested is appended to as a line
+	// of JSON.
+	IngestEventsFile string
+
+	// IngestWebhookURL, when set, is a url that every ledger, transaction,
+	// operation, effect and trade ingested is posted to as JSON.
+	IngestWebhookURL string
+
+	// FilterAccounts, FilterAssets and FilterOperationTypes restrict the
+	// transactions ingested to those with a participant among the listed
+	// accounts, an operation involving one of the listed assets, and an
+	// operation of one of the listed types.  Empty lists are not applied.  See
+	// ingest.Filter for details.
+	FilterAccounts       []string
+	FilterAssets         []string
+	FilterOperationTypes []string
 }
@@ -1,10 +1,69 @@
 package core
 
 import (
+	"bytes"
+	"sort"
+	"strconv"
+
 	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/xdr"
 )
 
+// Entry returns the xdr.AccountEntry that `ac` and its `signers` represent in
+// the ledger.  As in stellar-core, the signers are sorted by key.
+func (ac Account) Entry(signers []Signer) (xdr.AccountEntry, error) {
+	var result xdr.AccountEntry
+
+	err := result.AccountId.SetAddress(ac.Accountid)
+	if err != nil {
+		return result, errors.Wrap(err, "invalid account id")
+	}
+
+	seq, err := strconv.ParseInt(ac.Seqnum, 10, 64)
+	if err != nil {
+		return result, errors.Wrap(err, "invalid sequence number")
+	}
+
+	if ac.Inflationdest.Valid {
+		var dest xdr.AccountId
+		err = dest.SetAddress(ac.Inflationdest.String)
+		if err != nil {
+			return result, errors.Wrap(err, "invalid inflation destination")
+		}
+		result.InflationDest = &dest
+	}
+
+	result.Balance = ac.Balance
+	result.SeqNum = xdr.SequenceNumber(seq)
+	result.NumSubEntries = xdr.Uint32(ac.Numsubentries)
+	result.Flags = xdr.Uint32(ac.Flags)
+	result.HomeDomain = xdr.String32(ac.HomeDomain.String)
+	result.Thresholds = ac.Thresholds
+	result.Signers = make([]xdr.Signer, 0, len(signers))
+
+	for _, signer := range signers {
+		if signer.Accountid != ac.Accountid {
+			continue
+		}
+
+		var key xdr.SignerKey
+		err = key.SetAddress(signer.Publickey)
+		if err != nil {
+			return result, errors.Wrap(err, "invalid signer key")
+		}
+
+		result.Signers = append(result.Signers, xdr.Signer{
+			Key:    key,
+			Weight: xdr.Uint32(signer.Weight),
+		})
+	}
+
+	sort.Sort(bySignerKey(result.Signers))
+
+	return result, nil
+}
+
 // IsAuthRequired returns true if the account has the "AUTH_REQUIRED" option
 // turned on.
 func (ac Account) IsAuthRequired() bool {
@@ -24,6 +83,14 @@ func (q *Q) AccountByAddress(dest interface{}, addy string) error {
 	return q.Get(dest, sql)
 }
 
+// AccountsByAddresses loads the rows from `accounts` for every address in
+// `addys`
+func (q *Q) AccountsByAddresses(dest interface{}, addys []string) error {
+	sql := selectAccount.Where(sq.Eq{"accountid": addys})
+
+	return q.Select(dest, sql)
+}
+
 // SequencesForAddresses loads the current sequence number for every accountid
 // specified in `addys`
 func (q *Q) SequencesForAddresses(dest interface{}, addys []string) error {
@@ -59,6 +126,33 @@ func (sp *SequenceProvider) Get(addys []string) (map[string]uint64, error) {
 	return results, nil
 }
 
+// bySignerKey orders signers by the type of their key, then its value.
+type bySignerKey []xdr.Signer
+
+func (s bySignerKey) Len() int      { return len(s) }
+func (s bySignerKey) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s bySignerKey) Less(i, j int) bool {
+	a, b := s[i].Key, s[j].Key
+	if a.Type != b.Type {
+		return a.Type < b.Type
+	}
+
+	av, bv := signerKeyValue(a), signerKeyValue(b)
+	return bytes.Compare(av[:], bv[:]) < 0
+}
+
+// signerKeyValue returns the value of `key`, whatever its type.
+func signerKeyValue(key xdr.SignerKey) xdr.Uint256 {
+	switch key.Type {
+	case xdr.SignerKeyTypeSignerKeyTypeEd25519:
+		return key.MustEd25519()
+	case xdr.SignerKeyTypeSignerKeyTypeHashTx:
+		return key.MustHashTx()
+	default:
+		return key.MustHashX()
+	}
+}
+
 var selectAccount = sq.Select(
 	"a.accountid",
 	"a.balance",
@@ -68,4 +162,5 @@ var selectAccount = sq.Select(
 	"a.homedomain",
 	"a.thresholds",
 	"a.flags",
+	"a.lastmodified",
 ).From("accounts a")
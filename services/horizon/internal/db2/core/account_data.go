@@ -26,8 +26,15 @@ func (q *Q) AllDataByAddress(dest interface{}, addy string) error {
 	return q.Select(dest, sql)
 }
 
+// AllDataByAddresses loads all data for every address in `addys`
+func (q *Q) AllDataByAddresses(dest interface{}, addys []string) error {
+	sql := selectAccountData.Where(sq.Eq{"accountid": addys})
+	return q.Select(dest, sql)
+}
+
 var selectAccountData = sq.Select(
 	"ad.accountid",
 	"ad.dataname",
 	"ad.datavalue",
+	"ad.lastmodified",
 ).From("accountdata ad")
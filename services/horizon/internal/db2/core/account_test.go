@@ -0,0 +1,59 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/guregu/null"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/xdr"
+)
+
+func TestAccountEntry(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	account := Account{
+		Accountid:     "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+		Balance:       xdr.Int64(100),
+		Seqnum:        "8589934593",
+		Numsubentries: 2,
+		Inflationdest: null.StringFrom("GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2"),
+		HomeDomain:    null.StringFrom("example.com"),
+		Thresholds:    xdr.Thresholds{1, 2, 3, 4},
+		Flags:         xdr.AccountFlagsAuthRequiredFlag,
+	}
+	signers := []Signer{
+		{account.Accountid, "XBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWGTOG", 1},
+		{account.Accountid, "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU", 2},
+		{account.Accountid, "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2", 3},
+		{"GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2", account.Accountid, 4},
+	}
+
+	entry, err := account.Entry(signers)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(account.Accountid, entry.AccountId.Address())
+	tt.Assert.Equal(xdr.SequenceNumber(8589934593), entry.SeqNum)
+	tt.Assert.Equal(xdr.Uint32(2), entry.NumSubEntries)
+	tt.Assert.Equal(account.Inflationdest.String, entry.InflationDest.Address())
+	tt.Assert.Equal(xdr.Uint32(1), entry.Flags)
+	tt.Assert.Equal(xdr.String32("example.com"), entry.HomeDomain)
+	tt.Assert.Equal(account.Thresholds, entry.Thresholds)
+
+	// only the account's own signers, ordered by type and then key
+	if tt.Assert.Len(entry.Signers, 3) {
+		tt.Assert.Equal(signers[2].Publickey, entry.Signers[0].Key.Address())
+		tt.Assert.Equal(signers[1].Publickey, entry.Signers[1].Key.Address())
+		tt.Assert.Equal(signers[0].Publickey, entry.Signers[2].Key.Address())
+	}
+
+	// accounts without an inflation destination
+	account.Inflationdest = null.String{}
+	entry, err = account.Entry(nil)
+	tt.Require.NoError(err)
+	tt.Assert.Nil(entry.InflationDest)
+	tt.Assert.Len(entry.Signers, 0)
+
+	account.Seqnum = "wat"
+	_, err = account.Entry(nil)
+	tt.Assert.Error(err)
+}
@@ -0,0 +1,190 @@
+package core
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// Entry returns the xdr.TrustLineEntry that `tl` represents in the ledger.
+func (tl Trustline) Entry() (xdr.TrustLineEntry, error) {
+	var result xdr.TrustLineEntry
+
+	err := result.AccountId.SetAddress(tl.Accountid)
+	if err != nil {
+		return result, errors.Wrap(err, "invalid account id")
+	}
+
+	result.Asset, err = AssetFromDB(tl.Assettype, tl.Assetcode, tl.Issuer)
+	if err != nil {
+		return result, errors.Wrap(err, "invalid asset")
+	}
+
+	result.Balance = tl.Balance
+	result.Limit = tl.Tlimit
+	result.Flags = xdr.Uint32(tl.Flags)
+
+	return result, nil
+}
+
+// Entry returns the xdr.OfferEntry that `r` represents in the ledger.
+func (r Offer) Entry() (xdr.OfferEntry, error) {
+	var result xdr.OfferEntry
+
+	err := result.SellerId.SetAddress(r.SellerID)
+	if err != nil {
+		return result, errors.Wrap(err, "invalid seller id")
+	}
+
+	result.Selling, err = AssetFromDB(
+		r.SellingAssetType,
+		r.SellingAssetCode.String,
+		r.SellingIssuer.String,
+	)
+	if err != nil {
+		return result, errors.Wrap(err, "invalid selling asset")
+	}
+
+	result.Buying, err = AssetFromDB(
+		r.BuyingAssetType,
+		r.BuyingAssetCode.String,
+		r.BuyingIssuer.String,
+	)
+	if err != nil {
+		return result, errors.Wrap(err, "invalid buying asset")
+	}
+
+	result.OfferId = xdr.Uint64(r.OfferID)
+	result.Amount = r.Amount
+	result.Price = xdr.Price{N: xdr.Int32(r.Pricen), D: xdr.Int32(r.Priced)}
+	result.Flags = xdr.Uint32(r.Flags)
+
+	return result, nil
+}
+
+// Entry returns the xdr.DataEntry that `ad` represents in the ledger.
+func (ad AccountData) Entry() (xdr.DataEntry, error) {
+	var result xdr.DataEntry
+
+	err := result.AccountId.SetAddress(ad.Accountid)
+	if err != nil {
+		return result, errors.Wrap(err, "invalid account id")
+	}
+
+	value, err := ad.Raw()
+	if err != nil {
+		return result, errors.Wrap(err, "invalid data value")
+	}
+
+	result.DataName = xdr.String64(ad.Key)
+	result.DataValue = xdr.DataValue(value)
+
+	return result, nil
+}
+
+// LedgerEntryByKey loads `dest` with the current state of the ledger entry
+// identified by `key`, as recorded in stellar-core's database.  It returns
+// sql.ErrNoRows when no such entry exists.
+func (q *Q) LedgerEntryByKey(dest *xdr.LedgerEntry, key xdr.LedgerKey) error {
+	var (
+		body         interface{}
+		lastModified int32
+	)
+
+	switch key.Type {
+	case xdr.LedgerEntryTypeAccount:
+		akey := key.MustAccount()
+		addy := akey.AccountId.Address()
+
+		var (
+			account Account
+			signers []Signer
+		)
+
+		err := q.AccountByAddress(&account, addy)
+		if err != nil {
+			return err
+		}
+
+		err = q.SignersByAddress(&signers, addy)
+		if err != nil {
+			return err
+		}
+
+		entry, err := account.Entry(signers)
+		if err != nil {
+			return err
+		}
+
+		body, lastModified = entry, account.Lastmodified
+	case xdr.LedgerEntryTypeTrustline:
+		tlkey := key.MustTrustLine()
+
+		if tlkey.Asset.Type == xdr.AssetTypeAssetTypeNative {
+			return sql.ErrNoRows
+		}
+
+		var tl Trustline
+		err := q.TrustlineByAddressAndAsset(&tl, tlkey.AccountId.Address(), tlkey.Asset)
+		if err != nil {
+			return err
+		}
+
+		entry, err := tl.Entry()
+		if err != nil {
+			return err
+		}
+
+		body, lastModified = entry, tl.Lastmodified
+	case xdr.LedgerEntryTypeOffer:
+		okey := key.MustOffer()
+
+		var offer Offer
+		err := q.Get(&offer, sq.Select("co.*").
+			From("offers co").
+			Where("co.offerid = ?", int64(okey.OfferId)).
+			Where("co.sellerid = ?", okey.SellerId.Address()).
+			Limit(1))
+		if err != nil {
+			return err
+		}
+
+		entry, err := offer.Entry()
+		if err != nil {
+			return err
+		}
+
+		body, lastModified = entry, offer.Lastmodified
+	case xdr.LedgerEntryTypeData:
+		dkey := key.MustData()
+
+		var ad AccountData
+		err := q.AccountDataByKey(&ad, dkey.AccountId.Address(), string(dkey.DataName))
+		if err != nil {
+			return err
+		}
+
+		entry, err := ad.Entry()
+		if err != nil {
+			return err
+		}
+
+		body, lastModified = entry, ad.Lastmodified
+	default:
+		return errors.Errorf("unknown ledger entry type: %d", key.Type)
+	}
+
+	data, err := xdr.NewLedgerEntryData(key.Type, body)
+	if err != nil {
+		return errors.Wrap(err, "failed to build ledger entry")
+	}
+
+	*dest = xdr.LedgerEntry{
+		LastModifiedLedgerSeq: xdr.Uint32(lastModified),
+		Data:                  data,
+	}
+
+	return nil
+}
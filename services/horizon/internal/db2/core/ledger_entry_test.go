@@ -0,0 +1,64 @@
+package core
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/xdr"
+)
+
+func TestLedgerEntryByKey(t *testing.T) {
+	tt := test.Start(t).Scenario("trades")
+	defer tt.Finish()
+	q := &Q{tt.CoreSession()}
+
+	var (
+		holder xdr.AccountId
+		entry  xdr.LedgerEntry
+	)
+	tt.Require.NoError(holder.SetAddress("GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2"))
+
+	usd, err := AssetFromDB(xdr.AssetTypeAssetTypeCreditAlphanum4, "USD", "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
+	tt.Require.NoError(err)
+
+	// account
+	key := xdr.LedgerKey{
+		Type:    xdr.LedgerEntryTypeAccount,
+		Account: &xdr.LedgerKeyAccount{AccountId: holder},
+	}
+	err = q.LedgerEntryByKey(&entry, key)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(xdr.LedgerEntryTypeAccount, entry.Data.Type)
+		account := entry.Data.MustAccount()
+		tt.Assert.Equal(holder.Address(), account.AccountId.Address())
+		tt.Assert.NotZero(entry.LastModifiedLedgerSeq)
+	}
+
+	// trustline
+	key = xdr.LedgerKey{
+		Type:      xdr.LedgerEntryTypeTrustline,
+		TrustLine: &xdr.LedgerKeyTrustLine{AccountId: holder, Asset: usd},
+	}
+	err = q.LedgerEntryByKey(&entry, key)
+	if tt.Assert.NoError(err) {
+		tl := entry.Data.MustTrustLine()
+		tt.Assert.Equal(holder.Address(), tl.AccountId.Address())
+		tt.Assert.True(tl.Asset.Equals(usd))
+		tt.Assert.NotZero(entry.LastModifiedLedgerSeq)
+	}
+
+	// native trustlines do not exist
+	key.TrustLine.Asset, err = xdr.NewAsset(xdr.AssetTypeAssetTypeNative, nil)
+	tt.Require.NoError(err)
+	err = q.LedgerEntryByKey(&entry, key)
+	tt.Assert.Equal(sql.ErrNoRows, err)
+
+	// missing data
+	key = xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeData,
+		Data: &xdr.LedgerKeyData{AccountId: holder, DataName: "missing"},
+	}
+	err = q.LedgerEntryByKey(&entry, key)
+	tt.Assert.Equal(sql.ErrNoRows, err)
+}
@@ -14,3 +14,12 @@ func (q *Q) LedgerHeaderBySequence(dest interface{}, seq int32) error {
 
 	return q.Get(dest, sql)
 }
+
+// LedgerHeaderCount loads into `dest` the number of ledgers from `start` to
+// `end`, inclusive, present in the `ledgerheaders` table.
+func (q *Q) LedgerHeaderCount(dest interface{}, start, end int32) error {
+	return q.GetRaw(dest, `
+		SELECT COUNT(*)
+		FROM ledgerheaders
+		WHERE ledgerseq BETWEEN $1 AND $2`, start, end)
+}
@@ -19,13 +19,27 @@ type Account struct {
 	HomeDomain    null.String
 	Thresholds    xdr.Thresholds
 	Flags         xdr.AccountFlags
+	Lastmodified  int32
 }
 
 // AccountData is a row of data from the `accountdata` table
 type AccountData struct {
-	Accountid string
-	Key       string `db:"dataname"`
-	Value     string `db:"datavalue"`
+	Accountid    string
+	Key          string `db:"dataname"`
+	Value        string `db:"datavalue"`
+	Lastmodified int32
+}
+
+// BestOffer is the cheapest offer of an asset for another, i.e. the best price
+// on one side of an order book.
+type BestOffer struct {
+	SellingType   xdr.AssetType `db:"sellingassettype"`
+	SellingCode   string        `db:"sellingassetcode"`
+	SellingIssuer string        `db:"sellingissuer"`
+	BuyingType    xdr.AssetType `db:"buyingassettype"`
+	BuyingCode    string        `db:"buyingassetcode"`
+	BuyingIssuer  string        `db:"buyingissuer"`
+	PriceLevel
 }
 
 // LedgerHeader is row of data from the `ledgerheaders` table
@@ -117,13 +131,14 @@ type TransactionFee struct {
 
 // Trustline is a row of data from the `trustlines` table from stellar-core
 type Trustline struct {
-	Accountid string
-	Assettype xdr.AssetType
-	Issuer    string
-	Assetcode string
-	Tlimit    xdr.Int64
-	Balance   xdr.Int64
-	Flags     int32
+	Accountid    string
+	Assettype    xdr.AssetType
+	Issuer       string
+	Assetcode    string
+	Tlimit       xdr.Int64
+	Balance      xdr.Int64
+	Flags        int32
+	Lastmodified int32
 }
 
 // AssetFromDB produces an xdr.Asset by combining the constituent type, code and
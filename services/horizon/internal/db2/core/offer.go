@@ -20,6 +20,40 @@ func (r Offer) PriceAsString() string {
 	return big.NewRat(int64(r.Pricen), int64(r.Priced)).FloatString(7)
 }
 
+// BestOffers loads into `dest` the cheapest offer for every pair of selling and
+// buying assets that has offers, i.e. the best price on each side of every
+// order book.
+func (q *Q) BestOffers(dest interface{}) error {
+	sql := sq.Select(
+		"co.sellingassettype",
+		"coalesce(co.sellingassetcode, '') AS sellingassetcode",
+		"coalesce(co.sellingissuer, '') AS sellingissuer",
+		"co.buyingassettype",
+		"coalesce(co.buyingassetcode, '') AS buyingassetcode",
+		"coalesce(co.buyingissuer, '') AS buyingissuer",
+		"co.pricen",
+		"co.priced",
+		"co.price AS pricef",
+		"co.amount",
+	).
+		Options(`DISTINCT ON (
+			co.sellingassettype, co.sellingassetcode, co.sellingissuer,
+			co.buyingassettype, co.buyingassetcode, co.buyingissuer
+		)`).
+		From("offers co").
+		OrderBy(
+			"co.sellingassettype",
+			"co.sellingassetcode",
+			"co.sellingissuer",
+			"co.buyingassettype",
+			"co.buyingassetcode",
+			"co.buyingissuer",
+			"co.price ASC",
+		)
+
+	return q.Select(dest, sql)
+}
+
 // ConnectedAssets loads xdr.Asset records for the purposes of path
 // finding.  Given the input asset type, a list of xdr.Assets is returned that
 // each have some available trades for the input asset.
@@ -78,19 +112,105 @@ func (q *Q) ConnectedAssets(dest interface{}, selling xdr.Asset) error {
 	return nil
 }
 
+// OfferByID loads a row from `offers`, by offer id
+func (q *Q) OfferByID(dest interface{}, id int64) error {
+	sql := sq.Select("co.*").
+		From("offers co").
+		Where("co.offerid = ?", id).
+		Limit(1)
+
+	return q.Get(dest, sql)
+}
+
+// Offers loads a page of active offers, optionally filtered by the asset being
+// sold, the asset being bought and the account that made the offer.  A nil
+// asset or an empty seller disables the corresponding filter.
+func (q *Q) Offers(
+	dest interface{},
+	selling *xdr.Asset,
+	buying *xdr.Asset,
+	seller string,
+	pq db2.PageQuery,
+) error {
+	sql := sq.Select("co.*").From("offers co")
+
+	var err error
+	if selling != nil {
+		sql, err = filterOffersByAsset(sql, "selling", *selling)
+		if err != nil {
+			return err
+		}
+	}
+
+	if buying != nil {
+		sql, err = filterOffersByAsset(sql, "buying", *buying)
+		if err != nil {
+			return err
+		}
+	}
+
+	if seller != "" {
+		sql = sql.Where("co.sellerid = ?", seller)
+	}
+
+	sql, err = pageOffers(sql, pq)
+	if err != nil {
+		return err
+	}
+
+	return q.Select(dest, sql)
+}
+
 // OffersByAddress loads a page of active offers for the given
 // address.
 func (q *Q) OffersByAddress(dest interface{}, addy string, pq db2.PageQuery) error {
 	sql := sq.Select("co.*").
 		From("offers co").
-		Where("co.sellerid = ?", addy).
-		Limit(uint64(pq.Limit))
+		Where("co.sellerid = ?", addy)
 
-	cursor, err := pq.CursorInt64()
+	sql, err := pageOffers(sql, pq)
 	if err != nil {
 		return err
 	}
 
+	return q.Select(dest, sql)
+}
+
+// filterOffersByAsset restricts `sql` to offers whose `side` ("selling" or
+// "buying") asset is `asset`.
+func filterOffersByAsset(sql sq.SelectBuilder, side string, asset xdr.Asset) (sq.SelectBuilder, error) {
+	var (
+		t xdr.AssetType
+		c string
+		i string
+	)
+
+	err := asset.Extract(&t, &c, &i)
+	if err != nil {
+		return sql, err
+	}
+
+	sql = sql.Where(sq.Eq{"co." + side + "assettype": t})
+	if t != xdr.AssetTypeAssetTypeNative {
+		sql = sql.Where(sq.Eq{
+			"co." + side + "assetcode": c,
+			"co." + side + "issuer":    i,
+		})
+	}
+
+	return sql, nil
+}
+
+// pageOffers applies the paging constraints of `pq` to `sql`, using the offer
+// id as the cursor.
+func pageOffers(sql sq.SelectBuilder, pq db2.PageQuery) (sq.SelectBuilder, error) {
+	cursor, err := pq.CursorInt64()
+	if err != nil {
+		return sql, err
+	}
+
+	sql = sql.Limit(uint64(pq.Limit))
+
 	switch pq.Order {
 	case "asc":
 		sql = sql.Where("co.offerid > ?", cursor).OrderBy("co.offerid asc")
@@ -98,5 +218,5 @@ func (q *Q) OffersByAddress(dest interface{}, addy string, pq db2.PageQuery) err
 		sql = sql.Where("co.offerid < ?", cursor).OrderBy("co.offerid desc")
 	}
 
-	return q.Select(dest, sql)
+	return sql, nil
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/xdr"
 )
 
 func TestOffersByAddress(t *testing.T) {
@@ -69,3 +70,53 @@ func TestOffersByAddress(t *testing.T) {
 		tt.Assert.Equal(int64(2), offers[0].OfferID)
 	}
 }
+
+func TestOffers(t *testing.T) {
+	tt := test.Start(t).Scenario("trades")
+	defer tt.Finish()
+	q := &Q{tt.CoreSession()}
+
+	usd, err := AssetFromDB(xdr.AssetTypeAssetTypeCreditAlphanum4, "USD", "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
+	tt.Require.NoError(err)
+	eur, err := AssetFromDB(xdr.AssetTypeAssetTypeCreditAlphanum4, "EUR", "GCQPYGH4K57XBDENKKX55KDTWOTK5WDWRQOH2LHEDX3EKVIQRLMESGBG")
+	tt.Require.NoError(err)
+	native, err := AssetFromDB(xdr.AssetTypeAssetTypeNative, "", "")
+	tt.Require.NoError(err)
+
+	var offers []Offer
+	pq := db2.MustPageQuery("", "asc", db2.DefaultPageSize)
+
+	// no filters
+	err = q.Offers(&offers, nil, nil, "", pq)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(offers, 4)
+	}
+
+	// asset pair
+	offers = []Offer{}
+	err = q.Offers(&offers, &eur, &usd, "", pq)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(offers, 3)
+	}
+
+	// native assets
+	offers = []Offer{}
+	err = q.Offers(&offers, &usd, &native, "", pq)
+	if tt.Assert.NoError(err) && tt.Assert.Len(offers, 1) {
+		tt.Assert.Equal(int64(4), offers[0].OfferID)
+	}
+
+	// single side and seller
+	offers = []Offer{}
+	err = q.Offers(&offers, &usd, nil, "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2", pq)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(offers, 0)
+	}
+
+	// paging
+	offers = []Offer{}
+	err = q.Offers(&offers, &eur, &usd, "", db2.MustPageQuery("1", "asc", 1))
+	if tt.Assert.NoError(err) && tt.Assert.Len(offers, 1) {
+		tt.Assert.Equal(int64(2), offers[0].OfferID)
+	}
+}
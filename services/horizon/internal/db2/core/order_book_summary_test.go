@@ -98,3 +98,38 @@ func TestGetOrderBookSummary_Regress310(t *testing.T) {
 	tt.Assert.Equal(1.0/10.1, asks[1].Pricef)
 	tt.Assert.Equal(1.0/10.0, asks[2].Pricef)
 }
+
+func TestBestOffers(t *testing.T) {
+	tt := test.Start(t).Scenario("order_books")
+	defer tt.Finish()
+	q := &Q{tt.CoreSession()}
+
+	var offers []BestOffer
+	err := q.BestOffers(&offers)
+	tt.Require.NoError(err)
+	tt.Require.Len(offers, 4)
+
+	// native for USD
+	tt.Assert.Equal(xdr.AssetTypeAssetTypeNative, offers[0].SellingType)
+	tt.Assert.Equal("USD", offers[0].BuyingCode)
+	tt.Assert.Equal(int32(1), offers[0].Pricen)
+	tt.Assert.Equal(int32(10), offers[0].Priced)
+
+	// BTC for USD
+	tt.Assert.Equal("BTC", offers[1].SellingCode)
+	tt.Assert.Equal("USD", offers[1].BuyingCode)
+	tt.Assert.Equal(int32(1), offers[1].Pricen)
+	tt.Assert.Equal(int32(10), offers[1].Priced)
+
+	// USD for native
+	tt.Assert.Equal("USD", offers[2].SellingCode)
+	tt.Assert.Equal(xdr.AssetTypeAssetTypeNative, offers[2].BuyingType)
+	tt.Assert.Equal(int32(15), offers[2].Pricen)
+	tt.Assert.Equal(int32(1), offers[2].Priced)
+
+	// USD for BTC
+	tt.Assert.Equal("USD", offers[3].SellingCode)
+	tt.Assert.Equal("BTC", offers[3].BuyingCode)
+	tt.Assert.Equal(int32(15), offers[3].Pricen)
+	tt.Assert.Equal(int32(1), offers[3].Priced)
+}
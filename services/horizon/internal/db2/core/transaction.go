@@ -127,6 +127,16 @@ func (tx *Transaction) SourceAddress() string {
 	return strkey.MustEncode(strkey.VersionByteAccountID, raw)
 }
 
+// TransactionByHash is a query that loads a single row from the `txhistory`.
+func (q *Q) TransactionByHash(dest interface{}, hash string) error {
+	sql := sq.Select("ctxh.*").
+		From("txhistory ctxh").
+		Limit(1).
+		Where("ctxh.txid = ?", hash)
+
+	return q.Get(dest, sql)
+}
+
 // TransactionByHashAfterLedger is a query that loads a single row from the `txhistory`.
 func (q *Q) TransactionByHashAfterLedger(
 	dest interface{},
@@ -2,11 +2,39 @@ package core
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/xdr"
 )
 
+const (
+	// HoldersByAccountID orders the holders of an asset by their address.
+	HoldersByAccountID = "account_id"
+
+	// HoldersByBalance orders the holders of an asset by their balance, then by
+	// their address.
+	HoldersByBalance = "balance"
+)
+
+// HolderPagingToken returns a paging token for the trustline when the holders
+// of its asset are ordered by `orderBy`.
+func (r Trustline) HolderPagingToken(orderBy string) string {
+	if orderBy == HoldersByBalance {
+		return fmt.Sprintf("%d-%s", r.Balance, r.Accountid)
+	}
+	return r.Accountid
+}
+
+// IsAuthorized returns true if the trustline's owner is authorized to hold its
+// asset.
+func (r Trustline) IsAuthorized() bool {
+	return xdr.TrustLineFlags(r.Flags)&xdr.TrustLineFlagsAuthorizedFlag != 0
+}
+
 // AssetsForAddress loads `dest` as `[]xdr.Asset` with every asset the account
 // at `addy` can hold.
 func (q *Q) AssetsForAddress(dest interface{}, addy string) error {
@@ -43,6 +71,35 @@ func (q *Q) TrustlinesByAddress(dest interface{}, addy string) error {
 	return q.Select(dest, sql)
 }
 
+// TrustlineByAddressAndAsset loads the trustline of `addy` to `asset`
+func (q *Q) TrustlineByAddressAndAsset(dest interface{}, addy string, asset xdr.Asset) error {
+	var (
+		t xdr.AssetType
+		c string
+		i string
+	)
+
+	err := asset.Extract(&t, &c, &i)
+	if err != nil {
+		return err
+	}
+
+	sql := selectTrustline.Limit(1).Where(sq.Eq{
+		"tl.accountid": addy,
+		"tl.assettype": t,
+		"tl.assetcode": c,
+		"tl.issuer":    i,
+	})
+
+	return q.Get(dest, sql)
+}
+
+// TrustlinesByAddresses loads all trustlines for every address in `addys`
+func (q *Q) TrustlinesByAddresses(dest interface{}, addys []string) error {
+	sql := selectTrustline.Where(sq.Eq{"accountid": addys})
+	return q.Select(dest, sql)
+}
+
 // BalancesForAsset returns all the balances by asset type, code, issuer
 func (q *Q) BalancesForAsset(
 	assetType int32,
@@ -63,6 +120,91 @@ func (q *Q) BalancesForAsset(
 	return result.Count, result.Sum, err
 }
 
+// HoldersForAsset loads a page of the trustlines to the credit asset `asset`
+// whose balance is at least `minBalance`, ordered by `orderBy`.
+func (q *Q) HoldersForAsset(
+	dest interface{},
+	asset xdr.Asset,
+	minBalance xdr.Int64,
+	orderBy string,
+	pq db2.PageQuery,
+) error {
+	var (
+		t xdr.AssetType
+		c string
+		i string
+	)
+
+	err := asset.Extract(&t, &c, &i)
+	if err != nil {
+		return err
+	}
+
+	if t == xdr.AssetTypeAssetTypeNative {
+		return errors.New("native asset has no trustlines")
+	}
+
+	sql := selectTrustline.Where(sq.Eq{
+		"tl.assettype": t,
+		"tl.assetcode": c,
+		"tl.issuer":    i,
+	})
+
+	if minBalance > 0 {
+		sql = sql.Where("tl.balance >= ?", minBalance)
+	}
+
+	sql, err = pageHolders(sql, orderBy, pq)
+	if err != nil {
+		return err
+	}
+
+	return q.Select(dest, sql)
+}
+
+// pageHolders applies the paging constraints of `pq` to `sql`, using the
+// account id, or the balance and account id, as the cursor.
+func pageHolders(sql sq.SelectBuilder, orderBy string, pq db2.PageQuery) (sq.SelectBuilder, error) {
+	var op string
+	switch pq.Order {
+	case db2.OrderAscending:
+		op = ">"
+	case db2.OrderDescending:
+		op = "<"
+	default:
+		return sql, db2.ErrInvalidOrder
+	}
+
+	sql = sql.Limit(uint64(pq.Limit))
+
+	switch orderBy {
+	case HoldersByAccountID:
+		if pq.Cursor != "" {
+			sql = sql.Where("tl.accountid "+op+" ?", pq.Cursor)
+		}
+		sql = sql.OrderBy("tl.accountid " + pq.Order)
+	case HoldersByBalance:
+		if pq.Cursor != "" {
+			parts := strings.SplitN(pq.Cursor, "-", 2)
+			if len(parts) != 2 {
+				return sql, db2.ErrInvalidCursor
+			}
+
+			balance, err := strconv.ParseInt(parts[0], 10, 64)
+			if err != nil {
+				return sql, db2.ErrInvalidCursor
+			}
+
+			sql = sql.Where("(tl.balance, tl.accountid) "+op+" (?, ?)", balance, parts[1])
+		}
+		sql = sql.OrderBy("tl.balance "+pq.Order, "tl.accountid "+pq.Order)
+	default:
+		return sql, fmt.Errorf("invalid holder order: %s", orderBy)
+	}
+
+	return sql, nil
+}
+
 var selectTrustline = sq.Select(
 	"tl.accountid",
 	"tl.assettype",
@@ -71,5 +213,6 @@ var selectTrustline = sq.Select(
 	"tl.tlimit",
 	"tl.balance",
 	"tl.flags",
+	"tl.lastmodified",
 ).From("trustlines tl")
 var selectBalances = sq.Select("COUNT(*)", "COALESCE(SUM(balance), 0) as sum").From("trustlines")
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/xdr"
+)
+
+func TestHoldersForAsset(t *testing.T) {
+	tt := test.Start(t).Scenario("trades")
+	defer tt.Finish()
+	q := &Q{tt.CoreSession()}
+
+	usd, err := AssetFromDB(xdr.AssetTypeAssetTypeCreditAlphanum4, "USD", "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
+	tt.Require.NoError(err)
+
+	var holders []Trustline
+
+	// ordered by account id
+	pq := db2.MustPageQuery("", "asc", db2.DefaultPageSize)
+	err = q.HoldersForAsset(&holders, usd, 0, HoldersByAccountID, pq)
+	if tt.Assert.NoError(err) && tt.Assert.Len(holders, 2) {
+		tt.Assert.Equal("GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2", holders[0].Accountid)
+		tt.Assert.True(holders[0].IsAuthorized())
+	}
+
+	// ordered by balance, largest first
+	holders = []Trustline{}
+	pq = db2.MustPageQuery("", "desc", db2.DefaultPageSize)
+	err = q.HoldersForAsset(&holders, usd, 0, HoldersByBalance, pq)
+	if tt.Assert.NoError(err) && tt.Assert.Len(holders, 2) {
+		tt.Assert.Equal(xdr.Int64(4500000000), holders[0].Balance)
+		tt.Assert.Equal("4500000000-GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU", holders[0].HolderPagingToken(HoldersByBalance))
+	}
+
+	// next page by balance
+	holders = []Trustline{}
+	pq = db2.MustPageQuery("4500000000-GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU", "desc", db2.DefaultPageSize)
+	err = q.HoldersForAsset(&holders, usd, 0, HoldersByBalance, pq)
+	if tt.Assert.NoError(err) && tt.Assert.Len(holders, 1) {
+		tt.Assert.Equal(xdr.Int64(500000000), holders[0].Balance)
+	}
+
+	// minimum balance
+	holders = []Trustline{}
+	pq = db2.MustPageQuery("", "asc", db2.DefaultPageSize)
+	err = q.HoldersForAsset(&holders, usd, 1000000000, HoldersByAccountID, pq)
+	if tt.Assert.NoError(err) && tt.Assert.Len(holders, 1) {
+		tt.Assert.Equal("GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU", holders[0].Accountid)
+	}
+
+	// invalid balance cursor
+	pq = db2.MustPageQuery("GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU", "asc", db2.DefaultPageSize)
+	err = q.HoldersForAsset(&holders, usd, 0, HoldersByBalance, pq)
+	tt.Assert.Equal(db2.ErrInvalidCursor, err)
+}
@@ -3,6 +3,7 @@ package history
 import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/xdr"
 )
 
@@ -21,12 +22,205 @@ func (q *Q) AccountByAddress(dest interface{}, addy string) error {
 	return q.Get(dest, sql)
 }
 
+// AccountsByAddresses loads the rows from `history_accounts` for every address
+// in `addys`
+func (q *Q) AccountsByAddresses(dest interface{}, addys []string) error {
+	sql := selectAccount.Where(sq.Eq{"ha.address": addys})
+	return q.Select(dest, sql)
+}
+
 // AccountByID loads a row from `history_accounts`, by id
 func (q *Q) AccountByID(dest interface{}, id int64) error {
 	sql := selectAccount.Limit(1).Where("ha.id = ?", id)
 	return q.Get(dest, sql)
 }
 
+// AccountCreationByAddress loads the most recent creation of the account
+// identified by `addy` into `dest`.
+func (q *Q) AccountCreationByAddress(dest interface{}, addy string) error {
+	sql := selectAccountCreation.
+		Where("ha.address = ?", addy).
+		OrderBy("hac.history_operation_id desc").
+		Limit(1)
+	return q.Get(dest, sql)
+}
+
+// AccountCreations provides a helper to filter rows from the
+// `history_account_creations` table with pre-defined filters.  See
+// `AccountCreationsQ` methods for the available filters.
+func (q *Q) AccountCreations() *AccountCreationsQ {
+	return &AccountCreationsQ{
+		parent: q,
+		sql:    selectAccountCreation,
+	}
+}
+
+// CreatedBy filters the query results to the accounts created by the account
+// identified by `addy`.
+func (q *AccountCreationsQ) CreatedBy(addy string) *AccountCreationsQ {
+	q.sql = q.sql.Where("hcb.address = ?", addy)
+	return q
+}
+
+// Page specifies the paging constraints for the query being built by `q`.
+func (q *AccountCreationsQ) Page(page db2.PageQuery) *AccountCreationsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql, q.Err = page.ApplyTo(q.sql, "hac.history_operation_id")
+	return q
+}
+
+// Select loads the results of the query specified by `q` into `dest`.
+func (q *AccountCreationsQ) Select(dest interface{}) error {
+	if q.Err != nil {
+		return q.Err
+	}
+
+	q.Err = q.parent.Select(dest, q.sql)
+	return q.Err
+}
+
+// RecordAccountCreation records that `account` was created by `funder` in the
+// operation identified by `opid`.  Every creation of an account is recorded
+// separately, such that the creations of prior incarnations of an account
+// that was merged are retained.  When a creation is reingested, the merge that
+// ended it is restored from the operations already ingested after it.
+func (q *Q) RecordAccountCreation(account xdr.AccountId, funder xdr.AccountId, opid int64) error {
+	accountID, err := q.GetCreateAccountID(account)
+	if err != nil {
+		return errors.Wrap(err, "failed to get account id")
+	}
+
+	funderID, err := q.GetCreateAccountID(funder)
+	if err != nil {
+		return errors.Wrap(err, "failed to get funder id")
+	}
+
+	sql := accountCreationsInsert.Values(opid, accountID, funderID)
+
+	_, err = q.Exec(sql)
+	if err != nil {
+		return errors.Wrap(err, "failed to exec sql")
+	}
+
+	// the creation is ended by the first merge of the account that follows it,
+	// unless the account is created again in between.
+	_, err = q.ExecRaw(`
+		UPDATE history_account_creations hac
+		SET merged_into_id = dest.id, merged_by_operation_id = m.id
+		FROM history_operations m
+		JOIN history_accounts dest ON dest.address = m.details->>'into'
+		WHERE hac.history_operation_id = ?
+		AND m.id = (
+			SELECT MIN(hop.history_operation_id)
+			FROM history_operation_participants hop
+			JOIN history_operations o ON o.id = hop.history_operation_id
+			WHERE hop.history_account_id = ?
+			AND hop.history_operation_id > ?
+			AND o.type = ?
+			AND o.details->>'account' = ?
+		)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM history_account_creations next
+			WHERE next.history_account_id = ?
+			AND next.history_operation_id > ?
+			AND next.history_operation_id < m.id
+		)`,
+		opid,
+		accountID, opid, xdr.OperationTypeAccountMerge, account.Address(),
+		accountID, opid,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to restore merge")
+	}
+
+	return nil
+}
+
+// RecordAccountMerge records that `account` was merged into `into` in the
+// operation identified by `opid`, against the most recent creation of
+// `account` prior to the merge.
+func (q *Q) RecordAccountMerge(account xdr.AccountId, into xdr.AccountId, opid int64) error {
+	accountID, err := q.GetCreateAccountID(account)
+	if err != nil {
+		return errors.Wrap(err, "failed to get account id")
+	}
+
+	intoID, err := q.GetCreateAccountID(into)
+	if err != nil {
+		return errors.Wrap(err, "failed to get destination id")
+	}
+
+	_, err = q.ExecRaw(`
+		UPDATE history_account_creations
+		SET merged_into_id = ?, merged_by_operation_id = ?
+		WHERE history_operation_id = (
+			SELECT MAX(history_operation_id)
+			FROM history_account_creations
+			WHERE history_account_id = ?
+			AND history_operation_id < ?
+		)`,
+		intoID, opid, accountID, opid,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to exec sql")
+	}
+
+	return nil
+}
+
+// ForgetAccountMerges clears the merges recorded by the operations whose ids
+// are in the range [start, end).
+func (q *Q) ForgetAccountMerges(start int64, end int64) error {
+	_, err := q.ExecRaw(`
+		UPDATE history_account_creations
+		SET merged_into_id = NULL, merged_by_operation_id = NULL
+		WHERE merged_by_operation_id >= ? AND merged_by_operation_id < ?`,
+		start, end,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to exec sql")
+	}
+
+	return nil
+}
+
+// RecordAccountMerges records the merges of the ingested operations whose ids
+// are in the range [start, end), replacing the merges recorded by them before.
+// Each merge is recorded against the most recent creation of the merged account
+// prior to it, as by RecordAccountMerge.
+func (q *Q) RecordAccountMerges(start int64, end int64) error {
+	err := q.ForgetAccountMerges(start, end)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.ExecRaw(`
+		UPDATE history_account_creations hac
+		SET merged_into_id = dest.id, merged_by_operation_id = m.id
+		FROM history_operations m
+		JOIN history_accounts account ON account.address = m.details->>'account'
+		JOIN history_accounts dest ON dest.address = m.details->>'into'
+		WHERE m.type = ?
+		AND m.id >= ? AND m.id < ?
+		AND hac.history_operation_id = (
+			SELECT MAX(prev.history_operation_id)
+			FROM history_account_creations prev
+			WHERE prev.history_account_id = account.id
+			AND prev.history_operation_id < m.id
+		)`,
+		xdr.OperationTypeAccountMerge, start, end,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to exec sql")
+	}
+
+	return nil
+}
+
 // Page specifies the paging constraints for the query being built by `q`.
 func (q *AccountsQ) Page(page db2.PageQuery) *AccountsQ {
 	if q.Err != nil {
@@ -77,4 +271,21 @@ func (q *Q) GetCreateAccountID(
 	return
 }
 
-var selectAccount = sq.Select("ha.*").From("history_accounts ha")
+var selectAccount = sq.Select("ha.id", "ha.address").From("history_accounts ha")
+
+var selectAccountCreation = sq.Select(
+	"ha.address",
+	"hcb.address AS created_by",
+	"hac.history_operation_id",
+	"hmi.address AS merged_into",
+).
+	From("history_account_creations hac").
+	Join("history_accounts ha ON ha.id = hac.history_account_id").
+	Join("history_accounts hcb ON hcb.id = hac.created_by_id").
+	LeftJoin("history_accounts hmi ON hmi.id = hac.merged_into_id")
+
+var accountCreationsInsert = sq.Insert("history_account_creations").Columns(
+	"history_operation_id",
+	"history_account_id",
+	"created_by_id",
+)
@@ -3,7 +3,9 @@ package history
 import (
 	"testing"
 
+	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/xdr"
 )
 
 func TestAccountQueries(t *testing.T) {
@@ -19,3 +21,82 @@ func TestAccountQueries(t *testing.T) {
 		tt.Assert.Len(acs, 4)
 	}
 }
+
+func TestAccountCreationQueries(t *testing.T) {
+	tt := test.Start(t).Scenario("account_merge")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	var root, merged xdr.AccountId
+	tt.Require.NoError(root.SetAddress("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"))
+	tt.Require.NoError(merged.SetAddress("GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"))
+
+	// recreate the merged account
+	err := q.RecordAccountCreation(merged, root, 17179873281)
+	tt.Require.NoError(err)
+
+	var latest AccountCreation
+	err = q.AccountCreationByAddress(&latest, merged.Address())
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int64(17179873281), latest.OperationID)
+		tt.Assert.False(latest.MergedInto.Valid)
+	}
+
+	// the creation of the prior incarnation is retained, along with its merge
+	var creations []AccountCreation
+	err = q.AccountCreations().
+		CreatedBy(root.Address()).
+		Page(db2.PageQuery{Order: db2.OrderAscending, Limit: 10}).
+		Select(&creations)
+	if tt.Assert.NoError(err) && tt.Assert.Len(creations, 3) {
+		tt.Assert.Equal(merged.Address(), creations[0].Address)
+		tt.Assert.Equal(int64(8589938689), creations[0].OperationID)
+		tt.Assert.Equal("GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2", creations[0].MergedInto.String)
+		tt.Assert.Equal(merged.Address(), creations[2].Address)
+		tt.Assert.Equal(int64(17179873281), creations[2].OperationID)
+	}
+
+	// merging again is recorded against the latest creation only
+	err = q.RecordAccountMerge(merged, root, 17179877377)
+	tt.Require.NoError(err)
+
+	err = q.AccountCreationByAddress(&latest, merged.Address())
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(root.Address(), latest.MergedInto.String)
+	}
+
+	// forgetting the merge leaves the prior incarnation untouched
+	err = q.ForgetAccountMerges(17179869184, 21474836480)
+	tt.Require.NoError(err)
+
+	err = q.AccountCreations().
+		CreatedBy(root.Address()).
+		Page(db2.PageQuery{Order: db2.OrderAscending, Limit: 10}).
+		Select(&creations)
+	if tt.Assert.NoError(err) && tt.Assert.Len(creations, 3) {
+		tt.Assert.True(creations[0].MergedInto.Valid)
+		tt.Assert.False(creations[2].MergedInto.Valid)
+	}
+}
+
+func TestRecordAccountMerges(t *testing.T) {
+	tt := test.Start(t).Scenario("account_merge")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	// the account is merged in ledger 3
+	err := q.ForgetAccountMerges(12884901888, 17179869184)
+	tt.Require.NoError(err)
+
+	var creation AccountCreation
+	err = q.AccountCreationByAddress(&creation, "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU")
+	tt.Require.NoError(err)
+	tt.Assert.False(creation.MergedInto.Valid)
+
+	err = q.RecordAccountMerges(12884901888, 17179869184)
+	tt.Require.NoError(err)
+
+	err = q.AccountCreationByAddress(&creation, "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU")
+	tt.Require.NoError(err)
+	tt.Assert.Equal("GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2", creation.MergedInto.String)
+}
@@ -11,6 +11,16 @@ func (q *Q) GetAssetByID(dest interface{}, id int64) (err error) {
 	return
 }
 
+// IssuedAssets loads every asset other than the native one in the
+// `history_assets` table.
+func (q *Q) IssuedAssets(dest interface{}) error {
+	sql := sq.Select("id", "asset_type", "asset_code", "asset_issuer").
+		From("history_assets").
+		Where(sq.NotEq{"asset_type": "native"}).
+		OrderBy("id")
+	return q.Select(dest, sql)
+}
+
 // GetAssetIDs fetches the ids for many Assets at once
 func (q *Q) GetAssetIDs(assets []xdr.Asset) ([]int64, error) {
 	list := make([]string, 0, len(assets))
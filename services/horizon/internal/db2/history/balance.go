@@ -0,0 +1,131 @@
+package history
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// PagingToken returns a cursor for this balance snapshot
+func (r *BalanceSnapshot) PagingToken() string {
+	return fmt.Sprintf("%d", r.LedgerSequence)
+}
+
+// BalanceSnapshots provides a helper to filter rows from the
+// `history_balance_snapshots` table with pre-defined filters.  See
+// `BalanceSnapshotsQ` methods for the available filters.
+func (q *Q) BalanceSnapshots() *BalanceSnapshotsQ {
+	return &BalanceSnapshotsQ{
+		parent: q,
+		sql:    selectBalanceSnapshot,
+	}
+}
+
+// BalancesAt loads into `dest` the most recent snapshot, taken at or before the
+// ledger `seq`, of each balance held by the account identified by `aid`.
+func (q *Q) BalancesAt(dest interface{}, aid int64, seq int32) error {
+	sql := selectBalanceSnapshot.
+		Options("DISTINCT ON (hbs.history_asset_id)").
+		Where("hbs.history_account_id = ?", aid).
+		Where("hbs.ledger_sequence <= ?", seq).
+		OrderBy("hbs.history_asset_id asc, hbs.ledger_sequence desc")
+
+	return q.Select(dest, sql)
+}
+
+// ForAccount filters the query results by the account identified by `aid`.
+func (q *BalanceSnapshotsQ) ForAccount(aid int64) *BalanceSnapshotsQ {
+	q.sql = q.sql.Where("hbs.history_account_id = ?", aid)
+	return q
+}
+
+// ForAsset filters the query results by asset.
+func (q *BalanceSnapshotsQ) ForAsset(asset xdr.Asset) *BalanceSnapshotsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	var typ, code, issuer string
+	q.Err = asset.Extract(&typ, &code, &issuer)
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql = q.sql.
+		Where("ha.asset_type = ?", typ).
+		Where("ha.asset_code = ?", code).
+		Where("ha.asset_issuer = ?", issuer)
+	return q
+}
+
+// Page specifies the paging constraints for the query being built by `q`.
+func (q *BalanceSnapshotsQ) Page(page db2.PageQuery) *BalanceSnapshotsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql, q.Err = page.ApplyTo(q.sql, "hbs.ledger_sequence")
+	return q
+}
+
+// Select loads the results of the query specified by `q` into `dest`.
+func (q *BalanceSnapshotsQ) Select(dest interface{}) error {
+	if q.Err != nil {
+		return q.Err
+	}
+
+	q.Err = q.parent.Select(dest, q.sql)
+	return q.Err
+}
+
+// InsertBalanceSnapshot records `balance` as the balance of `asset` held by the
+// account identified by `aid` at the close of the ledger `seq`.
+func (q *Q) InsertBalanceSnapshot(
+	aid int64,
+	asset xdr.Asset,
+	seq int32,
+	balance xdr.Int64,
+) error {
+	assetID, err := q.GetCreateAssetID(asset)
+	if err != nil {
+		return errors.Wrap(err, "failed to get asset id")
+	}
+
+	sql := balanceSnapshotsInsert.Values(aid, assetID, seq, balance)
+
+	_, err = q.Exec(sql)
+	if err != nil {
+		return errors.Wrap(err, "failed to exec sql")
+	}
+
+	return nil
+}
+
+var selectBalanceSnapshot = sq.Select(
+	"hbs.ledger_sequence",
+	"ha.asset_type",
+	"ha.asset_code",
+	"ha.asset_issuer",
+	"hbs.balance",
+	`hbs.balance - COALESCE((
+		SELECT prev.balance
+		FROM history_balance_snapshots prev
+		WHERE prev.history_account_id = hbs.history_account_id
+		AND prev.history_asset_id = hbs.history_asset_id
+		AND prev.ledger_sequence < hbs.ledger_sequence
+		ORDER BY prev.ledger_sequence desc
+		LIMIT 1
+	), 0) as change`,
+).
+	From("history_balance_snapshots hbs").
+	Join("history_assets ha ON hbs.history_asset_id = ha.id")
+
+var balanceSnapshotsInsert = sq.Insert("history_balance_snapshots").Columns(
+	"history_account_id",
+	"history_asset_id",
+	"ledger_sequence",
+	"balance",
+)
@@ -0,0 +1,101 @@
+package history_test
+
+import (
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/db2"
+	. "github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/xdr"
+)
+
+func TestBalanceSnapshots(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	var account xdr.AccountId
+	err := account.SetAddress("GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU")
+	tt.Require.NoError(err)
+	aid, err := q.GetCreateAccountID(account)
+	tt.Require.NoError(err)
+
+	native, err := xdr.NewAsset(xdr.AssetTypeAssetTypeNative, nil)
+	tt.Require.NoError(err)
+	usd := creditAsset("USD", "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
+
+	tt.Require.NoError(q.InsertBalanceSnapshot(aid, native, 2, 1000000000))
+	tt.Require.NoError(q.InsertBalanceSnapshot(aid, native, 3, 949999900))
+	tt.Require.NoError(q.InsertBalanceSnapshot(aid, usd, 3, 50000000))
+	tt.Require.NoError(q.InsertBalanceSnapshot(aid, native, 5, 949999800))
+
+	// the latest snapshot of each balance as of a ledger
+	var balances []BalanceSnapshot
+	err = q.BalancesAt(&balances, aid, 4)
+	tt.Require.NoError(err)
+	if tt.Assert.Len(balances, 2) {
+		tt.Assert.Equal("native", balances[0].AssetType)
+		tt.Assert.Equal(int32(3), balances[0].LedgerSequence)
+		tt.Assert.Equal(xdr.Int64(949999900), balances[0].Balance)
+		tt.Assert.Equal("USD", balances[1].AssetCode)
+		tt.Assert.Equal(xdr.Int64(50000000), balances[1].Balance)
+	}
+
+	err = q.BalancesAt(&balances, aid, 2)
+	tt.Require.NoError(err)
+	if tt.Assert.Len(balances, 1) {
+		tt.Assert.Equal(xdr.Int64(1000000000), balances[0].Balance)
+	}
+
+	// the snapshots of a single balance, with the change from the previous one
+	var snapshots []BalanceSnapshot
+	err = q.BalanceSnapshots().
+		ForAccount(aid).
+		ForAsset(native).
+		Page(db2.MustPageQuery("", "asc", 10)).
+		Select(&snapshots)
+	tt.Require.NoError(err)
+	if tt.Assert.Len(snapshots, 3) {
+		tt.Assert.Equal(xdr.Int64(1000000000), snapshots[0].Change)
+		tt.Assert.Equal(xdr.Int64(-50000100), snapshots[1].Change)
+		tt.Assert.Equal(xdr.Int64(-100), snapshots[2].Change)
+		tt.Assert.Equal("5", snapshots[2].PagingToken())
+	}
+
+	// the change is computed from snapshots before the page
+	err = q.BalanceSnapshots().
+		ForAccount(aid).
+		ForAsset(native).
+		Page(db2.MustPageQuery("3", "asc", 10)).
+		Select(&snapshots)
+	tt.Require.NoError(err)
+	if tt.Assert.Len(snapshots, 1) {
+		tt.Assert.Equal(int32(5), snapshots[0].LedgerSequence)
+		tt.Assert.Equal(xdr.Int64(-100), snapshots[0].Change)
+	}
+
+	err = q.BalanceSnapshots().
+		ForAccount(aid).
+		ForAsset(native).
+		Page(db2.MustPageQuery("5", "desc", 1)).
+		Select(&snapshots)
+	tt.Require.NoError(err)
+	if tt.Assert.Len(snapshots, 1) {
+		tt.Assert.Equal(int32(3), snapshots[0].LedgerSequence)
+	}
+}
+
+func creditAsset(code, issuer string) (asset xdr.Asset) {
+	var aid xdr.AccountId
+	err := aid.SetAddress(issuer)
+	if err != nil {
+		panic(err)
+	}
+
+	err = asset.SetCredit(code, aid)
+	if err != nil {
+		panic(err)
+	}
+
+	return
+}
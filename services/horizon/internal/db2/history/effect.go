@@ -85,6 +85,20 @@ func (q *EffectsQ) ForLedger(seq int32) *EffectsQ {
 	return q
 }
 
+// ForLedgerRange filters the query to only effects in the ledgers from
+// `from` to `to`, inclusive.  A zero bound leaves that end of the range open.
+func (q *EffectsQ) ForLedgerRange(from, to int32) *EffectsQ {
+	if from > 0 {
+		q.sql = q.sql.Where("heff.history_operation_id > ?", toid.AfterLedger(from-1).ToInt64())
+	}
+
+	if to > 0 {
+		q.sql = q.sql.Where("heff.history_operation_id <= ?", toid.AfterLedger(to).ToInt64())
+	}
+
+	return q
+}
+
 // ForOperation filters the query to only effects in a specific operation,
 // specified by its id.
 func (q *EffectsQ) ForOperation(id int64) *EffectsQ {
@@ -142,6 +156,12 @@ func (q *EffectsQ) OfType(typ EffectType) *EffectsQ {
 	return q
 }
 
+// OfTypes filters the query to only effects of the given types.
+func (q *EffectsQ) OfTypes(types ...EffectType) *EffectsQ {
+	q.sql = q.sql.Where(sq.Eq{"heff.type": types})
+	return q
+}
+
 // Page specifies the paging constraints for the query being built by `q`.
 func (q *EffectsQ) Page(page db2.PageQuery) *EffectsQ {
 	if q.Err != nil {
@@ -0,0 +1,53 @@
+package history
+
+// CapacityUsage returns the fraction of the sampled ledgers' transaction set
+// capacity that was used, between 0 and 1.
+func (r *FeeStats) CapacityUsage() float64 {
+	if r.MaxTxSetSize == 0 {
+		return 0
+	}
+
+	return float64(r.TransactionCount) / float64(r.MaxTxSetSize)
+}
+
+// FeeStats loads a summary of the fees paid per operation by the transactions
+// in the ledgers from `first` to `last`, inclusive.  Fee statistics are zero
+// when no transactions were included in the range.
+func (q *Q) FeeStats(dest *FeeStats, first, last int32) error {
+	err := q.GetRaw(dest, `
+		SELECT
+			COALESCE(MIN(fee_per_op), 0) AS min,
+			COALESCE(mode() WITHIN GROUP (ORDER BY fee_per_op), 0) AS mode,
+			COALESCE(percentile_disc(0.10) WITHIN GROUP (ORDER BY fee_per_op), 0) AS p10,
+			COALESCE(percentile_disc(0.20) WITHIN GROUP (ORDER BY fee_per_op), 0) AS p20,
+			COALESCE(percentile_disc(0.30) WITHIN GROUP (ORDER BY fee_per_op), 0) AS p30,
+			COALESCE(percentile_disc(0.40) WITHIN GROUP (ORDER BY fee_per_op), 0) AS p40,
+			COALESCE(percentile_disc(0.50) WITHIN GROUP (ORDER BY fee_per_op), 0) AS p50,
+			COALESCE(percentile_disc(0.60) WITHIN GROUP (ORDER BY fee_per_op), 0) AS p60,
+			COALESCE(percentile_disc(0.70) WITHIN GROUP (ORDER BY fee_per_op), 0) AS p70,
+			COALESCE(percentile_disc(0.80) WITHIN GROUP (ORDER BY fee_per_op), 0) AS p80,
+			COALESCE(percentile_disc(0.90) WITHIN GROUP (ORDER BY fee_per_op), 0) AS p90,
+			COALESCE(percentile_disc(0.95) WITHIN GROUP (ORDER BY fee_per_op), 0) AS p95,
+			COALESCE(percentile_disc(0.99) WITHIN GROUP (ORDER BY fee_per_op), 0) AS p99
+		FROM (
+			SELECT fee_paid / operation_count AS fee_per_op
+			FROM history_transactions
+			WHERE ledger_sequence >= $1
+			AND ledger_sequence <= $2
+			AND operation_count > 0
+		) AS fees
+	`, first, last)
+	if err != nil {
+		return err
+	}
+
+	return q.GetRaw(dest, `
+		SELECT
+			COUNT(*) AS ledger_count,
+			COALESCE(SUM(transaction_count), 0) AS transaction_count,
+			COALESCE(SUM(max_tx_set_size), 0) AS max_tx_set_size
+		FROM history_ledgers
+		WHERE sequence >= $1
+		AND sequence <= $2
+	`, first, last)
+}
@@ -0,0 +1,34 @@
+package history_test
+
+import (
+	"testing"
+
+	. "github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/test"
+)
+
+func TestFeeStats(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	var stats FeeStats
+	err := q.FeeStats(&stats, 1, 3)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(3), stats.LedgerCount)
+		tt.Assert.Equal(int64(4), stats.TransactionCount)
+		tt.Assert.Equal(int64(100), stats.Min)
+		tt.Assert.Equal(int64(100), stats.Mode)
+		tt.Assert.Equal(int64(100), stats.P99)
+		tt.Assert.True(stats.CapacityUsage() > 0)
+	}
+
+	// empty range
+	stats = FeeStats{}
+	err = q.FeeStats(&stats, 100, 110)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(0), stats.LedgerCount)
+		tt.Assert.Equal(int64(0), stats.Min)
+		tt.Assert.Equal(float64(0), stats.CapacityUsage())
+	}
+}
@@ -2,6 +2,7 @@ package history
 
 import (
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/stellar/go/services/horizon/internal/db2"
@@ -17,6 +18,30 @@ func (q *Q) LedgerBySequence(dest interface{}, seq int32) error {
 	return q.Get(dest, sql)
 }
 
+// FirstLedgerClosedAtOrAfter loads into `dest` the sequence of the first ledger
+// closed at or after `t`.
+func (q *Q) FirstLedgerClosedAtOrAfter(dest interface{}, t time.Time) error {
+	sql := sq.Select("hl.sequence").
+		From("history_ledgers hl").
+		Where(sq.GtOrEq{"hl.closed_at": t}).
+		OrderBy("hl.closed_at ASC").
+		Limit(1)
+
+	return q.Get(dest, sql)
+}
+
+// LastLedgerClosedBefore loads into `dest` the sequence of the last ledger
+// closed before `t`.
+func (q *Q) LastLedgerClosedBefore(dest interface{}, t time.Time) error {
+	sql := sq.Select("hl.sequence").
+		From("history_ledgers hl").
+		Where(sq.Lt{"hl.closed_at": t}).
+		OrderBy("hl.closed_at DESC").
+		Limit(1)
+
+	return q.Get(dest, sql)
+}
+
 // Ledgers provides a helper to filter rows from the `history_ledgers` table
 // with pre-defined filters.  See `LedgersQ` methods for the available filters.
 func (q *Q) Ledgers() *LedgersQ {
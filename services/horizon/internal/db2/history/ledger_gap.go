@@ -0,0 +1,6 @@
+package history
+
+// Len returns the number of ledgers in the gap.
+func (g LedgerGap) Len() int32 {
+	return g.End - g.Start + 1
+}
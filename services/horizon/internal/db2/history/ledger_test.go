@@ -3,6 +3,7 @@ package history
 import (
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/stellar/go/services/horizon/internal/test"
 )
@@ -44,3 +45,60 @@ func TestLedgerQueries(t *testing.T) {
 		tt.Assert.Contains(foundSeqs, int32(3))
 	}
 }
+
+func TestLedgerClosedAtQueries(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	// ledger 2 closed at 02:23:15 and ledger 3 at 02:23:16
+	at := time.Date(2017, 11, 30, 2, 23, 16, 0, time.UTC)
+
+	var seq int32
+	err := q.FirstLedgerClosedAtOrAfter(&seq, at)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(3), seq)
+	}
+
+	err = q.LastLedgerClosedBefore(&seq, at)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(2), seq)
+	}
+
+	err = q.FirstLedgerClosedAtOrAfter(&seq, at.Add(time.Second))
+	tt.Assert.Equal(sql.ErrNoRows, err)
+}
+
+func TestLedgerGaps(t *testing.T) {
+	tt := test.Start(t).Scenario("kahuna")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	var gaps []LedgerGap
+	tt.Require.NoError(q.LedgerGaps(&gaps, 0))
+	tt.Assert.Empty(gaps)
+
+	_, err := tt.HorizonSession().ExecRaw(
+		`DELETE FROM history_ledgers WHERE sequence BETWEEN 10 AND 12`,
+	)
+	tt.Require.NoError(err)
+
+	_, err = tt.HorizonSession().ExecRaw(
+		`UPDATE history_ledgers SET previous_ledger_hash = ? WHERE sequence = 30`,
+		"0000000000000000000000000000000000000000000000000000000000000000",
+	)
+	tt.Require.NoError(err)
+
+	_, err = tt.HorizonSession().ExecRaw(
+		`UPDATE history_ledgers SET importer_version = 0 WHERE sequence BETWEEN 40 AND 42`,
+	)
+	tt.Require.NoError(err)
+
+	tt.Require.NoError(q.LedgerGaps(&gaps, 0))
+	tt.Assert.Equal([]LedgerGap{
+		{Start: 10, End: 12, Missing: true},
+		{Start: 29, End: 30, Missing: false},
+		{Start: 40, End: 42, Incomplete: true},
+	}, gaps)
+	tt.Assert.Equal(int32(3), gaps[0].Len())
+}
@@ -99,12 +99,50 @@ const (
 
 )
 
+const (
+	// OfferEventCreated occurs when an offer is first placed on the books
+	OfferEventCreated OfferEventType = 0 // from manage_offer, create_passive_offer
+
+	// OfferEventUpdated occurs when an offer's owner changes its amount or price
+	OfferEventUpdated OfferEventType = 1 // from manage_offer
+
+	// OfferEventPartiallyFilled occurs when a trade consumes a portion of an
+	// offer, leaving the remainder on the books.
+	OfferEventPartiallyFilled OfferEventType = 2 // from manage_offer, create_passive_offer, path_payment
+
+	// OfferEventFilled occurs when a trade consumes the remainder of an offer
+	OfferEventFilled OfferEventType = 3 // from manage_offer, create_passive_offer, path_payment
+
+	// OfferEventCanceled occurs when an offer is removed from the books without
+	// being filled, either by its owner or because stellar-core found it to be
+	// unfundable.
+	OfferEventCanceled OfferEventType = 4 // from manage_offer, create_passive_offer, path_payment
+)
+
 // Account is a row of data from the `history_accounts` table
 type Account struct {
 	ID      int64
 	Address string `db:"address"`
 }
 
+// AccountCreation is a row of data from the `history_account_creations` table,
+// representing a single creation of an account along with the account the
+// remainder of its balance was merged into, if it has since been merged.
+type AccountCreation struct {
+	Address     string      `db:"address"`
+	CreatedBy   string      `db:"created_by"`
+	OperationID int64       `db:"history_operation_id"`
+	MergedInto  null.String `db:"merged_into"`
+}
+
+// AccountCreationsQ is a helper struct to aid in configuring queries that
+// loads slices of account creation structs.
+type AccountCreationsQ struct {
+	Err    error
+	parent *Q
+	sql    sq.SelectBuilder
+}
+
 // AccountsQ is a helper struct to aid in configuring queries that loads
 // slices of account structs.
 type AccountsQ struct {
@@ -130,6 +168,26 @@ type AssetStat struct {
 	Toml        string `db:"toml"`
 }
 
+// BalanceSnapshot is a row of data from the `history_balance_snapshots` table,
+// joined with the asset whose balance it records.  Change is the difference
+// between the balance and that of the previous snapshot of the same asset.
+type BalanceSnapshot struct {
+	LedgerSequence int32     `db:"ledger_sequence"`
+	AssetType      string    `db:"asset_type"`
+	AssetCode      string    `db:"asset_code"`
+	AssetIssuer    string    `db:"asset_issuer"`
+	Balance        xdr.Int64 `db:"balance"`
+	Change         xdr.Int64 `db:"change"`
+}
+
+// BalanceSnapshotsQ is a helper struct to aid in configuring queries that loads
+// slices of BalanceSnapshot structs.
+type BalanceSnapshotsQ struct {
+	Err    error
+	parent *Q
+	sql    sq.SelectBuilder
+}
+
 // Effect is a row of data from the `history_effects` table
 type Effect struct {
 	HistoryAccountID   int64       `db:"history_account_id"`
@@ -152,6 +210,28 @@ type EffectsQ struct {
 // `history_effects` table.
 type EffectType int
 
+// FeeStats summarizes the fees paid per operation by the transactions in a
+// range of ledgers, along with how much of those ledgers' capacity was used.
+// Fees are expressed in stroops per operation.
+type FeeStats struct {
+	Min              int64 `db:"min"`
+	Mode             int64 `db:"mode"`
+	P10              int64 `db:"p10"`
+	P20              int64 `db:"p20"`
+	P30              int64 `db:"p30"`
+	P40              int64 `db:"p40"`
+	P50              int64 `db:"p50"`
+	P60              int64 `db:"p60"`
+	P70              int64 `db:"p70"`
+	P80              int64 `db:"p80"`
+	P90              int64 `db:"p90"`
+	P95              int64 `db:"p95"`
+	P99              int64 `db:"p99"`
+	LedgerCount      int32 `db:"ledger_count"`
+	TransactionCount int64 `db:"transaction_count"`
+	MaxTxSetSize     int64 `db:"max_tx_set_size"`
+}
+
 // Ledger is a row of data from the `history_ledgers` table
 type Ledger struct {
 	TotalOrderID
@@ -172,6 +252,23 @@ type Ledger struct {
 	ProtocolVersion    int32       `db:"protocol_version"`
 }
 
+// LedgerGap is a discontinuity between two consecutive rows of the
+// `history_ledgers` table.  Reingesting the ledgers from Start to End,
+// inclusive, closes it.
+type LedgerGap struct {
+	Start int32 `db:"start"`
+	End   int32 `db:"end"`
+
+	// Missing is true when the ledgers from Start to End are absent.
+	Missing bool `db:"missing"`
+
+	// Incomplete is true when the ledgers from Start to End are present, but
+	// were ingested without the meta of their transactions.  When neither
+	// Missing nor Incomplete is set, the ledger at End does not follow the hash
+	// of the ledger at Start.
+	Incomplete bool `db:"incomplete"`
+}
+
 // LedgerCache is a helper struct to load ledger data related to a batch of
 // sequences.
 type LedgerCache struct {
@@ -189,6 +286,40 @@ type LedgersQ struct {
 	sql    sq.SelectBuilder
 }
 
+// OfferEvent is a row of data from the `history_offer_events` table, joined
+// with asset information from the assets table and the seller's address from
+// the accounts table.
+type OfferEvent struct {
+	HistoryOperationID int64          `db:"history_operation_id"`
+	Order              int32          `db:"order"`
+	OfferID            int64          `db:"offer_id"`
+	Type               OfferEventType `db:"type"`
+	LedgerCloseTime    time.Time      `db:"ledger_closed_at"`
+	SellerAccount      string         `db:"seller_account"`
+	SellingAssetType   string         `db:"selling_asset_type"`
+	SellingAssetCode   string         `db:"selling_asset_code"`
+	SellingAssetIssuer string         `db:"selling_asset_issuer"`
+	BuyingAssetType    string         `db:"buying_asset_type"`
+	BuyingAssetCode    string         `db:"buying_asset_code"`
+	BuyingAssetIssuer  string         `db:"buying_asset_issuer"`
+	Amount             xdr.Int64      `db:"amount"`
+	Pricen             int32          `db:"pricen"`
+	Priced             int32          `db:"priced"`
+	Price              float64        `db:"price"`
+}
+
+// OfferEventType is the numeric type for an offer event, used as the `type`
+// field in the `history_offer_events` table.
+type OfferEventType int
+
+// OfferEventsQ is a helper struct to aid in configuring queries that loads
+// slices of offer event structs.
+type OfferEventsQ struct {
+	Err    error
+	parent *Q
+	sql    sq.SelectBuilder
+}
+
 // Operation is a row of data from the `history_operations` table
 type Operation struct {
 	TotalOrderID
@@ -208,12 +339,81 @@ type OperationsQ struct {
 	sql    sq.SelectBuilder
 }
 
+// OrderBookLevel is a single price level of one side of an order book
+// snapshot: the summed amount of the offers selling at a given price.
+type OrderBookLevel struct {
+	Pricen int32 `json:"n"`
+	Priced int32 `json:"d"`
+	Amount int64 `json:"amount"`
+}
+
+// OrderBookSnapshot is a row of data from the `history_order_book_snapshots`
+// table.  Each row records the best price levels of the offers selling one
+// asset for another as of the close of a ledger.
+type OrderBookSnapshot struct {
+	LedgerSequence int32  `db:"ledger_sequence"`
+	LevelsString   string `db:"levels"`
+}
+
 // Q is a helper struct on which to hang common_trades queries against a history
 // portion of the horizon database.
 type Q struct {
 	*db.Session
 }
 
+// SignerStatesQ is a helper struct to aid in configuring queries that page
+// through the signer states of an account.
+type SignerStatesQ struct {
+	Err    error
+	parent *Q
+	aid    int64
+	sql    sq.SelectBuilder
+}
+
+// SignerHistory is an ordered series of the signer states of an account.
+type SignerHistory []SignerState
+
+// SignerState represents the signers and thresholds of an account immediately
+// after an operation that changed them, reconstructed from the effects
+// recorded in the history database.
+type SignerState struct {
+	HistoryOperationID int64
+	// Removed is true when the account was merged away by the operation.
+	Removed       bool
+	LowThreshold  byte
+	MedThreshold  byte
+	HighThreshold byte
+	// Signers is ordered by public key.
+	Signers []SignerWeight
+}
+
+// SignerWeight represents one of the signers of an account and its weight.
+type SignerWeight struct {
+	PublicKey string
+	Weight    int32
+}
+
+// Ticker represents the latest price and the trading statistics of the past
+// day for an asset pair, computed from the `history_trade_aggregations` table.
+type Ticker struct {
+	BaseAssetID        int64   `db:"base_asset_id"`
+	BaseAssetType      string  `db:"base_asset_type"`
+	BaseAssetCode      string  `db:"base_asset_code"`
+	BaseAssetIssuer    string  `db:"base_asset_issuer"`
+	CounterAssetID     int64   `db:"counter_asset_id"`
+	CounterAssetType   string  `db:"counter_asset_type"`
+	CounterAssetCode   string  `db:"counter_asset_code"`
+	CounterAssetIssuer string  `db:"counter_asset_issuer"`
+	LastPrice          float64 `db:"last_price"`
+	TradeCount         int64   `db:"count"`
+	BaseVolume         int64   `db:"base_volume"`
+	CounterVolume      int64   `db:"counter_volume"`
+	Open               float64 `db:"open"`
+	High               float64 `db:"high"`
+	Low                float64 `db:"low"`
+	Close              float64 `db:"close"`
+}
+
 // TotalOrderID represents the ID portion of rows that are identified by the
 // "TotalOrderID".  See total_order_id.go in the `db` package for details.
 type TotalOrderID struct {
@@ -290,6 +490,54 @@ func (q *Q) LatestLedger(dest interface{}) error {
 	return q.GetRaw(dest, `SELECT COALESCE(MAX(sequence), 0) FROM history_ledgers`)
 }
 
+// LedgerGaps loads into `dest` the gaps in the `history_ledgers` table, in
+// ascending order: ledgers missing between two rows, rows whose previous ledger
+// hash does not match the hash of the ledger before them, and runs of ledgers
+// ingested with the importer version `incompleteVersion`, which lack the data
+// derived from the meta of their transactions.  The query scans the whole
+// table, so keep it out of latency sensitive code paths.
+func (q *Q) LedgerGaps(dest interface{}, incompleteVersion int) error {
+	return q.SelectRaw(dest, `
+		SELECT start, "end", missing, incomplete
+		FROM (
+			SELECT
+				CASE WHEN sequence = prev_sequence + 1
+					THEN prev_sequence ELSE prev_sequence + 1 END AS start,
+				CASE WHEN sequence = prev_sequence + 1
+					THEN sequence ELSE sequence - 1 END AS "end",
+				sequence <> prev_sequence + 1 AS missing,
+				false AS incomplete
+
+			FROM (
+				SELECT
+					sequence,
+					previous_ledger_hash,
+					LAG(sequence, 1) OVER (ORDER BY sequence) AS prev_sequence,
+					LAG(ledger_hash, 1) OVER (ORDER BY sequence) AS prev_hash
+				FROM history_ledgers
+			) seqs
+
+			WHERE prev_sequence IS NOT NULL
+			AND (
+				sequence <> prev_sequence + 1
+				OR previous_ledger_hash IS DISTINCT FROM prev_hash
+			)
+
+			UNION ALL
+
+			SELECT MIN(sequence), MAX(sequence), false, true
+			FROM (
+				SELECT
+					sequence,
+					sequence - ROW_NUMBER() OVER (ORDER BY sequence) AS run
+				FROM history_ledgers
+				WHERE importer_version = $1
+			) runs
+			GROUP BY run
+		) gaps
+		ORDER BY start ASC`, incompleteVersion)
+}
+
 // OldestOutdatedLedgers populates a slice of ints with the first million
 // outdated ledgers, based upon the provided `currentVersion` number
 func (q *Q) OldestOutdatedLedgers(dest interface{}, currentVersion int) error {
@@ -300,3 +548,24 @@ func (q *Q) OldestOutdatedLedgers(dest interface{}, currentVersion int) error {
 		ORDER BY sequence ASC
 		LIMIT 1000000`, currentVersion)
 }
+
+// LedgerCountWithVersion loads into `dest` the number of ledgers from `start`
+// to `end`, inclusive, that were ingested with exactly the importer version
+// `version`.
+func (q *Q) LedgerCountWithVersion(dest interface{}, start, end int32, version int) error {
+	return q.GetRaw(dest, `
+		SELECT COUNT(*)
+		FROM history_ledgers
+		WHERE sequence BETWEEN $1 AND $2
+		AND importer_version = $3`, start, end, version)
+}
+
+// CurrentLedgerCount loads into `dest` the number of ledgers from `start` to
+// `end`, inclusive, that were ingested with the provided `currentVersion`.
+func (q *Q) CurrentLedgerCount(dest interface{}, start, end int32, currentVersion int) error {
+	return q.GetRaw(dest, `
+		SELECT COUNT(*)
+		FROM history_ledgers
+		WHERE sequence BETWEEN $1 AND $2
+		AND importer_version >= $3`, start, end, currentVersion)
+}
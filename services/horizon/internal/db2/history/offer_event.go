@@ -0,0 +1,213 @@
+package history
+
+import (
+	"fmt"
+	"math"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/toid"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/time"
+	"github.com/stellar/go/xdr"
+)
+
+// LedgerSequence return the ledger in which the offer event occurred.
+func (r *OfferEvent) LedgerSequence() int32 {
+	id := toid.Parse(r.HistoryOperationID)
+	return id.LedgerSequence
+}
+
+// PagingToken returns a cursor for this offer event
+func (r *OfferEvent) PagingToken() string {
+	return fmt.Sprintf("%d-%d", r.HistoryOperationID, r.Order)
+}
+
+// String returns the name of the offer event type, as used in horizon
+// responses.
+func (t OfferEventType) String() string {
+	switch t {
+	case OfferEventCreated:
+		return "created"
+	case OfferEventUpdated:
+		return "updated"
+	case OfferEventPartiallyFilled:
+		return "partially_filled"
+	case OfferEventFilled:
+		return "filled"
+	case OfferEventCanceled:
+		return "canceled"
+	default:
+		return "unknown"
+	}
+}
+
+// OfferEvents provides a helper to filter rows from the `history_offer_events`
+// table with pre-defined filters.  See `OfferEventsQ` methods for the available
+// filters.
+func (q *Q) OfferEvents() *OfferEventsQ {
+	return &OfferEventsQ{
+		parent: q,
+		sql:    selectOfferEvent,
+	}
+}
+
+// LatestOfferEvent loads the most recent event recorded for the offer
+// identified by `id` into `dest`.
+func (q *Q) LatestOfferEvent(dest interface{}, id int64) error {
+	sql := selectOfferEvent.
+		Where("hoe.offer_id = ?", id).
+		OrderBy("hoe.history_operation_id desc, hoe.order desc").
+		Limit(1)
+
+	return q.Get(dest, sql)
+}
+
+// ForOffer filters the query results by the offer id.
+func (q *OfferEventsQ) ForOffer(id int64) *OfferEventsQ {
+	q.sql = q.sql.Where("hoe.offer_id = ?", id)
+	return q
+}
+
+// Page specifies the paging constraints for the query being built by `q`.
+func (q *OfferEventsQ) Page(page db2.PageQuery) *OfferEventsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	op, idx, err := page.CursorInt64Pair(db2.DefaultPairSep)
+	if err != nil {
+		q.Err = err
+		return q
+	}
+
+	if idx > math.MaxInt32 {
+		idx = math.MaxInt32
+	}
+
+	switch page.Order {
+	case "asc":
+		q.sql = q.sql.
+			Where(`(
+					 hoe.history_operation_id > ?
+				OR (
+							hoe.history_operation_id = ?
+					AND hoe.order > ?
+				))`, op, op, idx).
+			OrderBy("hoe.history_operation_id asc, hoe.order asc")
+	case "desc":
+		q.sql = q.sql.
+			Where(`(
+					 hoe.history_operation_id < ?
+				OR (
+							hoe.history_operation_id = ?
+					AND hoe.order < ?
+				))`, op, op, idx).
+			OrderBy("hoe.history_operation_id desc, hoe.order desc")
+	}
+
+	q.sql = q.sql.Limit(page.Limit)
+	return q
+}
+
+// Select loads the results of the query specified by `q` into `dest`.
+func (q *OfferEventsQ) Select(dest interface{}) error {
+	if q.Err != nil {
+		return q.Err
+	}
+
+	q.Err = q.parent.Select(dest, q.sql)
+	return q.Err
+}
+
+var selectOfferEvent = sq.Select(
+	"hoe.history_operation_id",
+	"hoe.\"order\"",
+	"hoe.offer_id",
+	"hoe.type",
+	"hoe.ledger_closed_at",
+	"seller_accounts.address as seller_account",
+	"selling_assets.asset_type as selling_asset_type",
+	"selling_assets.asset_code as selling_asset_code",
+	"selling_assets.asset_issuer as selling_asset_issuer",
+	"buying_assets.asset_type as buying_asset_type",
+	"buying_assets.asset_code as buying_asset_code",
+	"buying_assets.asset_issuer as buying_asset_issuer",
+	"hoe.amount",
+	"hoe.pricen",
+	"hoe.priced",
+	"hoe.price",
+).
+	From("history_offer_events hoe").
+	Join("history_accounts seller_accounts ON hoe.seller_account_id = seller_accounts.id").
+	Join("history_assets selling_assets ON hoe.selling_asset_id = selling_assets.id").
+	Join("history_assets buying_assets ON hoe.buying_asset_id = buying_assets.id")
+
+var offerEventsInsert = sq.Insert("history_offer_events").Columns(
+	"history_operation_id",
+	"\"order\"",
+	"offer_id",
+	"type",
+	"ledger_closed_at",
+	"seller_account_id",
+	"selling_asset_id",
+	"buying_asset_id",
+	"amount",
+	"pricen",
+	"priced",
+	"price",
+)
+
+// InsertOfferEvent records a change to the state of an offer into the
+// history_offer_events table.  `offer` should be the state of the offer after
+// the event occurred or, for events that remove the offer from the books, its
+// last known state.
+func (q *Q) InsertOfferEvent(
+	opid int64,
+	order int32,
+	typ OfferEventType,
+	offer xdr.OfferEntry,
+	ledgerClosedAt time.Millis,
+) error {
+	sellerAccountID, err := q.GetCreateAccountID(offer.SellerId)
+	if err != nil {
+		return errors.Wrap(err, "failed to load seller account id")
+	}
+
+	sellingAssetID, err := q.GetCreateAssetID(offer.Selling)
+	if err != nil {
+		return errors.Wrap(err, "failed to get selling asset id")
+	}
+
+	buyingAssetID, err := q.GetCreateAssetID(offer.Buying)
+	if err != nil {
+		return errors.Wrap(err, "failed to get buying asset id")
+	}
+
+	amount := offer.Amount
+	if typ == OfferEventFilled || typ == OfferEventCanceled {
+		amount = 0
+	}
+
+	sql := offerEventsInsert.Values(
+		opid,
+		order,
+		int64(offer.OfferId),
+		typ,
+		ledgerClosedAt.ToTime(),
+		sellerAccountID,
+		sellingAssetID,
+		buyingAssetID,
+		amount,
+		offer.Price.N,
+		offer.Price.D,
+		float64(offer.Price.N)/float64(offer.Price.D),
+	)
+
+	_, err = q.Exec(sql)
+	if err != nil {
+		return errors.Wrap(err, "failed to exec sql")
+	}
+
+	return nil
+}
@@ -30,6 +30,13 @@ func (r *Operation) UnmarshalDetails(dest interface{}) error {
 	return err
 }
 
+// detailString returns the string value of `key` in `dets`, or the empty
+// string if it is missing.
+func detailString(dets map[string]interface{}, key string) string {
+	v, _ := dets[key].(string)
+	return v
+}
+
 // Operations provides a helper to filter the operations table with pre-defined
 // filters.  See `OperationsQ` for the available filters.
 func (q *Q) Operations() *OperationsQ {
@@ -48,6 +55,14 @@ func (q *Q) OperationByID(dest interface{}, id int64) error {
 	return q.Get(dest, sql)
 }
 
+// OperationsByIDs loads the operations whose id is in `ids` into `dest`
+func (q *Q) OperationsByIDs(dest interface{}, ids []int64) error {
+	sql := selectOperation.
+		Where(sq.Eq{"hop.id": ids})
+
+	return q.Select(dest, sql)
+}
+
 // ForAccount filters the operations collection to a specific account
 func (q *OperationsQ) ForAccount(aid string) *OperationsQ {
 	var account Account
@@ -84,6 +99,28 @@ func (q *OperationsQ) ForLedger(seq int32) *OperationsQ {
 	return q
 }
 
+// ForMemo filters the query to only operations of transactions whose memo is
+// of type `memoType` and has the value `memo`, in the form recorded during
+// ingestion.
+func (q *OperationsQ) ForMemo(memoType, memo string) *OperationsQ {
+	q.sql = q.sql.Where("ht.memo_type = ? AND ht.memo = ?", memoType, memo)
+	return q
+}
+
+// ForLedgerRange filters the query to only operations in the ledgers from
+// `from` to `to`, inclusive.  A zero bound leaves that end of the range open.
+func (q *OperationsQ) ForLedgerRange(from, to int32) *OperationsQ {
+	if from > 0 {
+		q.sql = q.sql.Where("hop.id > ?", toid.AfterLedger(from-1).ToInt64())
+	}
+
+	if to > 0 {
+		q.sql = q.sql.Where("hop.id <= ?", toid.AfterLedger(to).ToInt64())
+	}
+
+	return q
+}
+
 // ForTransaction filters the query to a only operations in a specific
 // transaction, specified by the transactions's hex-encoded hash.
 func (q *OperationsQ) ForTransaction(hash string) *OperationsQ {
@@ -105,6 +142,13 @@ func (q *OperationsQ) ForTransaction(hash string) *OperationsQ {
 	return q
 }
 
+// OfTypes filters the query being built to only include operations of the
+// given types.
+func (q *OperationsQ) OfTypes(types ...xdr.OperationType) *OperationsQ {
+	q.sql = q.sql.Where(sq.Eq{"hop.type": types})
+	return q
+}
+
 // OnlyPayments filters the query being built to only include operations that
 // are in the "payment" class of operations:  CreateAccountOps, Payments, and
 // PathPayments.
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/xdr"
 )
 
 func TestOperationQueries(t *testing.T) {
@@ -37,6 +38,29 @@ func TestOperationQueries(t *testing.T) {
 		tt.Assert.Len(ops, 3)
 	}
 
+	// ledger range filter works
+	ops = []Operation{}
+	err = q.Operations().ForLedgerRange(3, 0).Select(&ops)
+
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(ops, 1)
+	}
+
+	ops = []Operation{}
+	err = q.Operations().ForLedgerRange(1, 2).Select(&ops)
+
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(ops, 3)
+	}
+
+	// type filter works
+	ops = []Operation{}
+	err = q.Operations().OfTypes(xdr.OperationTypePayment).Select(&ops)
+
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(ops, 1)
+	}
+
 	// tx filter works
 	hash := "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
 	ops = []Operation{}
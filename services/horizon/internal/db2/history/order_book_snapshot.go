@@ -0,0 +1,108 @@
+package history
+
+import (
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// Levels unmarshals the price levels recorded in this snapshot, ordered from
+// the best (lowest) price to the worst.
+func (r *OrderBookSnapshot) Levels() ([]OrderBookLevel, error) {
+	var levels []OrderBookLevel
+	err := json.Unmarshal([]byte(r.LevelsString), &levels)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal levels failed")
+	}
+
+	return levels, nil
+}
+
+// OrderBookSnapshotAt loads into `dest` the most recent snapshot of the offers
+// selling the asset identified by `sellingAssetID` for the asset identified by
+// `buyingAssetID` that was taken at or before the ledger `seq`.
+func (q *Q) OrderBookSnapshotAt(
+	dest interface{},
+	sellingAssetID int64,
+	buyingAssetID int64,
+	seq int32,
+) error {
+	sql := selectOrderBookSnapshot.
+		Where("hobs.selling_asset_id = ?", sellingAssetID).
+		Where("hobs.buying_asset_id = ?", buyingAssetID).
+		Where("hobs.ledger_sequence <= ?", seq).
+		OrderBy("hobs.ledger_sequence desc").
+		Limit(1)
+
+	return q.Get(dest, sql)
+}
+
+// InsertOrderBookSnapshot records the best price levels of the offers selling
+// `selling` for `buying` as of the close of the ledger `seq`, unless a snapshot
+// of them has already been recorded for that ledger.
+func (q *Q) InsertOrderBookSnapshot(
+	seq int32,
+	selling xdr.Asset,
+	buying xdr.Asset,
+	levels []OrderBookLevel,
+) error {
+	sellingAssetID, err := q.GetCreateAssetID(selling)
+	if err != nil {
+		return errors.Wrap(err, "failed to get selling asset id")
+	}
+
+	buyingAssetID, err := q.GetCreateAssetID(buying)
+	if err != nil {
+		return errors.Wrap(err, "failed to get buying asset id")
+	}
+
+	var existing int
+	err = q.Get(&existing, sq.Select("COUNT(*)").
+		From("history_order_book_snapshots hobs").
+		Where("hobs.selling_asset_id = ?", sellingAssetID).
+		Where("hobs.buying_asset_id = ?", buyingAssetID).
+		Where("hobs.ledger_sequence = ?", seq))
+	if err != nil {
+		return errors.Wrap(err, "failed to check for existing snapshot")
+	}
+	if existing > 0 {
+		return nil
+	}
+
+	if levels == nil {
+		levels = []OrderBookLevel{}
+	}
+
+	ljson, err := json.Marshal(levels)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal levels")
+	}
+
+	sql := orderBookSnapshotsInsert.Values(
+		seq,
+		sellingAssetID,
+		buyingAssetID,
+		ljson,
+	)
+
+	_, err = q.Exec(sql)
+	if err != nil {
+		return errors.Wrap(err, "failed to exec sql")
+	}
+
+	return nil
+}
+
+var selectOrderBookSnapshot = sq.Select(
+	"hobs.ledger_sequence",
+	"hobs.levels",
+).From("history_order_book_snapshots hobs")
+
+var orderBookSnapshotsInsert = sq.Insert("history_order_book_snapshots").Columns(
+	"ledger_sequence",
+	"selling_asset_id",
+	"buying_asset_id",
+	"levels",
+)
@@ -0,0 +1,262 @@
+package history
+
+import (
+	"fmt"
+	"sort"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/toid"
+	"github.com/stellar/go/support/errors"
+)
+
+// LedgerSequence returns the ledger in which the state was reached.
+func (r *SignerState) LedgerSequence() int32 {
+	id := toid.Parse(r.HistoryOperationID)
+	return id.LedgerSequence
+}
+
+// PagingToken returns a cursor for this signer state
+func (r SignerState) PagingToken() string {
+	return fmt.Sprintf("%d", r.HistoryOperationID)
+}
+
+// SignerHistory loads the signer states of the account identified by
+// `address` that were reached at or before the ledger `seq`, since the account
+// was last created or removed, by folding the account's `account_created`,
+// `account_removed`, `account_thresholds_updated` and `signer_*` effects.
+func (q *Q) SignerHistory(dest *SignerHistory, address string, seq int32) error {
+	var account Account
+	err := q.AccountByAddress(&account, address)
+	if err != nil {
+		return err
+	}
+
+	last := toid.New(seq+1, 0, 0).ToInt64() - 1
+
+	var effects []Effect
+	err = q.signerEffects(&effects, account.ID, last, last)
+	if err != nil {
+		return err
+	}
+
+	*dest, err = NewSignerHistory(effects)
+	return err
+}
+
+// SignerStates provides a helper to page through the signer states of an
+// account.  See `SignerStatesQ` methods for the available filters.
+func (q *Q) SignerStates() *SignerStatesQ {
+	return &SignerStatesQ{
+		parent: q,
+		sql:    selectSignerOperation,
+	}
+}
+
+// ForAccount filters the query results by the account identified by `aid`.
+func (q *SignerStatesQ) ForAccount(aid int64) *SignerStatesQ {
+	q.aid = aid
+	q.sql = q.sql.Where("heff.history_account_id = ?", aid)
+	return q
+}
+
+// Page specifies the paging constraints for the query being built by `q`.
+func (q *SignerStatesQ) Page(page db2.PageQuery) *SignerStatesQ {
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql, q.Err = page.ApplyTo(q.sql, "heff.history_operation_id")
+	return q
+}
+
+// Select loads the results of the query specified by `q` into `dest`.  The
+// operations with signer effects are paged in the database; the state after
+// each of them is then reconstructed from the effects recorded since the
+// account was last created or removed before the page.  An operation that
+// leaves the signers and thresholds as they were is skipped, so a page may hold
+// fewer states than its limit.
+func (q *SignerStatesQ) Select(dest *SignerHistory) error {
+	if q.Err != nil {
+		return q.Err
+	}
+
+	var ids []int64
+	q.Err = q.parent.Select(&ids, q.sql)
+	if q.Err != nil {
+		return q.Err
+	}
+
+	*dest = SignerHistory{}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// ids are in page order, which may be descending
+	first, last := ids[0], ids[len(ids)-1]
+	if first > last {
+		first, last = last, first
+	}
+
+	var effects []Effect
+	q.Err = q.parent.signerEffects(&effects, q.aid, first, last)
+	if q.Err != nil {
+		return q.Err
+	}
+
+	states, err := NewSignerHistory(effects)
+	if err != nil {
+		q.Err = err
+		return q.Err
+	}
+
+	byID := map[int64]SignerState{}
+	for _, state := range states {
+		byID[state.HistoryOperationID] = state
+	}
+
+	for _, id := range ids {
+		if state, ok := byID[id]; ok {
+			*dest = append(*dest, state)
+		}
+	}
+
+	return nil
+}
+
+// signerEffects loads into `dest`, in order, the signer effects of the account
+// identified by `aid` from the last creation or removal of the account at or
+// before the operation `first` through the operation `last`.
+func (q *Q) signerEffects(dest interface{}, aid int64, first, last int64) error {
+	sql := selectEffect.
+		Where("heff.history_account_id = ?", aid).
+		Where(sq.Eq{"heff.type": signerEffectTypes}).
+		Where(`heff.history_operation_id >= COALESCE((
+			SELECT MAX(reset.history_operation_id)
+			FROM history_effects reset
+			WHERE reset.history_account_id = ?
+			AND reset.type IN (?, ?)
+			AND reset.history_operation_id <= ?
+		), 0)`, aid, EffectAccountCreated, EffectAccountRemoved, first).
+		Where("heff.history_operation_id <= ?", last).
+		OrderBy("heff.history_operation_id asc, heff.order asc")
+
+	err := q.Select(dest, sql)
+	if err != nil {
+		return errors.Wrap(err, "select effects failed")
+	}
+
+	return nil
+}
+
+// NewSignerHistory folds `effects`, which must be ordered, into the signer
+// states of an account.  A state is recorded for each operation after which
+// the account's signers or thresholds differ from the previous state.
+func NewSignerHistory(effects []Effect) (SignerHistory, error) {
+	result := SignerHistory{}
+	current := SignerState{}
+	signers := map[string]int32{}
+
+	flush := func() {
+		if current.HistoryOperationID == 0 {
+			return
+		}
+
+		current.Signers = make([]SignerWeight, 0, len(signers))
+		for key, weight := range signers {
+			current.Signers = append(current.Signers, SignerWeight{
+				PublicKey: key,
+				Weight:    weight,
+			})
+		}
+		sort.Sort(byPublicKey(current.Signers))
+
+		if len(result) > 0 && result[len(result)-1].sameAs(current) {
+			return
+		}
+		result = append(result, current)
+	}
+
+	for _, effect := range effects {
+		if effect.HistoryOperationID != current.HistoryOperationID {
+			flush()
+			current.HistoryOperationID = effect.HistoryOperationID
+		}
+
+		var dets map[string]interface{}
+		err := effect.UnmarshalDetails(&dets)
+		if err != nil {
+			return nil, err
+		}
+
+		switch effect.Type {
+		case EffectAccountCreated, EffectAccountRemoved:
+			current = SignerState{
+				HistoryOperationID: effect.HistoryOperationID,
+				Removed:            effect.Type == EffectAccountRemoved,
+			}
+			signers = map[string]int32{}
+		case EffectAccountThresholdsUpdated:
+			// only the thresholds that were changed are present
+			if v, ok := dets["low_threshold"].(float64); ok {
+				current.LowThreshold = byte(v)
+			}
+			if v, ok := dets["med_threshold"].(float64); ok {
+				current.MedThreshold = byte(v)
+			}
+			if v, ok := dets["high_threshold"].(float64); ok {
+				current.HighThreshold = byte(v)
+			}
+		case EffectSignerCreated, EffectSignerUpdated:
+			weight, _ := dets["weight"].(float64)
+			signers[detailString(dets, "public_key")] = int32(weight)
+		case EffectSignerRemoved:
+			delete(signers, detailString(dets, "public_key"))
+		}
+	}
+	flush()
+
+	return result, nil
+}
+
+// sameAs returns true if `other` has the same signers and thresholds as `r`.
+func (r *SignerState) sameAs(other SignerState) bool {
+	if r.Removed != other.Removed ||
+		r.LowThreshold != other.LowThreshold ||
+		r.MedThreshold != other.MedThreshold ||
+		r.HighThreshold != other.HighThreshold ||
+		len(r.Signers) != len(other.Signers) {
+		return false
+	}
+
+	for i := range r.Signers {
+		if r.Signers[i] != other.Signers[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// byPublicKey orders signers by their public key.
+type byPublicKey []SignerWeight
+
+func (s byPublicKey) Len() int           { return len(s) }
+func (s byPublicKey) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byPublicKey) Less(i, j int) bool { return s[i].PublicKey < s[j].PublicKey }
+
+// signerEffectTypes are the types of the effects that change the signers or
+// thresholds of an account.
+var signerEffectTypes = []EffectType{
+	EffectAccountCreated,
+	EffectAccountRemoved,
+	EffectAccountThresholdsUpdated,
+	EffectSignerCreated,
+	EffectSignerRemoved,
+	EffectSignerUpdated,
+}
+
+var selectSignerOperation = sq.Select("heff.history_operation_id").
+	From("history_effects heff").
+	Where(sq.Eq{"heff.type": signerEffectTypes}).
+	GroupBy("heff.history_operation_id")
@@ -0,0 +1,102 @@
+package history_test
+
+import (
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/db2"
+	. "github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/test"
+)
+
+func TestSignerHistory(t *testing.T) {
+	tt := test.Start(t).Scenario("set_options")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	const (
+		scott = "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"
+		added = "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4"
+	)
+
+	var history SignerHistory
+	err := q.SignerHistory(&history, scott, 11)
+	tt.Require.NoError(err)
+
+	// creation, master weight, thresholds, signer added, signer weight changed
+	// and signer removed.  Operations that leave the signers and thresholds
+	// untouched are not recorded.
+	if tt.Assert.Len(history, 6) {
+		tt.Assert.Equal(int32(2), history[0].LedgerSequence())
+		tt.Assert.Equal([]SignerWeight{{scott, 1}}, history[0].Signers)
+
+		tt.Assert.Equal(int32(5), history[1].LedgerSequence())
+		tt.Assert.Equal([]SignerWeight{{scott, 2}}, history[1].Signers)
+
+		tt.Assert.Equal(int32(6), history[2].LedgerSequence())
+		tt.Assert.Equal(byte(0), history[2].LowThreshold)
+		tt.Assert.Equal(byte(2), history[2].MedThreshold)
+		tt.Assert.Equal(byte(2), history[2].HighThreshold)
+
+		tt.Assert.Equal(int32(9), history[4].LedgerSequence())
+		tt.Assert.Equal([]SignerWeight{{added, 5}, {scott, 2}}, history[4].Signers)
+
+		tt.Assert.Equal(int32(11), history[5].LedgerSequence())
+		tt.Assert.Equal([]SignerWeight{{scott, 2}}, history[5].Signers)
+		tt.Assert.Equal(byte(2), history[5].HighThreshold)
+	}
+
+	// as of an earlier ledger
+	err = q.SignerHistory(&history, scott, 8)
+	tt.Require.NoError(err)
+	if tt.Assert.Len(history, 4) {
+		tt.Assert.Equal([]SignerWeight{{added, 1}, {scott, 2}}, history[3].Signers)
+	}
+
+}
+
+func TestSignerStatesQueries(t *testing.T) {
+	tt := test.Start(t).Scenario("set_options")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	const scott = "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"
+
+	var account Account
+	tt.Require.NoError(q.AccountByAddress(&account, scott))
+
+	var all SignerHistory
+	tt.Require.NoError(q.SignerHistory(&all, scott, 11))
+	tt.Require.Len(all, 6)
+
+	// every state, in order
+	var states SignerHistory
+	err := q.SignerStates().
+		ForAccount(account.ID).
+		Page(db2.PageQuery{Order: db2.OrderAscending, Limit: 10}).
+		Select(&states)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(all, states)
+
+	// a page from the middle of the history is reconstructed in full
+	err = q.SignerStates().
+		ForAccount(account.ID).
+		Page(db2.PageQuery{
+			Cursor: all[2].PagingToken(),
+			Order:  db2.OrderAscending,
+			Limit:  2,
+		}).
+		Select(&states)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(SignerHistory{all[3], all[4]}, states)
+
+	err = q.SignerStates().
+		ForAccount(account.ID).
+		Page(db2.PageQuery{
+			Cursor: all[1].PagingToken(),
+			Order:  db2.OrderDescending,
+			Limit:  10,
+		}).
+		Select(&states)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(SignerHistory{all[0]}, states)
+}
@@ -0,0 +1,120 @@
+package history
+
+import (
+	"fmt"
+
+	. "github.com/stellar/go/support/time"
+)
+
+// TickerWindow is the length, in milliseconds, of the period over which the
+// trading statistics of a ticker are computed.
+const TickerWindow = 24 * 60 * 60 * 1000
+
+// Tickers loads into `dest` a ticker for every asset pair that has ever been
+// traded, with its trading statistics computed from the trades that closed at
+// or after `since`.  Pairs are in the canonical order they are stored in.
+func (q *Q) Tickers(dest interface{}, since Millis) error {
+	return q.SelectRaw(dest, tickerSql("", ""), since.ToInt64())
+}
+
+// TickerForAssetPair loads into `dest` the ticker of the pair of assets
+// identified by `baseAssetID` and `counterAssetID`, with its trading statistics
+// computed from the trades that closed at or after `since`.
+func (q *Q) TickerForAssetPair(
+	dest *Ticker,
+	baseAssetID int64,
+	counterAssetID int64,
+	since Millis,
+) error {
+	orderPreserved, baseAssetID, counterAssetID := getCanonicalAssetOrder(baseAssetID, counterAssetID)
+
+	pair := "AND base_asset_id = ? AND counter_asset_id = ?"
+	err := q.GetRaw(
+		dest,
+		tickerSql(pair, pair),
+		baseAssetID, counterAssetID,
+		since.ToInt64(),
+		baseAssetID, counterAssetID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if !orderPreserved {
+		dest.reverse()
+	}
+
+	return nil
+}
+
+// reverse swaps the base and counter assets of the ticker, inverting its
+// prices.
+func (t *Ticker) reverse() {
+	t.BaseAssetID, t.CounterAssetID = t.CounterAssetID, t.BaseAssetID
+	t.BaseAssetType, t.CounterAssetType = t.CounterAssetType, t.BaseAssetType
+	t.BaseAssetCode, t.CounterAssetCode = t.CounterAssetCode, t.BaseAssetCode
+	t.BaseAssetIssuer, t.CounterAssetIssuer = t.CounterAssetIssuer, t.BaseAssetIssuer
+	t.BaseVolume, t.CounterVolume = t.CounterVolume, t.BaseVolume
+
+	t.LastPrice = 1 / t.LastPrice
+	t.Open = 1 / t.Open
+	t.Close = 1 / t.Close
+	t.High, t.Low = 1/t.Low, 1/t.High
+}
+
+// tickerSql generates the sql statement that loads tickers.  The last price of
+// a pair is the close of its latest daily rollup, and its trading statistics
+// are aggregated from the minute rollups within the window.  Pairs without
+// trades in the window report the last price as their open, high, low and
+// close.  `lastFilter` and `windowFilter` are appended to the conditions of
+// the respective subqueries.
+func tickerSql(lastFilter string, windowFilter string) string {
+	return fmt.Sprintf(`
+		SELECT
+			l.base_asset_id,
+			ba.asset_type AS base_asset_type,
+			ba.asset_code AS base_asset_code,
+			ba.asset_issuer AS base_asset_issuer,
+			l.counter_asset_id,
+			ca.asset_type AS counter_asset_type,
+			ca.asset_code AS counter_asset_code,
+			ca.asset_issuer AS counter_asset_issuer,
+			l.last_price,
+			COALESCE(w.count, 0) AS count,
+			COALESCE(w.base_volume, 0) AS base_volume,
+			COALESCE(w.counter_volume, 0) AS counter_volume,
+			COALESCE(w.open, l.last_price) AS open,
+			COALESCE(w.high, l.last_price) AS high,
+			COALESCE(w.low, l.last_price) AS low,
+			COALESCE(w.close, l.last_price) AS close
+		FROM (
+			SELECT DISTINCT ON (base_asset_id, counter_asset_id)
+				base_asset_id,
+				counter_asset_id,
+				close AS last_price
+			FROM history_trade_aggregations
+			WHERE resolution = %d %s
+			ORDER BY base_asset_id, counter_asset_id, timestamp DESC
+		) l
+		LEFT JOIN (
+			SELECT
+				base_asset_id,
+				counter_asset_id,
+				sum(count) AS count,
+				sum(base_volume) AS base_volume,
+				sum(counter_volume) AS counter_volume,
+				first(open ORDER BY timestamp) AS open,
+				max(high) AS high,
+				min(low) AS low,
+				last(close ORDER BY timestamp) AS close
+			FROM history_trade_aggregations
+			WHERE resolution = %d AND timestamp >= ? %s
+			GROUP BY base_asset_id, counter_asset_id
+		) w ON w.base_asset_id = l.base_asset_id AND w.counter_asset_id = l.counter_asset_id
+		JOIN history_assets ba ON ba.id = l.base_asset_id
+		JOIN history_assets ca ON ca.id = l.counter_asset_id
+		ORDER BY l.base_asset_id, l.counter_asset_id`,
+		TradeAggregationResolutions[0], lastFilter,
+		TradeAggregationResolutions[len(TradeAggregationResolutions)-1], windowFilter,
+	)
+}
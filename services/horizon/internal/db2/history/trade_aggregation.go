@@ -2,12 +2,24 @@ package history
 
 import (
 	"fmt"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/support/errors"
 	. "github.com/stellar/go/support/time"
 )
 
+// TradeAggregationResolutions are the resolutions, in milliseconds, at which
+// trades are rolled up into the `history_trade_aggregations` table, from the
+// largest to the smallest.
+var TradeAggregationResolutions = []int64{
+	86400000, // 1 day
+	3600000,  // 1 hour
+	900000,   // 15 minutes
+	60000,    // 1 minute
+}
+
 // Trade aggregation represents an aggregation of trades from the trades table
 type TradeAggregation struct {
 	Timestamp     int64   `db:"timestamp"`
@@ -27,6 +39,7 @@ type TradeAggregationsQ struct {
 	baseAssetId    int64
 	counterAssetId int64
 	resolution     int64
+	offset         int64
 	startTime      Millis
 	endTime        Millis
 	pagingParams   db2.PageQuery
@@ -42,39 +55,64 @@ func (q Q) GetTradeAggregationsQ(baseAssetId int64, counterAssetId int64, resolu
 	}
 }
 
+// WithOffset shifts the boundaries of the buckets forward from the epoch by
+// `offset` millis, e.g. to align daily buckets with midnight in a time zone
+// other than UTC.
+func (q *TradeAggregationsQ) WithOffset(offset int64) *TradeAggregationsQ {
+	q.offset = offset
+	return q
+}
+
 // WithStartTime adds an optional lower time boundary filter to the trades being aggregated
 func (q *TradeAggregationsQ) WithStartTime(startTime Millis) *TradeAggregationsQ {
-	// Round lower boundary up, if start time is in the middle of a bucket
-	q.startTime = startTime.RoundUp(q.resolution)
+	q.startTime = startTime
 	return q
 }
 
 // WithEndTime adds an upper optional time boundary filter to the trades being aggregated
 func (q *TradeAggregationsQ) WithEndTime(endTime Millis) *TradeAggregationsQ {
-	// Round upper boundary down, to not deliver partial bucket
-	q.endTime = endTime.RoundDown(q.resolution)
+	q.endTime = endTime
 	return q
 }
 
-// Generate a sql statement to aggregate Trades based on given parameters
+// Generate a sql statement to aggregate Trades based on given parameters.  The
+// buckets are built from the largest rolled up resolution that evenly divides
+// both the resolution and the offset of the query, falling back to the
+// `history_trades` table when there is none.
 func (q *TradeAggregationsQ) GetSql() sq.SelectBuilder {
 	var orderPreserved bool
 	orderPreserved, q.baseAssetId, q.counterAssetId = getCanonicalAssetOrder(q.baseAssetId, q.counterAssetId)
 
+	// Round lower boundary up, if start time is in the middle of a bucket, and
+	// round upper boundary down, to not deliver partial buckets
+	startTime, endTime := q.startTime, q.endTime
+	if !startTime.IsNil() {
+		startTime = q.roundUp(startTime)
+	}
+	if !endTime.IsNil() {
+		endTime = q.roundDown(endTime)
+	}
+
+	for _, rollup := range TradeAggregationResolutions {
+		if q.resolution%rollup == 0 && q.offset%rollup == 0 {
+			return q.rollupSql(rollup, orderPreserved, startTime, endTime)
+		}
+	}
+
 	var bucketSql sq.SelectBuilder
 	if orderPreserved {
-		bucketSql = bucketTrades(q.resolution)
+		bucketSql = bucketTrades(q.resolution, q.offset)
 	} else {
-		bucketSql = reverseBucketTrades(q.resolution)
+		bucketSql = reverseBucketTrades(q.resolution, q.offset)
 	}
 
 	bucketSql = bucketSql.From("history_trades").
 		Where(sq.Eq{"base_asset_id": q.baseAssetId, "counter_asset_id": q.counterAssetId})
 
 	//adjust time range and apply time filters
-	bucketSql = bucketSql.Where(sq.GtOrEq{"ledger_closed_at": q.startTime.ToTime()})
+	bucketSql = bucketSql.Where(sq.GtOrEq{"ledger_closed_at": startTime.ToTime()})
 	if !q.endTime.IsNil() {
-		bucketSql = bucketSql.Where(sq.Lt{"ledger_closed_at": q.endTime.ToTime()})
+		bucketSql = bucketSql.Where(sq.Lt{"ledger_closed_at": endTime.ToTime()})
 	}
 
 	//ensure open/close order for cases when multiple trades occur in the same ledger
@@ -96,17 +134,78 @@ func (q *TradeAggregationsQ) GetSql() sq.SelectBuilder {
 		OrderBy("timestamp " + q.pagingParams.Order)
 }
 
-// formatBucketTimestampSelect formats a sql select clause for a bucketed timestamp, based on given resolution
-func formatBucketTimestampSelect(resolution int64) string {
-	return fmt.Sprintf("div(cast((extract(epoch from ledger_closed_at) * 1000 ) as bigint), %d)*%d as timestamp",
-		resolution, resolution)
+// roundUp rounds `t` up to the nearest bucket boundary.
+func (q *TradeAggregationsQ) roundUp(t Millis) Millis {
+	shifted := MillisFromInt64(t.ToInt64() - q.offset).RoundUp(q.resolution)
+	return MillisFromInt64(shifted.ToInt64() + q.offset)
 }
 
+// roundDown rounds `t` down to the nearest bucket boundary.
+func (q *TradeAggregationsQ) roundDown(t Millis) Millis {
+	shifted := MillisFromInt64(t.ToInt64() - q.offset).RoundDown(q.resolution)
+	return MillisFromInt64(shifted.ToInt64() + q.offset)
+}
+
+// rollupSql generates a sql statement that aggregates the rows of the
+// `history_trade_aggregations` table at resolution `rollup` into buckets.
+func (q *TradeAggregationsQ) rollupSql(
+	rollup int64,
+	orderPreserved bool,
+	startTime Millis,
+	endTime Millis,
+) sq.SelectBuilder {
+	var bucketSql sq.SelectBuilder
+	if orderPreserved {
+		bucketSql = bucketRollups(q.resolution, q.offset)
+	} else {
+		bucketSql = reverseBucketRollups(q.resolution, q.offset)
+	}
+
+	bucketSql = bucketSql.From("history_trade_aggregations").
+		Where(sq.Eq{
+			"resolution":       rollup,
+			"base_asset_id":    q.baseAssetId,
+			"counter_asset_id": q.counterAssetId,
+		}).
+		Where(sq.GtOrEq{"timestamp": startTime.ToInt64()})
+
+	if !q.endTime.IsNil() {
+		bucketSql = bucketSql.Where(sq.Lt{"timestamp": endTime.ToInt64()})
+	}
+
+	return sq.Select(
+		"timestamp",
+		"sum(count) as count",
+		"sum(base_volume) as base_volume",
+		"sum(counter_volume) as counter_volume",
+		"sum(price_sum) / sum(count)::float as avg",
+		"max(high) as high",
+		"min(low) as low",
+		"first(open ORDER BY rollup_timestamp) as open",
+		"last(close ORDER BY rollup_timestamp) as close").
+		FromSelect(bucketSql, "htrd").
+		GroupBy("timestamp").
+		Limit(q.pagingParams.Limit).
+		OrderBy("timestamp " + q.pagingParams.Order)
+}
+
+// formatBucketTimestampSelect formats a sql select clause for a bucketed
+// timestamp, based on given resolution and offset, from the millis since epoch
+// given by the sql expression `millis`.
+func formatBucketTimestampSelect(millis string, resolution int64, offset int64) string {
+	return fmt.Sprintf("div(%s - %d, %d)*%d + %d as timestamp",
+		millis, offset, resolution, resolution, offset)
+}
+
+// tradeMillis is the sql expression of the close time of a trade's ledger, as
+// millis since epoch.
+const tradeMillis = "cast((extract(epoch from ledger_closed_at) * 1000 ) as bigint)"
+
 // bucketTrades generates a select statement to filter rows from the `history_trades` table in
 // a compact form, with a timestamp rounded to resolution and reversed base/counter.
-func bucketTrades(resolution int64) sq.SelectBuilder {
+func bucketTrades(resolution int64, offset int64) sq.SelectBuilder {
 	return sq.Select(
-		formatBucketTimestampSelect(resolution),
+		formatBucketTimestampSelect(tradeMillis, resolution, offset),
 		"history_operation_id",
 		"\"order\"",
 		"base_asset_id",
@@ -119,9 +218,9 @@ func bucketTrades(resolution int64) sq.SelectBuilder {
 
 // reverseBucketTrades generates a select statement to filter rows from the `history_trades` table in
 // a compact form, with a timestamp rounded to resolution and reversed base/counter.
-func reverseBucketTrades(resolution int64) sq.SelectBuilder {
+func reverseBucketTrades(resolution int64, offset int64) sq.SelectBuilder {
 	return sq.Select(
-		formatBucketTimestampSelect(resolution),
+		formatBucketTimestampSelect(tradeMillis, resolution, offset),
 		"history_operation_id",
 		"\"order\"",
 		"counter_asset_id as base_asset_id",
@@ -131,3 +230,223 @@ func reverseBucketTrades(resolution int64) sq.SelectBuilder {
 		"base_amount::float/counter_amount as price",
 	)
 }
+
+// bucketRollups generates a select statement to filter rows from the
+// `history_trade_aggregations` table, with a timestamp rounded to resolution.
+func bucketRollups(resolution int64, offset int64) sq.SelectBuilder {
+	return sq.Select(
+		formatBucketTimestampSelect("timestamp", resolution, offset),
+		"timestamp as rollup_timestamp",
+		"count",
+		"base_volume",
+		"counter_volume",
+		"price_sum",
+		"high",
+		"low",
+		"open",
+		"close",
+	)
+}
+
+// reverseBucketRollups generates a select statement to filter rows from the
+// `history_trade_aggregations` table, with a timestamp rounded to resolution
+// and reversed base/counter.
+func reverseBucketRollups(resolution int64, offset int64) sq.SelectBuilder {
+	return sq.Select(
+		formatBucketTimestampSelect("timestamp", resolution, offset),
+		"timestamp as rollup_timestamp",
+		"count",
+		"counter_volume as base_volume",
+		"base_volume as counter_volume",
+		"inverse_price_sum as price_sum",
+		"1 / low as high",
+		"1 / high as low",
+		"1 / open as open",
+		"1 / close as close",
+	)
+}
+
+// TradeBucket identifies the bucket, at the smallest resolution of
+// TradeAggregationResolutions, that a trade of an asset pair is rolled up into.
+type TradeBucket struct {
+	BaseAssetID    int64 `db:"base_asset_id"`
+	CounterAssetID int64 `db:"counter_asset_id"`
+	Timestamp      int64 `db:"timestamp"`
+}
+
+// minuteResolution is the smallest resolution of TradeAggregationResolutions,
+// the only one that is computed from the `history_trades` table.  The others
+// are rolled up from its buckets.
+const minuteResolution = 60000
+
+// tradeBucketBatchSize is the number of buckets refreshed by each statement of
+// RefreshTradeAggregationBuckets.
+const tradeBucketBatchSize = 1000
+
+// TradeBuckets loads the buckets that contain the trades of the operations
+// whose ids are in the range [start, end).
+func (q *Q) TradeBuckets(dest *[]TradeBucket, start int64, end int64) error {
+	return q.SelectRaw(dest, fmt.Sprintf(`
+		SELECT DISTINCT base_asset_id, counter_asset_id, %s
+		FROM history_trades
+		WHERE history_operation_id >= ? AND history_operation_id < ?`,
+		formatBucketTimestampSelect(tradeMillis, minuteResolution, 0),
+	), start, end)
+}
+
+// RefreshTradeAggregations recomputes, at every resolution of
+// TradeAggregationResolutions, the rolled up buckets that contain the trades
+// of the operations whose ids are in the range [start, end).
+func (q *Q) RefreshTradeAggregations(start int64, end int64) error {
+	var buckets []TradeBucket
+	err := q.TradeBuckets(&buckets, start, end)
+	if err != nil {
+		return errors.Wrap(err, "failed to load trade buckets")
+	}
+
+	return q.RefreshTradeAggregationBuckets(buckets)
+}
+
+// RefreshTradeAggregationBuckets recomputes `buckets` from the trades they
+// currently contain, along with the buckets of the larger resolutions that
+// contain them.  Buckets left without trades are removed, so that clearing a
+// range of history is followed by a refresh of the buckets loaded with
+// TradeBuckets beforehand.
+func (q *Q) RefreshTradeAggregationBuckets(buckets []TradeBucket) error {
+	for len(buckets) > 0 {
+		n := len(buckets)
+		if n > tradeBucketBatchSize {
+			n = tradeBucketBatchSize
+		}
+
+		err := q.refreshTradeAggregationBuckets(buckets[:n])
+		if err != nil {
+			return err
+		}
+		buckets = buckets[n:]
+	}
+
+	return nil
+}
+
+func (q *Q) refreshTradeAggregationBuckets(buckets []TradeBucket) error {
+	values, args := tradeBucketValues(buckets)
+
+	_, err := q.ExecRaw(fmt.Sprintf(`
+		DELETE FROM history_trade_aggregations hta
+		USING %s
+		WHERE hta.resolution = %d
+		AND hta.base_asset_id = b.base_asset_id
+		AND hta.counter_asset_id = b.counter_asset_id
+		AND hta.timestamp = b.timestamp`,
+		values, minuteResolution,
+	), args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete trade aggregations")
+	}
+
+	_, err = q.ExecRaw(fmt.Sprintf(`
+		INSERT INTO history_trade_aggregations
+		SELECT
+			%d,
+			b.base_asset_id,
+			b.counter_asset_id,
+			b.timestamp,
+			count(*),
+			sum(htrd.base_amount),
+			sum(htrd.counter_amount),
+			sum(htrd.counter_amount::float / htrd.base_amount),
+			sum(htrd.base_amount::float / htrd.counter_amount),
+			max(htrd.counter_amount::float / htrd.base_amount),
+			min(htrd.counter_amount::float / htrd.base_amount),
+			first(htrd.counter_amount::float / htrd.base_amount ORDER BY htrd.history_operation_id, htrd."order"),
+			last(htrd.counter_amount::float / htrd.base_amount ORDER BY htrd.history_operation_id, htrd."order")
+		FROM %s
+		JOIN history_trades htrd
+		ON htrd.base_asset_id = b.base_asset_id
+		AND htrd.counter_asset_id = b.counter_asset_id
+		AND htrd.ledger_closed_at >= timestamp 'epoch' + b.timestamp * interval '1 millisecond'
+		AND htrd.ledger_closed_at < timestamp 'epoch' + (b.timestamp + %d) * interval '1 millisecond'
+		GROUP BY b.base_asset_id, b.counter_asset_id, b.timestamp`,
+		minuteResolution, values, minuteResolution,
+	), args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert trade aggregations")
+	}
+
+	for _, resolution := range TradeAggregationResolutions {
+		if resolution == minuteResolution {
+			continue
+		}
+
+		rollups := fmt.Sprintf(`(
+			SELECT DISTINCT base_asset_id, counter_asset_id, %s
+			FROM %s
+		) r`,
+			formatBucketTimestampSelect("b.timestamp", resolution, 0), values,
+		)
+
+		_, err = q.ExecRaw(fmt.Sprintf(`
+			DELETE FROM history_trade_aggregations hta
+			USING %s
+			WHERE hta.resolution = %d
+			AND hta.base_asset_id = r.base_asset_id
+			AND hta.counter_asset_id = r.counter_asset_id
+			AND hta.timestamp = r.timestamp`,
+			rollups, resolution,
+		), args...)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete trade aggregations")
+		}
+
+		_, err = q.ExecRaw(fmt.Sprintf(`
+			INSERT INTO history_trade_aggregations
+			SELECT
+				%d,
+				r.base_asset_id,
+				r.counter_asset_id,
+				r.timestamp,
+				sum(m.count),
+				sum(m.base_volume),
+				sum(m.counter_volume),
+				sum(m.price_sum),
+				sum(m.inverse_price_sum),
+				max(m.high),
+				min(m.low),
+				first(m.open ORDER BY m.timestamp),
+				last(m.close ORDER BY m.timestamp)
+			FROM %s
+			JOIN history_trade_aggregations m
+			ON m.resolution = %d
+			AND m.base_asset_id = r.base_asset_id
+			AND m.counter_asset_id = r.counter_asset_id
+			AND m.timestamp >= r.timestamp
+			AND m.timestamp < r.timestamp + %d
+			GROUP BY r.base_asset_id, r.counter_asset_id, r.timestamp`,
+			resolution, rollups, minuteResolution, resolution,
+		), args...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert trade aggregations")
+		}
+	}
+
+	return nil
+}
+
+// tradeBucketValues returns a sql VALUES list of `buckets`, aliased as `b`,
+// along with its arguments.
+func tradeBucketValues(buckets []TradeBucket) (string, []interface{}) {
+	rows := make([]string, len(buckets))
+	args := make([]interface{}, 0, 3*len(buckets))
+
+	for i, b := range buckets {
+		rows[i] = "(?::bigint, ?::bigint, ?::bigint)"
+		args = append(args, b.BaseAssetID, b.CounterAssetID, b.Timestamp)
+	}
+
+	values := fmt.Sprintf(
+		"(VALUES %s) b(base_asset_id, counter_asset_id, timestamp)",
+		strings.Join(rows, ", "),
+	)
+	return values, args
+}
@@ -16,6 +16,15 @@ func (q *Q) TransactionByHash(dest interface{}, hash string) error {
 	return q.Get(dest, sql)
 }
 
+// TransactionsByHashes is a query that loads the rows from the
+// `history_transactions` table for every hash in `hashes`.
+func (q *Q) TransactionsByHashes(dest interface{}, hashes []string) error {
+	sql := selectTransaction.
+		Where(sq.Eq{"ht.transaction_hash": hashes})
+
+	return q.Select(dest, sql)
+}
+
 // Transactions provides a helper to filter rows from the `history_transactions`
 // table with pre-defined filters.  See `TransactionsQ` methods for the
 // available filters.
@@ -61,6 +70,27 @@ func (q *TransactionsQ) ForLedger(seq int32) *TransactionsQ {
 	return q
 }
 
+// ForMemo filters the query to only transactions whose memo is of type
+// `memoType` and has the value `memo`, in the form recorded during ingestion.
+func (q *TransactionsQ) ForMemo(memoType, memo string) *TransactionsQ {
+	q.sql = q.sql.Where("ht.memo_type = ? AND ht.memo = ?", memoType, memo)
+	return q
+}
+
+// ForLedgerRange filters the query to only transactions in the ledgers from
+// `from` to `to`, inclusive.  A zero bound leaves that end of the range open.
+func (q *TransactionsQ) ForLedgerRange(from, to int32) *TransactionsQ {
+	if from > 0 {
+		q.sql = q.sql.Where("ht.id > ?", toid.AfterLedger(from-1).ToInt64())
+	}
+
+	if to > 0 {
+		q.sql = q.sql.Where("ht.id <= ?", toid.AfterLedger(to).ToInt64())
+	}
+
+	return q
+}
+
 // Page specifies the paging constraints for the query being built by `q`.
 func (q *TransactionsQ) Page(page db2.PageQuery) *TransactionsQ {
 	if q.Err != nil {
@@ -22,3 +22,21 @@ func TestTransactionQueries(t *testing.T) {
 	err = q.TransactionByHash(&tx, fake)
 	tt.Assert.Equal(err, sql.ErrNoRows)
 }
+
+func TestTransactionsForMemo(t *testing.T) {
+	tt := test.Start(t).Scenario("kahuna")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	var txs []Transaction
+	err := q.Transactions().ForMemo("id", "123").Select(&txs)
+	if tt.Assert.NoError(err) && tt.Assert.Len(txs, 1) {
+		tt.Assert.Equal("dd74eee27a59843b28a05ad08abf65eaa231b7debe4d05550c0a7a424cca5929", txs[0].TransactionHash)
+	}
+
+	txs = []Transaction{}
+	err = q.Transactions().ForMemo("text", "123").Select(&txs)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(txs, 0)
+	}
+}
@@ -2,6 +2,13 @@
 // sources:
 // .DS_Store
 // latest.sql
+// migrations/10_index_operations_and_effects_by_type.sql
+// migrations/11_index_transactions_by_memo.sql
+// migrations/12_create_order_book_snapshots_table.sql
+// migrations/13_create_trade_aggregations_table.sql
+// migrations/14_index_trade_aggregations_by_time.sql
+// migrations/15_create_account_creations_table.sql
+// migrations/16_create_balance_snapshots_table.sql
 // migrations/1_initial_schema.sql
 // migrations/2_index_participants_by_toid.sql
 // migrations/3_use_sequence_in_history_accounts.sql
@@ -11,6 +18,7 @@
 // migrations/7_modify_trades_table.sql
 // migrations/8_add_aggregators.sql
 // migrations/8_create_asset_stats_table.sql
+// migrations/9_create_offer_events_table.sql
 // DO NOT EDIT!
 
 package schema
@@ -98,7 +106,7 @@ func Ds_store() (*asset, error) {
 	return a, nil
 }
 
-var _latestSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x5c\x6b\x6f\xdb\x38\xd6\xfe\x9e\x5f\x41\x0c\x06\xb0\x03\x38\x41\x9c\xb4\xb9\x38\x6f\x06\xf0\x38\x4a\x6a\x8c\x23\xb7\xb6\xfc\x76\x8a\xc1\x80\xa0\x25\x5a\xe6\x56\x12\x55\x91\x4e\x93\x59\xec\x7f\x5f\xe8\x66\xeb\x42\x8a\x92\xad\xb4\xfb\xad\xb1\x8e\x1e\x3e\xcf\xe1\x21\x0f\x79\x48\xf5\xe4\xe4\xe8\xe4\x04\x7c\xa4\x8c\xdb\x01\x9e\x7f\x9a\x00\x0b\x71\xb4\x44\x0c\x03\x6b\xe3\xfa\x47\x27\x27\x47\xe1\xf3\xfb\x8d\xeb\x63\x0b\xac\x02\xea\xee\x0c\x9e\x71\xc0\x08\xf5\xc0\xcd\xe9\xe5\xe9\xfb\x8c\xd5\xf2\x15\xf8\x36\x0c\x5f\x2f\x98\x1c\xcd\x35\x03\x30\x8e\x38\x76\xb1\xc7\x21\x27\x2e\xa6\x1b\x0e\xee\xc0\xd9\x6d\xf4\xc8\xa1\xe6\xd7\xf2\xaf\xa6\x43\x42\x6b\xec\x99\xd4\x22\x9e\x0d\xee\x40\x67\x61\x3c\x5c\x77\x6e\x53\x38\xcf\x42\x81\x05\x4d\xea\xad\x68\xe0\x12\xcf\x86\x8c\x07\xc4\xb3\x19\xb8\x03\xd4\x4b\x30\xd6\xd8\xfc\x0a\x57\x1b\xcf\xe4\x84\x7a\x70\x49\x2d\x82\xc3\xe7\x2b\xe4\x30\x9c\x6b\xc6\x25\x1e\x74\x31\x63\xc8\x8e\x0c\xbe\xa3\xc0\x23\x9e\x7d\x9b\x70\xc7\x28\x30\xd7\xd0\x47\x7c\x0d\xee\x80\xbf\x59\x3a\xc4\xec\x85\x62\x4d\xc4\x91\x43\x43\xb3\xd8\x9f\x3a\x72\xf1\x00\xac\x48\xc0\x38\x44\xb6\xdd\x45\xde\x2b\x76\x22\xd5\x3d\xb0\xfb\xf7\xf1\x2d\x30\x5e\x7d\x3c\x00\x0f\x0b\x7d\x64\x8c\xa7\xfa\x2d\x98\x9b\x6b\xec\xa2\x41\x82\x7d\x0b\xa6\xdf\x3d\x1c\x0c\xc0\x49\xd4\x11\xa3\x99\x36\x34\xb4\xad\xb5\x1a\x1f\xcc\x34\x63\x31\xd3\xe7\x99\xdf\x8e\x00\x00\x60\x32\xd4\x1f\x17\xc3\x47\x0d\xb0\x6f\x0e\x18\x3f\x3d\x2d\x8c\xe1\xef\x13\x0d\xcc\x8d\xd9\x78\x64\x44\x16\xc3\x39\xf8\x15\xfe\x0a\xe6\xda\x44\x1b\x19\xe0\xd7\x7e\xf8\xd7\xed\x51\x5e\x9e\x83\xde\x54\x9d\x0a\xbe\x35\x71\xe7\x22\x71\x91\x6f\xbb\x02\x35\xc3\xc7\xc7\x99\xf6\x38\x34\xb4\x7a\x72\xb6\xe6\x65\x44\xd0\x8d\xd8\xcc\x43\xc5\x61\x2c\xa6\xbd\xd9\x8b\x7f\x36\xbe\x7c\xd4\xc0\x5d\x56\xdd\xb1\xa8\x07\x5a\xe5\x58\x04\x2c\x50\x4c\xbb\xa4\x9a\x61\x38\x52\x2c\xbc\x42\x1b\x87\x43\x8e\x96\x0e\x66\x3e\x32\x71\x38\x6e\x3b\xb7\xf9\xa7\xdf\x09\x5f\x43\x4a\xac\xcc\x50\xcc\xe9\x43\x8c\x61\x0e\xc3\x19\x83\xa5\xd2\xa2\xce\xac\x27\x2b\xee\xf7\x0c\x46\xa2\x86\x58\x60\x49\x6c\xe2\x71\xa0\x4f\x0d\xa0\x2f\x26\x93\x58\x0f\x72\xe9\xc6\xe3\xe2\x67\xde\xc6\x85\xc8\x34\x43\x03\x06\x88\xc7\xb1\x8d\x83\x82\xc9\xca\x41\x36\x03\xcc\x45\x8e\x53\x7e\x9f\x53\xd7\x01\xe6\x1a\x05\xc8\xe4\x38\x00\xcf\x28\x78\x25\x9e\xdd\xbd\x7c\x77\xbc\x35\x2c\x77\xaf\x4d\x03\x1f\xba\xc4\x0e\x50\x38\x6b\xed\xef\x82\x02\xce\xce\x0d\x1c\xbf\x94\x9c\xe0\xfb\x0e\xc1\x16\x44\x1c\x84\x33\x31\xe3\xc8\xf5\x41\xd8\x4f\xd1\x9f\xe0\x1f\xea\xe1\x32\xd1\x35\x61\x9c\x06\xaf\x5b\x0f\x41\x62\x41\x86\xbf\xa5\x84\xe7\xda\xa7\x85\xa6\x8f\x6a\x72\x4e\xad\x65\xa8\x49\xec\x0d\x67\x06\xf8\x3c\x36\x3e\x80\x7e\xf4\xc3\x58\x1f\xcd\xb4\x27\x4d\x37\xc0\xef\x5f\x92\x9f\xf4\x29\x78\x1a\xeb\xff\x3f\x9c\x2c\xb4\xed\xdf\xc3\x3f\x77\x7f\x8f\x86\xa3\x0f\x1a\xe8\xab\xc4\xec\xed\xf6\x22\x50\x29\xfc\xee\xb5\x87\xe1\x62\x62\x00\x0f\xbf\xf0\x67\xe4\x74\x3b\x12\xc5\x9d\xc1\x20\xc0\xb6\xe9\x20\xc6\x8e\x8b\xdd\x65\x59\x01\x66\x4c\x1c\x5a\x15\x1d\x15\x0e\x8a\x16\x94\x45\x30\x3b\x5d\xe2\x81\x11\x8f\x40\xfe\xea\x63\xc5\x08\xc8\x9a\x9b\xd4\x12\x99\xf7\xcf\xc5\xe6\x84\xb1\x0d\x0e\x04\x2f\xbc\xbf\xac\x1a\x61\x79\x21\x2d\x87\x6d\x16\xf3\x87\x05\x6d\x95\x10\x30\xfd\xac\x6b\xf7\xe0\xf7\x2f\x0a\x45\xc3\x89\xa1\xcd\x14\x82\xb6\x58\x85\xc7\xa7\xc4\x92\x71\xc3\xab\x15\x36\x5b\x88\xba\x04\x27\x09\xbb\xc2\x98\x81\xb2\xd9\x3d\xb5\xa3\x3e\x8e\xe7\x41\xa9\xe5\x2f\x34\xb0\x70\xf0\x8b\x24\x9a\xa3\x38\x16\x3f\xb2\x30\x47\xc4\x61\xe0\x5f\x8c\x7a\x4b\x79\xb0\x39\xd8\xb2\x71\x70\xb8\x1f\x12\x9c\xc4\x0f\x0c\x7f\xdb\x60\xcf\x94\x71\x8b\x8d\xe1\x1a\xb1\x75\xad\x51\xe8\x07\xf8\x99\xd0\x0d\x83\xca\x17\x13\xb7\x04\xc8\x63\x28\x5e\x5e\x47\x1d\xb1\xe5\x91\xce\x72\x67\x85\x16\x76\x1d\x51\xcf\xde\x74\x28\x13\x25\xa6\x70\xb3\xb0\xcd\x4d\xc5\x77\x02\x8c\xb8\xf2\xa5\xd8\x76\xe3\x5b\xb5\x6d\xb7\xa1\x93\xfc\xe9\xfa\x34\xe0\x38\x80\xe9\x7e\xa7\xa8\xa5\x5f\x5a\x0e\x70\xe4\x40\x93\x12\x8f\x89\x63\x70\x85\x31\xf4\x29\x75\xc4\x4f\xc3\xed\x17\x5c\x61\x59\x5f\x47\x8f\x03\xcc\x70\xf0\x2c\x33\x71\xd1\x0b\xe4\x2f\x30\x5a\x1a\x91\x7f\x64\x56\x7e\x40\x39\x35\xa9\x23\xd5\x75\x56\x63\x6e\xdd\xf5\xb3\x8f\x02\x4e\x4c\xe2\xa3\x36\xb2\xaa\x18\x56\x95\x8b\xea\xcf\x02\xea\x79\xa5\xa9\xe4\x76\xd3\x4b\x65\x1b\x3f\x2a\xdd\x34\x12\x7a\x60\xfa\xa9\x6c\xab\x9c\x8e\xc4\xe6\x15\xe9\x69\xfb\x42\x8b\xb1\xa9\xda\x72\x64\x67\x4d\xe9\xb6\x24\x5c\x91\x9b\xb1\x94\x28\x33\x1d\x98\x98\xe2\x9f\x18\xdd\x04\x26\x4e\xa3\x5b\x92\x12\xd2\x61\xde\xe9\x0c\x06\x25\x8b\x1a\xe3\x80\x07\xc8\xc2\x87\xbb\x33\x86\x29\xe4\xfb\x43\xf3\x78\x92\xd7\xf6\xc9\x2a\x74\xb5\xc2\x81\xb4\xd9\x68\xf6\x55\xad\x46\x62\xa3\x78\xe9\x5a\x69\x52\xb1\x27\x8d\x5a\xc0\x81\xb2\xad\xad\x5d\x65\x73\x5b\xab\x8a\x16\x23\x4a\x84\x41\x86\x1d\x07\x07\x60\x49\xa9\x83\x91\x17\x3f\x1b\x4d\xf5\xb9\x31\x1b\x8e\x75\xa3\xd0\x6f\x30\x23\x04\x46\x85\x38\x30\xfa\xa0\x8d\xfe\x00\xdd\x6e\x56\xe2\x6f\xe0\xec\xf8\x58\x05\x25\x7a\x3d\x55\xf5\x7f\x25\xa1\x35\xf0\x72\xa2\x0b\xf0\x05\x8f\x44\x04\x2b\x63\x7d\x3b\x94\x5b\x4d\x74\x32\xe0\xba\xa9\xae\xce\x1c\x73\x48\xb2\x93\xf1\x6b\x37\xdd\x29\x5a\xf9\x51\x09\xaf\xa1\xd8\x03\x53\x9e\xa2\xb5\x72\xd2\x93\xbd\x50\x91\xf6\x32\xaf\xb4\x1a\xab\x69\x7c\x66\x29\xd5\xde\x7d\x24\x93\xb3\x62\x4f\x53\x37\x33\x56\x27\x39\xa1\xed\xae\x69\xf9\xf2\x1c\x49\x87\x9e\x6c\x6b\xf3\x53\x36\x27\xfc\x05\x62\xef\x19\x3b\xd4\xc7\xa2\x82\x1f\x7f\x09\xb7\x0a\x1b\x87\x4b\x1e\xba\x98\x23\xc9\xa3\xd0\x0b\xb2\xc7\x8c\xd8\x1e\xe2\x9b\x00\x8b\x6a\x53\x37\x97\xc7\x7f\xfd\xbd\x5b\x5d\xfc\xfb\x3f\xa2\xf5\xc5\x5f\x7f\x17\xf7\x2c\xd8\xa5\x92\x32\xd2\x0e\xcb\xa3\x1e\xae\x5c\xad\xec\xb0\xca\x30\x89\x32\xe2\x62\xb8\xa4\x1b\xcf\x8a\x4a\xbd\xd7\x01\xf2\xec\xaa\xa2\x67\x5c\x04\x23\x56\x3a\x7a\x12\x2e\xb5\x86\x7c\x3c\x7c\xa6\xfa\xa4\x58\x47\x01\xf1\xf3\xd1\x74\xb2\x78\xd2\xc3\x2e\x9d\x6b\x46\x45\xc1\x30\x5b\x9a\xc9\x96\x0b\x9b\x2d\xdc\xdb\x13\x21\xc1\x6f\x24\xaa\x72\xc1\x5f\x47\xa4\x34\x73\xb6\x26\x53\xda\x42\x23\xa1\x8a\x69\x5e\x2c\xf5\x1e\x71\x04\x56\x34\x50\x1c\x95\x80\xfb\xa1\x31\x54\xc8\x93\x40\x56\x1d\x3f\xd4\x81\x1d\xeb\x73\x6d\x66\x80\xb1\x6e\x4c\x4b\x47\x10\x51\xc2\x9d\x83\x6e\xa7\x0f\x89\x47\x38\x41\x0e\x64\x11\xd6\x29\xfb\xe6\x74\x7a\xa0\x73\x7e\xd6\xbf\x3a\xe9\xf7\x4f\xce\x6f\x40\xff\x7a\x70\x7e\x3e\xe8\x5f\x9d\x9e\xbd\x3b\x7b\x77\x7e\x71\x72\x76\xdd\x39\xbe\xad\x87\x7e\x0e\x89\x67\xe1\x97\xbc\x57\x97\xaf\x90\x53\x62\x55\xb7\x74\x71\x75\x7d\xde\xa4\xa5\x0b\xb8\x61\x78\x9b\x35\x20\xf1\x60\xb1\x98\x5f\xdd\xde\xfb\x9b\xfe\x75\x93\xf6\xde\x41\x64\x59\xb0\x58\xa0\xa9\x6c\xe3\xfd\x79\xbf\x91\xf3\xde\xc3\x38\x43\xa5\x8b\xe5\xe8\x2c\xaf\xba\x85\xab\x8b\x66\x2a\x2e\xd3\x26\x92\x09\x4c\xdd\xc4\xe5\xd9\xcd\x65\xa3\x8e\xb9\x82\x2e\xb5\xc8\xea\xb5\xbe\x8a\xcb\x9b\xf3\x9b\x26\x2d\x5c\x47\x5d\x81\x6c\x3b\xc0\x36\xe2\x34\xa8\xee\xe9\xab\xfe\xf5\xe5\x55\x33\xf8\xac\x8f\xe2\x21\x5e\x43\xc5\xd5\xbb\xeb\x6d\x04\x4b\x06\x78\xe5\x49\x57\x93\x89\xa3\xd1\x29\x60\x38\x17\x2a\x70\x93\x93\xf9\xdd\xc5\x8a\x53\x86\xab\x4f\xc8\x7a\xa0\xdf\x8b\x8f\x90\x6b\xc8\x2d\x1f\x7e\x1d\x20\xb6\xf2\xc0\xa5\x15\xa9\xb9\xdc\xde\x44\xa8\xe8\xc0\xe5\x80\x7c\x50\x75\x7e\xd1\x02\x6c\x8d\x3a\xf1\xfe\xdd\xd4\xac\x50\xd9\x46\xb7\x55\xaf\x5e\x9a\x74\xa3\xa4\x30\xd9\x82\xcb\x05\xf5\xb9\x76\x50\xd5\x95\x90\xfd\xbb\xb2\xe9\x16\xbc\x8d\xce\x54\xad\xd0\x9a\x74\xa7\x74\xc3\xdd\xdc\x25\xd9\xbb\x2d\xd9\xec\xe0\x7f\xc5\xaf\x29\xf4\xae\xf8\xd5\x74\x91\x9b\x41\x8c\xaf\x4d\xdd\xdf\x67\x4b\x69\xc5\x06\xc1\xc7\xd9\xf8\x69\x38\xfb\x02\xfe\xd0\xbe\x80\x2e\xb1\x54\xd7\x59\x8a\x7f\xb7\xc4\xba\x80\x2a\x62\x2e\x6a\x58\xc9\xbe\xb0\x3d\x2b\xcc\xce\xbb\x4b\x0b\x70\x77\xdd\x01\x66\xef\x26\xc0\x56\xd4\xe5\x9b\x15\x89\xdb\x8b\x18\x58\xe8\xe3\x4f\x0b\x0d\x74\x77\xe6\xbd\xcc\xbd\x8d\x5e\xee\x96\x45\x43\xd7\xb4\xd3\xad\x8d\x85\x37\xea\x54\xc9\x76\x55\x31\x97\xb7\xab\x4c\xdc\x48\x95\xd2\x0a\x5a\xb5\x95\x4b\x77\xb0\xca\xa9\xaf\x5d\xf5\xb2\x66\xaa\xf4\x57\x52\x53\x7a\x20\x0e\xe9\xe5\x6b\x14\xed\xa9\x90\xb1\x7e\xaf\xfd\x59\xaf\xf2\x19\x99\xe6\x51\xc0\x54\x2f\x0e\x86\xc5\x7c\xac\x3f\x82\x25\x0f\x30\xce\x8e\x2e\x39\x9b\x78\x8c\x1d\xce\x27\xb9\x11\x55\x8b\x91\x64\x5c\x2f\xb7\xeb\xec\xbd\xe9\xec\x20\xb2\x4c\x72\x65\xe2\x3c\x9f\xd8\xb8\x57\xaa\xc3\x8a\xc8\xad\x11\x5b\x1f\xc2\x2c\x2a\x47\xd7\xa2\x55\x2c\x62\x8b\xd8\xc4\xcb\xe2\x43\xf8\xc4\x08\xf5\x18\x15\x2a\xe4\xbd\x72\x31\x5c\x38\xe4\x21\x0e\x63\x23\x7a\xbe\x07\xd3\x24\x4b\xc4\x84\x0b\x70\x59\xda\xe9\x0d\xad\x1c\x63\xd1\xc1\x6d\x2f\x3d\xa4\x95\x91\xdd\x55\xea\x0e\xa4\x49\xac\xda\x04\x77\x87\x60\x3d\xe1\x69\xb3\x82\x34\xf5\xa1\xdf\x16\xef\x04\x2b\x4b\x5d\x92\xaa\xf6\x52\x22\x16\xc0\x5f\xda\x13\x90\x60\x49\x62\x7a\x4f\x09\xf9\x13\xcd\xb2\x08\xea\x87\x51\xb9\xa6\x7b\x69\x48\xc8\xef\x30\xf6\x75\x7e\xb5\xa3\xb7\x17\xeb\xc2\xa9\xfa\x70\x5f\xe7\xe1\xb2\x94\xd3\x5b\x82\x39\x8e\x62\x46\x59\xbf\xb6\x45\xab\x84\x59\x6f\x7a\x13\x11\xe4\x71\x97\xf0\x43\xba\x75\x87\xb1\x7f\x48\xaa\xc2\x8f\x07\x56\x34\x2b\xae\x56\x07\xa4\x83\x1c\x4a\x81\xab\x85\x0b\xcc\xd2\x0b\x29\x62\x2e\xe9\x3d\x06\x87\xd2\xaf\x1b\xff\x30\x46\x79\x2c\x15\xaf\xd2\x85\x0c\x21\x3f\x1f\x91\x20\xfa\xec\xab\x15\x86\x45\x34\x15\xc7\xdc\x25\x92\x5e\xe9\x0e\x49\xaf\x74\x51\x48\x22\xa2\x85\xd1\x92\xe0\xa8\x18\x37\xcc\x49\x21\x6a\x6b\xde\x6d\xe0\x58\xa5\xdf\xe2\xa3\x99\x52\x45\x97\x7a\x30\xf9\xaa\xe1\x50\x87\x2a\x1b\xc8\xad\x8e\xd3\xaf\x34\xf2\xeb\xd1\xd8\xb0\x01\xf7\xc3\xe3\xa0\x0a\x5b\xcd\x58\x30\xca\xf2\x80\xc9\xda\x27\xc4\x0b\xf7\xf6\x7b\xc7\x43\x25\xaa\x72\xb1\x15\x1a\x29\x88\x26\x99\x2b\x84\xdc\x06\x51\x4b\x6c\x45\xd0\xca\xa4\x59\x37\x92\x33\xe0\x6d\x07\x43\x0e\x7a\x9f\x2c\x2f\x87\x2b\x5c\x61\x6f\xdf\xd1\xa5\x4b\xf2\x4a\xfa\x85\x17\xea\x8b\xc9\x7c\xb3\xf0\x66\xfe\xcf\x7e\x17\xa1\x52\x92\xb1\xad\x2f\x42\xf4\x05\xc6\x9b\xa9\x11\x7e\xee\xa1\x92\x25\x7a\xa9\xbe\xbe\x74\xeb\xfa\x66\x9a\xb6\x57\xb8\x54\x3a\xa4\x35\x86\x3c\xf4\xee\x1c\xe6\x2d\x86\x76\x11\x5d\xb8\xed\x68\x3a\xc0\xf3\xa0\xf9\x85\x6b\x4b\x23\xbc\xaa\x89\x3a\x1a\x14\xab\xe9\xca\xc6\xda\x4b\x5f\x65\xe0\x5a\xdc\xd5\x49\x2c\xbb\xc5\x79\x8b\xb0\x29\xe3\xef\xbd\xc1\x8a\x16\x71\xdb\x44\x9e\xd6\x75\xe0\x92\xd2\xaf\x7b\x7b\xb9\x02\x53\xb9\x44\xe8\x76\xd3\xef\x16\x4e\x7e\xfb\x0d\x74\x18\x75\xac\xcc\x19\x46\x67\x30\xe0\xf8\x85\x1f\x1f\xf7\x80\xdc\xd0\xa4\x56\x3d\xc3\xb8\x02\x2a\x37\x5d\xd2\x8d\xbd\xe6\xb5\x9a\xcf\x99\x56\x13\xc8\x99\x16\x28\x1c\x83\xcf\x1f\xb4\x99\x16\x07\x19\xb8\x03\x17\x17\x92\x9a\x71\xf9\xf8\x8f\x58\x70\x95\x29\xce\x3f\xfc\xf1\x63\x0e\x01\x93\x66\xc1\xc3\x74\xa6\x8d\x1f\xf5\x6d\xe1\x1d\xcc\xb4\x07\x6d\xa6\xe9\x23\x6d\x5e\xa8\x45\x47\x4f\xa7\x3a\x58\x7c\xbc\x0f\x43\x66\xa6\xc5\xff\x49\x43\xf8\xd3\xbd\x36\xd1\x0c\x0d\x8c\x86\xf3\xd1\xf0\x5e\xab\xfe\xc0\x44\xfc\xc1\xc1\xb6\x70\xd4\x9e\x33\xf2\xed\x28\x8e\x26\x64\x4c\xf2\xfe\x29\x58\x88\x9d\x95\x2c\xf4\x15\xe7\x38\x52\x4f\x24\x5b\xd9\x9f\xee\x87\x2c\x0f\x91\x17\xd2\x2a\x41\x75\xc0\x34\xf3\x40\xf9\x23\x99\x9f\xe8\x06\x09\x99\xbc\x2f\xca\x46\x2d\x07\x45\xb1\xc4\xf1\xbf\xe0\x10\x79\x68\x94\x6a\x48\x75\xa3\x43\xf6\x7f\x1a\x01\x93\xba\xbe\x83\x39\x8e\x34\xfc\x37\x00\x00\xff\xff\xea\xb7\xc1\x33\x00\x49\x00\x00")
+var _latestSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x5d\x6d\x6f\xe2\xb8\x16\xfe\xde\x5f\x61\x8d\x56\x6a\x2b\xd1\x8a\x00\x05\x4a\x6f\x47\x62\xdb\xcc\x4c\xb5\x1d\x3a\x0b\xf4\xee\x8e\x56\x2b\xcb\x24\x86\xe6\x4e\x88\xb3\x49\xe8\xb4\x7b\x75\xff\xfb\x95\xf3\x86\xed\xd8\x71\x02\xa1\x3b\xdf\x4a\x7c\x72\xce\x73\x1e\x1f\x1f\x1f\xbf\x40\xcf\xce\x8e\xce\xce\xc0\x17\x12\x46\xab\x00\xcf\x7e\xbd\x07\x36\x8a\xd0\x02\x85\x18\xd8\x9b\xb5\x7f\x74\x76\x76\x44\xdb\x6f\x37\x6b\x1f\xdb\x60\x19\x90\xf5\x56\xe0\x19\x07\xa1\x43\x3c\x70\x79\xde\x3f\xbf\x60\xa4\x16\xaf\xc0\x5f\x41\xfa\xba\x20\x72\x34\x33\xe7\x20\x8c\x50\x84\xd7\xd8\x8b\x60\xe4\xac\x31\xd9\x44\xe0\x1a\xb4\xaf\xe2\x26\x97\x58\xdf\x8a\x4f\x2d\xd7\xa1\xd2\xd8\xb3\x88\xed\x78\x2b\x70\x0d\x8e\x1f\xe7\x1f\x86\xc7\x57\x99\x3a\xcf\x46\x81\x0d\x2d\xe2\x2d\x49\xb0\x76\xbc\x15\x0c\xa3\xc0\xf1\x56\x21\xb8\x06\xc4\x4b\x75\x3c\x61\xeb\x1b\x5c\x6e\x3c\x2b\x72\x88\x07\x17\xc4\x76\x30\x6d\x5f\x22\x37\xc4\x9c\x99\xb5\xe3\xc1\x35\x0e\x43\xb4\x8a\x05\xbe\xa3\xc0\x73\xbc\xd5\xd5\x51\x2c\x13\x62\x14\x58\x4f\xd0\x47\xd1\x13\xb8\x06\xfe\x66\xe1\x3a\x56\x8b\x3a\x6b\xa1\x08\xb9\x84\x8a\x25\x7c\x4e\xd0\x1a\x8f\xc0\xd2\x09\xc2\x08\xa2\xd5\xea\x04\x79\xaf\xd8\x8d\xbd\x6e\x81\xed\xdf\xa7\x57\x60\xfe\xea\xe3\x11\xf8\xf0\x38\xb9\x99\xdf\x3d\x4c\xae\xc0\xcc\x7a\xc2\x6b\x34\x4a\x75\x5f\x81\x87\xef\x1e\x0e\x46\x80\x2a\x3d\x3a\xba\x99\x9a\xe3\xb9\x99\x4b\xeb\xf5\x83\xa9\x39\x7f\x9c\x4e\x66\xcc\xb3\x23\x00\x00\xb8\x1f\x4f\x3e\x3e\x8e\x3f\x9a\x20\xfc\xcb\x05\x77\x9f\x3f\x3f\xce\xc7\x3f\xdf\x9b\x60\x36\x9f\xde\xdd\xcc\x63\x89\xf1\x0c\xfc\x04\x7f\x02\x33\xf3\xde\xbc\x99\x83\x9f\x0c\xfa\xe9\xea\x88\x77\xcf\x45\x07\xf5\xce\x45\x6f\xe4\x5c\x47\xe6\x5c\xcc\xed\x89\xc4\x9b\xf1\xc7\x8f\x53\xf3\xe3\x78\x6e\x56\x73\x27\x17\x2f\x6a\x04\x27\x31\xd5\x33\xea\x31\xb8\xde\xf6\x66\x2b\x79\x3c\xff\xfa\xc5\x04\xd7\xac\x77\xa7\xb2\x1e\x68\x14\xa3\x8b\x4a\x21\xba\xa8\x0a\x42\x3a\x52\x6c\xbc\x44\x1b\x37\x82\x11\x5a\xb8\x38\xf4\x91\x85\xe9\xb8\x3d\xbe\xe2\x5b\xbf\x3b\xd1\x13\x24\x8e\xcd\x0c\x45\xce\x3f\x14\x86\x38\x82\x34\x63\x84\x99\x6b\x71\xa4\x56\x73\x2b\x16\x65\x75\xa4\xde\x38\x36\x58\x38\x2b\xc7\x8b\xc0\xe4\x61\x0e\x26\x8f\xf7\xf7\x89\x3f\x68\x4d\x36\x5e\x24\x6f\xf3\x36\x6b\x88\x2c\x8b\x0a\x84\xc0\xf1\x22\xbc\xc2\x81\x20\xb2\x74\xd1\x2a\x04\xe1\x1a\xb9\x6e\xf1\xfd\x88\xac\x5d\x60\x3d\xa1\x00\x59\x11\x0e\xc0\x33\x0a\x5e\x1d\x6f\x75\xd2\xef\x9d\xe6\x82\xc5\xee\x5d\x91\xc0\x87\x6b\x67\x15\x20\x9a\xb5\x76\xa7\x40\xd0\xb3\xa5\x21\xc2\x2f\x22\x50\xe4\xfb\xae\x83\x6d\x88\x22\x40\x33\x71\x18\xa1\xb5\x0f\x68\x3f\xc5\x1f\xc1\xdf\xc4\xc3\x45\xa0\x4f\x4e\x18\x91\xe0\x35\x67\x08\x3a\x36\x0c\xf1\x5f\x19\xe0\x99\xf9\xeb\xa3\x39\xb9\xa9\x88\x39\x93\x56\x69\x4d\x63\x6f\x3c\x9d\x83\xdf\xee\xe6\x9f\x80\x11\x3f\xb8\x9b\xdc\x4c\xcd\xcf\xe6\x64\x0e\x7e\xfe\x9a\x3e\x9a\x3c\x80\xcf\x77\x93\x7f\x8f\xef\x1f\xcd\xfc\xf3\xf8\xf7\xed\xe7\x9b\xf1\xcd\x27\x13\x18\x1a\x67\xa0\x15\xe0\x3d\xf9\x57\x6a\x4c\x7b\x22\x6b\x27\x3e\x4e\xba\x08\xaa\x42\x54\xd4\xa4\x92\x8b\x2d\x60\x1b\x2e\x5e\x95\x22\x6b\x1c\xac\xb0\x0d\x1d\x2f\x22\x5b\x19\xae\x69\x21\x45\xa4\xef\xfd\xa6\x78\x62\x02\x35\xf5\xe0\xd6\xfc\x30\x7e\xbc\x9f\x03\x0f\xbf\x44\xcf\xc8\x3d\x39\x56\x84\xc8\xf1\x68\x14\xe0\x95\xe5\xa2\x30\x3c\x15\xe3\xdb\xb6\x03\x1c\x86\xf2\xb1\x58\xe2\x1b\xcd\x22\x0d\x78\x16\xab\xd9\xfa\x25\xcf\x24\x49\xca\x8a\x5e\x7d\xac\x49\x19\xac\xb8\x45\x6c\x99\xb8\xd1\x91\x8b\x3b\x61\xb8\xc1\x81\xe4\x85\x8b\xfe\xf6\x05\x1d\x1f\x29\xdd\x4d\x8d\x73\x56\xe7\x9b\x8d\xf2\x32\x47\xc0\xc3\x6f\x13\xf3\x16\xfc\xfc\x55\xe3\xd1\xf8\x7e\x6e\x4e\x35\x0e\xe5\xba\x84\xe6\x73\xc7\x56\x61\x5b\x20\x17\x79\x16\x86\xa1\x87\xfc\xf0\x89\x34\x10\x7f\x05\x8d\x69\x28\x0a\xe3\x68\x3b\xda\x85\xd8\xc9\xe5\x28\x74\xa5\x94\x8b\xed\x15\x0e\x28\xa1\x1b\xec\x59\x58\x11\xe5\x29\x16\xb9\x8a\x9b\x87\xc9\x6c\x3e\x1d\xdf\x4d\xe6\x6a\xec\xf9\x93\xb8\xba\x07\x37\x9f\xcc\x9b\x5f\xc0\xc9\x49\xfa\x14\xbc\xbf\x06\xed\xd3\x92\x21\x8d\x97\x4b\x6c\x35\xc0\x69\xaa\x67\x47\x26\xf5\x39\xff\x1d\x09\x6c\x1c\xbc\x53\xb0\x18\x67\x09\x79\x93\x8d\x23\xe4\xb8\x21\xf8\x4f\x48\xbc\x85\x9a\x87\xa4\xb7\xf6\xe7\x21\xd5\x93\xf2\xa0\xe9\xfc\x34\x44\x9e\x50\xf8\x54\x29\xc7\xf9\x01\x7e\x76\xc8\x26\x4c\xad\x94\xbc\x98\xd2\x12\x20\x2f\x44\xc9\x6a\x2f\x0e\xe9\x1c\x47\x36\x87\xb4\x05\x0b\xdb\x8e\xa8\x26\x6f\xb9\x24\x94\xd5\x49\x74\x45\x9b\x97\x4a\x8a\x69\xb9\xfc\xa5\x44\xff\xc6\xb7\x2b\xcb\xe6\xa1\x93\x7e\x5c\xfb\x24\x88\x70\x00\xb3\xe5\xb7\xe8\x8b\x21\xe0\x8a\x48\x84\x5c\x68\x11\xc7\x0b\xe5\x31\xb8\xc4\x18\xfa\x84\xb8\xf2\x56\xba\x5d\x00\x97\x58\xd5\xd7\x71\x73\x80\x43\x1c\x3c\xab\x44\xd6\xe8\x05\x46\x2f\x90\x26\x95\xd0\xf9\x5b\x25\xe5\x07\x24\x22\x16\x71\x95\x7e\x6d\xfb\x48\x1d\xee\x64\xb9\xc4\x01\xc4\xcf\xb8\x89\x4a\x85\x55\x06\x4e\x1a\x1d\xd8\x89\x6a\xd5\xbb\xf1\xb0\x57\xac\x35\xd2\x21\xb2\x4b\x80\x86\xd8\x75\x71\xa0\x4d\x5e\x54\x8c\x6e\xb4\xa4\xa5\x84\x42\x6a\xb1\x79\xd5\x0b\x95\x2d\xba\xfc\xc0\xb1\xf0\xb6\x97\x25\x8d\xaa\x0a\x2a\x6e\x04\x36\xd9\x2c\x5c\x0c\xfc\x00\x5b\x4e\x1c\x2f\xda\x39\x86\xed\x4f\x98\x40\x13\x66\x97\x14\xaf\x6e\x72\xd9\xf6\xbe\x8f\x82\xc8\xb1\x1c\x1f\x35\x12\x6f\x52\xb5\xba\x7a\xb2\xf8\xb6\xaa\x37\xf4\xb3\x57\x5d\x97\x15\x95\x55\x35\xe7\x0b\x15\x55\xa9\x8d\xb7\x2a\x19\x6b\x39\xba\x67\x09\x59\x6a\xab\x58\x52\xca\xc5\x4b\x4a\xcc\xfc\x85\x06\x63\xb3\xb8\x6e\xe3\x83\x8c\x9d\x9b\x55\x32\xf1\x36\x84\x15\xab\x83\x71\x9a\xdc\xb3\xfc\x49\xf3\x16\xd9\x04\x16\xce\xa2\x5b\x51\x78\x64\x93\xc9\xf1\xf1\x68\x54\x90\xa8\x32\x0e\x28\x5c\xb8\x20\xe4\xdb\xb6\x54\xdd\x9f\x5c\x89\x52\x70\xc2\xa6\x7b\x4d\xc1\xd5\x60\xca\x76\xf1\x33\xce\x78\xad\xc0\x47\x14\x20\x1b\xd3\x5d\xc3\x00\xaf\x1a\x0a\xb5\xa2\xca\x94\x8b\x00\x87\xc4\xdd\x50\x23\x0a\xff\x68\x29\x52\xee\x5d\x9c\xf8\x70\xa0\x91\x7a\x97\xcf\xa9\xef\x4a\xd4\xc8\x9b\xe2\x7a\xe8\x99\xb8\x9b\x35\x2e\x79\x17\x07\xa5\x32\xf1\x0c\x07\xc3\xcd\x5a\x37\xcb\x39\x1e\xad\x96\x30\xac\xfc\xc2\x93\xb3\x7a\xd2\xc9\xb8\xe4\xbb\x4e\x84\xf8\xd8\xd3\xc9\xc4\x25\x8a\x5a\x48\x13\x53\x0d\xc5\x51\x16\x3b\x85\x3c\xb6\x63\xe1\xb6\x47\xf9\x55\x5e\xf3\xc5\x71\xa3\x9e\x99\x2b\x47\x78\x22\x52\x52\x77\xe5\x43\x40\x63\xab\xda\x50\xc9\xa5\x4a\x2c\xc6\x90\x9c\x10\xd2\x2c\x85\x03\xb0\x20\xc4\xc5\xc8\x53\x96\x69\x49\xbf\x41\xc6\x11\xa1\x4a\x63\x5d\x7c\x4f\x2b\x35\x9d\x2a\xd9\xeb\x99\x57\xff\x2a\x38\x5a\x41\x1f\xe7\xb4\xa0\x5e\x60\xe4\x7d\x79\x29\xc9\x4e\x97\xec\xac\xde\x44\xf4\x4b\x15\x57\x2d\x27\xd9\xf7\x55\x9d\x9f\xc9\xaa\xc3\xb6\xbe\xe3\x8a\x4a\xab\x1a\x05\x85\x0a\x4b\x63\xe5\xad\x8a\xca\x9a\xce\xee\x59\x56\x6a\xac\x15\x0b\x4b\xd5\x0b\x25\xa5\x25\xf3\x4a\xa3\xb1\x9a\xe5\x6b\xe6\x51\xc9\x76\x90\x3c\x39\x6b\x8a\xa5\xaa\xd5\x67\x79\x21\x29\x95\xdd\x9a\x96\x8e\x97\x78\xa3\x05\x29\x87\x9e\x6a\x93\xea\x1f\xd9\x66\x8a\x5e\x20\xf6\x9e\xb1\x4b\x7c\x2c\x3b\x49\x8c\x5e\x60\x80\xc3\x8d\x1b\x29\x1a\xd7\x38\x42\x8a\x26\xca\x82\xaa\x39\x74\x56\x1e\x8a\x36\x01\x96\x9d\xe1\x5c\xf6\x4f\xff\xf8\x33\xdf\x0e\x3a\xfe\xef\xff\x64\x35\xfc\x1f\x7f\x0a\x2a\xd7\x78\x4d\x14\xc7\x2d\x5b\x5d\x1e\xf1\x70\xe9\x8a\x60\xab\xab\xa8\x26\xf5\xcc\x59\x63\xb8\x20\x1b\xcf\x8e\xcf\x90\x87\x01\xf2\x56\x65\xa7\xa9\x74\xb6\x09\x81\x63\x67\xa3\x27\xc5\x52\x69\xc8\x27\xc3\xe7\x61\x72\xff\x55\xd4\x97\xa4\x84\x9b\x87\xfb\xc7\xcf\x13\x9a\xe4\xe9\x71\xbd\xfa\x60\x8d\x3d\xc2\x60\x8f\xd5\x54\xa0\xb7\x11\xca\xa6\x89\xe6\x9c\x50\xe8\xaf\xe5\x94\x5c\x47\x0d\x27\xd9\xd4\x73\x18\x37\x95\x16\x6a\x39\xaa\xd2\x52\xea\xea\x2d\x8a\x10\x58\x92\x40\x73\x07\x03\xdc\x8e\xe7\x63\x8d\x7b\x0a\x95\xc2\x7d\x84\xda\x6a\xef\x26\x33\x73\x3a\x07\x77\x93\xf9\x43\xe1\x6e\x43\x7c\xbc\x3f\x03\x27\xc7\x06\x74\x3c\x27\x72\x90\x0b\xc3\x78\x82\x3c\x0f\xff\x72\x8f\x5b\xe0\xb8\xd3\x36\x06\x67\x86\x71\xd6\xb9\x04\xc6\x70\xd4\xe9\x8c\x8c\xc1\x79\xbb\xd7\xee\x75\xba\x67\xed\xe1\xf1\xe9\x55\x35\xed\x1d\xe8\x78\x36\x7e\xe1\x59\x5d\xbc\xc2\x88\x38\x76\xb9\xa5\xee\x60\xd8\xa9\x63\xa9\x0b\x37\x21\xce\x67\x0d\xe8\x78\x30\xeb\xdd\x74\x46\x09\xcb\xed\x5d\x5c\x1a\xc3\x3a\xf6\x7a\x10\xd9\x36\x14\xb7\xda\x4b\x6d\x5c\x74\x8c\x5a\xe4\x5d\x24\x77\x2a\x70\x56\x2c\xc7\x97\x84\xca\x2d\x0c\xba\xf5\xbc\xe8\x67\x26\xd2\x04\xa6\x37\xd1\x6f\x5f\xf6\x6b\x75\xcc\x00\xae\x89\xed\x2c\x5f\xab\x7b\xd1\xbf\xec\x5c\xd6\xb1\x30\x8c\xbb\x22\xdb\xe6\x20\x41\x79\x4f\x0f\x8c\x61\x7f\x50\x4f\x3d\xcb\x51\x32\xc4\x2b\x78\x31\xe8\x0d\xeb\x45\xf0\x65\x66\x87\xdb\x5d\x97\x18\xea\x9c\x75\xda\xc0\x68\x8f\x8c\xde\xe8\xa2\x73\x6e\x18\xdd\xce\xd0\xa8\x63\xc8\x68\xa7\xa3\x32\x4f\xf0\x21\x44\x9e\x9d\x9d\xd0\xd2\xdb\x30\x74\x96\x67\x8c\x0e\xcf\xda\xc6\x59\xfb\x12\x18\xc6\xa8\xdd\x19\x75\x07\xe7\x3d\x63\x38\xe8\xd6\x0a\x66\xc3\x48\x8d\x32\xc9\x36\x4e\x05\xb4\x12\x10\x4d\x19\x06\x30\xfa\xa3\xde\x60\xd4\xbe\x38\xbf\x6c\x77\x0c\xa3\x57\xcb\x54\x27\x67\x52\xb2\x1d\x58\xe8\xba\xd8\x39\xa3\x1f\x33\xda\x19\xf5\x8c\xf3\x0b\xa3\xdb\x6d\xd7\x0a\x11\xa3\x9b\x59\x2c\x6e\xb9\x29\xec\x0d\x81\xd1\x1b\x75\x8d\x51\xfb\xf2\xbc\xd3\xb9\x18\xf6\xeb\xf5\x60\x6f\x4b\xa6\x68\x8e\xf6\x9e\xb3\x2e\x18\xec\x74\x40\xfb\x72\x74\x31\x18\x19\xfd\xf3\x6e\xbb\x77\x71\x51\x2b\x36\x8d\x3c\x17\x15\x2e\x67\xc9\xfd\xeb\xf4\x80\x71\x31\x6a\x77\x47\x9d\xc1\xf9\xc0\xb8\x6c\xf7\x6a\xa5\x25\x23\xcf\x4b\xd9\xdd\x05\x4d\xf7\x75\xfa\x34\x36\x7b\x9d\x51\x7b\x78\x6e\x74\x06\xdd\x7e\x3f\x35\xa7\x98\x5a\x95\x57\xcd\x9a\x9a\xbb\xc5\xc9\x67\x67\xbd\x72\x75\x69\x65\x92\x69\xcd\x17\xae\x33\x53\x57\x4a\xa5\xb7\x79\xb7\x97\xb1\xcf\x43\xcc\x57\x43\x82\x8d\xe3\x16\x30\x5a\xc9\xb5\xd3\x0a\x7c\x16\xef\x7f\xed\xe1\x2c\x5b\x54\x1f\xc6\x55\xae\x6c\xaf\xe3\x68\x21\x2e\x77\xf6\x59\x61\x20\x4d\xc6\x4d\xab\x95\xdd\x62\x69\x40\x2d\x3b\x65\x35\xae\x5b\xba\x0c\xd9\xd9\x4a\x15\xe5\x87\x8c\xb9\x52\x8b\xb5\x06\x5b\xae\xa9\x79\xca\x25\xf3\x66\xd3\x36\x8a\x53\xd7\x41\x2c\x1c\x42\xab\x74\xb1\xb8\xb3\x9d\x6a\xea\x0f\x19\x92\x1a\x9b\xb5\x82\x92\xd1\xb5\x3b\xf5\x85\x25\x35\xfb\x37\xf4\xbf\xe1\xd7\x4c\xf5\xf6\x68\xa1\xee\x16\x02\xa3\x31\xde\x75\x1a\xdf\xde\xb2\x07\x15\xa2\x41\xf0\x65\x7a\xf7\x79\x3c\xfd\x0a\x7e\x31\xbf\x82\x13\xc7\xd6\x7d\x0b\x41\xfc\xdc\x10\x6a\x41\xab\x0c\xb9\xcc\xb0\x16\xbd\xb0\xf9\xc5\x7f\x4c\x57\x3e\xf4\xea\x74\xfa\x27\x5d\x1f\xa4\x7f\x26\x37\xa4\x61\x23\xde\xf1\x66\x65\xce\xed\x04\x0c\x3c\x4e\xee\x7e\x7d\x34\xc1\xc9\x56\xbc\x95\x76\x30\x95\xcf\xfe\x4e\x3c\xa9\x49\x4d\x33\xdd\x5a\xdb\xf1\x5a\x9d\x2a\x9f\x72\x34\xcd\x0d\x05\x6c\xb9\x91\x32\x4f\x4b\x60\x55\xf6\x5c\x95\xd9\xb4\x02\x0d\x7b\xaf\x32\x53\xe6\x7f\x29\x34\x2d\x03\x71\x9c\xd0\xf5\x35\x8d\xf6\xcc\x91\xbb\xc9\xad\xf9\x7b\xb5\x73\xa5\x58\x94\xd7\x02\x1e\x26\xe2\x60\x78\x9c\xdd\x4d\x3e\x82\x45\x14\x60\xcc\x8e\x2e\x35\x9a\x64\x8c\xed\x8f\x27\xfd\x5e\x46\x25\x44\x8a\x71\xbd\xc8\x97\x3a\x3b\xc3\xd9\xaa\x60\xb9\x61\x3a\x4e\xc4\x93\x08\xb7\x0a\xa7\x5c\x32\x70\xf4\xb0\x6e\x1f\x64\xf4\xfd\x6a\xb0\x98\x96\xf8\x88\x50\x86\x26\x59\x38\xec\x83\x27\xd1\x50\x0d\x91\x70\xfe\xd8\x2a\x5e\x74\x93\x61\xa4\x5b\x49\xfb\x20\xa4\xef\x57\xc3\x97\x1f\x85\xb5\x00\xfd\xb3\x05\x64\x39\x08\x59\xb0\x81\x18\xe3\xd5\xb0\xf0\x0a\x9b\x17\x3c\x46\x51\xcc\xb1\x5b\x92\xdc\xaa\x06\x1e\xab\x25\xc1\xbe\xc0\x53\x35\x35\x80\x67\xe7\xb2\x8b\xd7\xda\x98\x73\x99\x1d\x50\xa7\x45\x02\x07\x3e\xd7\xb7\x03\xef\xe5\x78\x17\xe1\x7e\xc1\xc1\xa3\xe5\xb4\xb1\x58\x0b\xfb\x14\x72\xac\xb2\x18\x49\x93\xa7\xdd\x12\x6f\x03\xa8\x9c\xd9\x33\x45\x70\x5a\x6a\xf8\xa0\x45\xe7\x84\x11\xc4\x14\x60\x7c\x47\x61\x6f\xae\x79\x75\x2c\xd0\x74\xcf\x46\x1f\x0e\xad\xec\x6e\x5c\x09\x58\x5a\x99\xee\xce\x25\xa7\x46\x8b\x91\xda\x6a\x81\x9d\x90\x3a\xf6\x0e\x20\x65\x84\x3a\x76\x65\x2a\x65\xd1\x5a\x03\x34\xf1\x9b\xe1\x97\xf8\x32\x82\x73\x20\x52\x8e\x1d\x5b\x89\xc9\x6f\x8a\xcb\x54\x97\x14\x14\x57\x49\xee\xc6\xae\xdc\x81\xe8\xa5\x39\x07\xa2\x97\x82\x03\xaa\x62\xb8\xba\x0b\xac\x06\x99\x13\x24\x49\x3f\x3e\x72\x82\xbd\x9d\x60\x74\x71\xbd\x20\xd9\x56\xe3\x1d\x10\xaf\x9f\xb7\xc4\xab\xe6\x15\x92\x31\x89\xc7\x7d\xbc\x23\xbb\x83\x23\x99\x07\x5b\x25\x9c\x07\xcc\x3e\x2f\x8f\x3c\xbb\x93\x5b\x7b\x44\x26\x70\xa9\xe3\xfb\xe3\x4d\xb4\x54\x03\x5c\xf8\x0e\x97\x14\x9a\xdf\x40\x48\x27\x6a\xaa\xa1\xaa\x4b\x5e\x9c\x82\x9e\x88\x63\xef\x41\x5d\xae\x83\x83\x58\x23\x63\xb0\x60\x8b\x18\xf3\x2f\xa3\xc6\xf5\xdc\xde\x6c\xf2\xea\x58\xc8\xd9\x37\x6b\x39\x8c\x72\x44\x6c\x32\x68\x0a\x56\x41\x27\x8b\x8d\x69\xac\x00\x30\x4a\xba\x24\xda\x09\x57\x0a\x68\xab\x63\xf7\x3c\xca\x4a\x4b\x71\x06\x36\x3d\x7c\x6e\x2c\x6f\x0a\xfa\x04\xe0\xc2\x71\x01\x0f\x99\xbb\x8b\xde\x2a\x5c\x45\x6f\x31\xdf\x79\x69\xb1\x5f\x4b\x29\x75\x8a\x8a\xed\xd1\x03\xbc\xa2\x3a\xde\xd4\x02\xbb\x7f\xb6\x67\xb5\x14\x60\x2a\x12\xbd\x1c\x4b\xc6\xbb\x4b\xc8\xb7\x8d\xbf\x1f\x22\x5e\x97\x0e\x97\xd8\xe3\x72\x7c\x34\xac\xe2\xfb\x11\x8d\x20\x14\xb5\xe9\x30\x6a\x83\x54\xfc\x52\x8c\xc2\x89\x06\xf2\x55\xaa\x47\x87\x58\x96\xe2\x4b\xe6\x23\xaa\xb5\x31\x76\x6b\x10\xab\xe5\x2d\xb9\x2e\x23\xd4\x87\x21\x24\x1e\xbd\x3b\x46\x7f\xe9\x66\x5f\x42\xb5\x06\x58\x17\xb2\x66\xde\x89\x54\xb0\x06\x76\xc7\x3e\x1c\x6c\x3e\x36\xe4\x88\x1d\x5b\x03\x36\x5d\xc6\x51\x7d\x7b\xad\xb7\x4a\xb5\xb2\x38\xd3\x26\x1e\x26\x35\xad\x01\x9a\xd6\x0e\x14\x68\x1e\x44\x0d\xa1\x95\xa9\x66\x21\xa7\xed\x3c\xe4\x5c\xb2\x3a\xee\xa6\x83\x81\x53\xad\x05\xac\x0d\x05\x56\x9d\xf0\xc3\x1b\xcd\x13\x2d\x5a\xd0\xc3\x17\x5e\xa8\xee\x4c\x9a\x7a\x76\xdc\xad\xaf\xc6\x3f\x63\x43\xeb\x09\x23\x5b\xdd\x09\xd9\xef\xc6\x1c\xcc\x1b\xe9\x8f\xd4\xe8\xdc\x92\xbd\x54\xdd\xbf\x6c\xb5\x7c\x30\x9f\x32\x03\xda\xee\xc9\x04\x35\xd8\xf3\xf9\xf6\x20\x43\x5b\xd4\xce\xa2\xde\xb6\xd5\x1c\xe0\xbc\x52\x7e\xe9\xb0\x03\x7c\x3d\x6e\xde\x44\x15\x1f\xf8\x37\xea\xf9\xd3\xdc\xf4\x55\x54\x5c\x09\xbb\x7e\x12\x63\xdc\x3b\x48\xd8\x14\xf5\xb3\xc0\xd9\x56\x6d\xe8\xc4\xb5\x66\x3e\x91\x67\x9b\xe9\xf1\xde\xd8\xce\x2c\x97\xe8\x64\x71\xa6\x02\x3c\xc4\x93\x93\xec\x77\x30\xce\xde\xbf\x07\xc7\x21\x71\xed\xb4\x2c\xa7\xfd\x73\x3c\x1a\xd1\x6f\xe0\x9d\x9e\xb6\x80\x5a\xd0\x22\x76\x35\xc1\xe4\x3c\x5a\x2d\xba\x20\x9b\xd5\x53\x54\xc9\x3c\x27\x5a\x0e\x80\x13\x15\x20\x9c\x82\xdf\x3e\x99\x53\x33\x09\x32\x70\x0d\xba\xdd\x42\x87\x31\xf7\xa1\xd2\x53\xf8\xf8\x6f\x7a\x45\x6c\xc9\x5c\x95\xf8\xf0\xcb\x1e\xb7\x25\x18\xbd\xb2\x8b\x11\x12\xb3\xe0\xc3\xc3\xd4\xbc\xfb\x38\xc9\xaf\x41\x80\xa9\xf9\xc1\x9c\xd2\x0b\xc2\xb3\xbc\xc3\xe3\xf7\x42\xba\xd1\x42\xc3\xe0\xf1\xcb\x2d\x0d\xf3\xa9\x99\xfc\xd2\x31\x7d\x74\x6b\xde\x9b\x73\x13\xdc\x8c\x67\x37\xe3\x5b\x53\xf4\x5c\xdc\x54\xde\x1e\xfb\x29\x5b\x20\x77\x84\xd9\x1c\x41\x4a\x83\x65\xf7\x48\xaa\xa0\xe3\x79\xe4\xda\xe5\x94\xa6\xcb\x01\xd9\xd0\x56\xda\x55\xb7\x40\xb1\xe5\x07\x24\x4d\x01\x91\x67\xae\x28\xf4\x26\xf4\xf1\x3f\x52\xfb\xc3\x51\x27\x81\xc7\xd3\xc6\x0b\xec\x4c\x59\xf1\xa0\x58\xd9\xf2\x26\x11\x57\xb0\x5a\x46\x5b\x65\x88\x07\x8b\xb8\x02\x82\x2a\xd8\xd2\xad\xab\x1f\x97\x3c\x16\xa0\x82\xba\x54\x44\x33\x79\xa8\x68\xe3\x0e\x73\x64\x0f\xa1\x70\x86\xd7\x3c\x57\xac\xb5\x32\x9a\xb4\xa8\x78\x82\x04\x89\x83\xf1\x53\x38\x8a\xfb\x41\x18\x92\xe3\xe2\x39\x2a\xc8\xec\x3c\xfc\x58\xd3\xd2\x87\x50\x3c\x1d\xfe\x81\x68\xd2\x44\x92\x28\xb2\x6b\x28\xe5\x05\xfd\x76\xa4\x97\x36\xbe\xc1\xd0\x93\x58\x2d\x65\xae\x2a\x4a\x9e\x40\x41\xe2\xcd\xf8\x7b\x83\x90\x6b\x82\xc0\xb7\x0b\x41\xc9\xc9\x9d\xba\x29\xfd\xe9\xad\x83\x71\x57\xb4\x58\xc6\x5c\x25\x7c\x42\xd8\xb1\xed\x6f\xc2\x98\x78\x26\xf6\x03\x92\x26\x85\xc8\xf3\x26\x8a\xec\x43\x9d\x40\x57\xd6\x69\xfc\xa4\xd4\x3c\x43\x7a\x56\xe4\x48\x64\x01\x94\x4b\xec\x3c\x39\x96\x31\x71\xd8\x48\xa9\xce\x83\x3a\x1c\xb8\xf6\x46\x63\x21\x0f\xb4\x1f\x21\x1c\x14\x60\x78\x2e\x8a\x42\x0d\x07\x45\x6e\xe0\x9f\x8f\x0b\x29\x14\x05\x1d\x75\xa3\x43\xf5\xbf\xe8\x80\x45\xd6\xbe\x8b\x23\x7c\x74\x76\x76\x74\xf4\xff\x01\x00\xe5\x1a\x3f\x10\xb8\x6e\x00\x00")
 
 func latestSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -113,7 +121,147 @@ func latestSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "latest.sql", size: 18688, mode: os.FileMode(420), modTime: time.Unix(1512008537, 0)}
+	info := bindataFileInfo{name: "latest.sql", size: 28344, mode: os.FileMode(420), modTime: time.Unix(1792204292, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations10_index_operations_and_effects_by_typeSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x8e\x41\x0b\x82\x40\x10\x85\xef\xf3\x2b\x06\x4f\x49\xfa\x0b\xf6\x14\x29\xe1\x45\xc3\x12\xba\x0d\xda\x8e\xb5\x87\xdc\x65\x1c\x88\xfd\xf7\x51\xa7\xc0\xbd\xbe\xf7\xf8\xde\x57\x96\xb8\x7f\xb9\x87\x8c\xca\x38\x04\x80\x63\x5f\x1f\xae\x35\x36\x6d\x55\xdf\xf0\xe9\x56\x25\x1f\x68\x8a\xa4\x31\x30\x76\xed\x2f\xf2\x12\xc9\x07\x96\x51\x9d\x5f\x56\x1c\x2e\x4d\x7b\xc2\x49\x85\x19\x77\xdf\x5d\x81\xce\xe6\x26\x81\xe2\x14\x89\xe7\x99\xef\x9a\xc4\x6c\xce\xc8\xd9\x02\x33\x2f\x96\x25\xcb\x0d\xc0\xbf\x7d\xe5\xdf\x0b\x40\xd5\x77\xe7\xb4\xbd\xd9\x74\x4c\x53\x24\x8d\x81\x0d\x7c\x06\x00\x5f\xc3\x1c\x2a\x07\x01\x00\x00")
+
+func migrations10_index_operations_and_effects_by_typeSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations10_index_operations_and_effects_by_typeSql,
+		"migrations/10_index_operations_and_effects_by_type.sql",
+	)
+}
+
+func migrations10_index_operations_and_effects_by_typeSql() (*asset, error) {
+	bytes, err := migrations10_index_operations_and_effects_by_typeSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/10_index_operations_and_effects_by_type.sql", size: 263, mode: os.FileMode(420), modTime: time.Unix(1792198717, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations11_index_transactions_by_memoSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd2\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\x48\xaa\x8c\xcf\x4d\xcd\xcd\x57\xf0\xf7\x53\xc8\xc8\x2c\x2e\xc9\x2f\xaa\x8c\x2f\x29\x4a\xcc\x2b\x4e\x4c\x2e\xc9\xcc\xcf\x2b\x56\x08\x0d\xf6\xf4\x73\x57\x48\x2a\x29\x4a\x4d\x55\xd0\x00\xa9\x8c\x2f\xa9\x2c\x48\xd5\x51\x00\x31\x75\x14\x32\x53\x34\xad\xb9\xb8\x90\x2d\x70\xc9\x2f\xcf\xe3\xe2\x72\x09\xf2\x0f\x40\xb5\xc0\x9a\x0b\x30\x00\xfd\xd6\x9f\x9d\x87\x00\x00\x00")
+
+func migrations11_index_transactions_by_memoSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations11_index_transactions_by_memoSql,
+		"migrations/11_index_transactions_by_memo.sql",
+	)
+}
+
+func migrations11_index_transactions_by_memoSql() (*asset, error) {
+	bytes, err := migrations11_index_transactions_by_memoSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/11_index_transactions_by_memo.sql", size: 135, mode: os.FileMode(420), modTime: time.Unix(1792198808, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations12_create_order_book_snapshots_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x91\xcb\x4e\xac\x40\x10\x86\xf7\xfd\x14\xb5\x64\x72\x98\x27\x98\xd5\x30\xd4\x21\x6d\x48\xa3\x5c\x12\x77\x9d\x06\x2a\xd0\x11\x69\xec\xea\xd1\xf0\xf6\xc6\x31\x12\xc3\xc2\x98\xb8\xff\xea\xaf\xff\x72\x3c\xc2\xbf\x67\x3b\x78\x13\x08\x9a\x45\x5c\x4a\x3c\xd7\x08\xf5\x39\xc9\x11\x46\xcb\xc1\xf9\x55\x3b\xdf\x93\xd7\xad\x73\x4f\x9a\x67\xb3\xf0\xe8\x02\x43\x24\x00\x00\x26\xea\x07\xf2\x9a\xe9\xe5\x4a\x73\x47\x20\x55\x8d\x19\x96\xa0\x8a\x1a\x54\x93\xe7\xf1\x8d\x62\x9a\x26\x3b\x0f\xda\x30\x53\xd0\xb6\x87\x44\x66\x52\xd5\x1b\x05\x25\xfe\xc7\x12\xd5\x05\xab\xed\xe9\x8d\xe5\xc8\xf6\x87\x4f\x8d\xf6\xba\xfe\x55\x62\xa2\x57\x9a\x18\xee\xaa\x42\x25\xdb\xa1\x38\x9c\xc4\x57\xec\x46\xc9\x87\x06\x41\xaa\x14\x1f\x61\x74\x2d\xeb\x76\xd5\x8b\xb1\x1e\x0a\xf5\x73\x1b\x4d\x25\x55\x06\x6d\xf0\x44\x10\xed\xe3\xc6\x7b\xf3\xf1\xbe\xb7\x0f\x0f\xdf\x97\x48\xdd\xdb\x2c\xd2\xb2\xb8\xff\xcd\x12\x9d\xe1\xce\xf4\x74\x12\xef\x03\x00\x11\x33\xf8\xfa\xcc\x01\x00\x00")
+
+func migrations12_create_order_book_snapshots_tableSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations12_create_order_book_snapshots_tableSql,
+		"migrations/12_create_order_book_snapshots_table.sql",
+	)
+}
+
+func migrations12_create_order_book_snapshots_tableSql() (*asset, error) {
+	bytes, err := migrations12_create_order_book_snapshots_tableSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/12_create_order_book_snapshots_table.sql", size: 460, mode: os.FileMode(420), modTime: time.Unix(1792199131, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations13_create_trade_aggregations_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x94\xcd\x6e\xdb\x3a\x10\x85\xf7\x7c\x8a\x41\x56\x54\xae\x82\xab\x34\x41\xd0\x26\x2b\xc7\x66\x02\x15\x2e\xe5\xea\xa7\x68\x57\x02\x2d\x8d\x65\x02\x92\x28\x90\xb4\x93\xbc\x7d\x21\xd5\x91\x65\xd7\xb5\x13\xb4\x3b\x92\xf3\xf1\x70\x38\x73\x30\x17\x17\xf0\x5f\x25\x0b\x2d\x2c\x42\xd2\x90\x71\xc8\x46\x31\x83\x78\x74\x3f\x65\xb0\x94\xc6\x2a\xfd\x92\x5a\x2d\x72\x4c\x45\x51\x68\x2c\x84\x95\xaa\x36\x40\x09\x00\x80\x46\xa3\xca\x55\x7b\x02\xf7\xfe\xa3\xcf\x63\xe0\x41\x0c\x3c\x99\x4e\xdd\x2e\x3e\x17\x06\x53\x61\x0c\xda\x54\xe6\xfb\x08\x84\xec\x81\x85\x8c\x8f\x59\xd4\xbf\xd4\xb1\x86\xca\xdc\xf9\x25\x90\xa9\x55\x6d\x51\xff\x95\x86\x95\x15\x1a\x2b\xaa\x66\xff\xf2\xe0\x89\xc3\xa1\x2e\xfd\xb5\x2a\x57\x15\x1e\xb9\x8b\xfa\x28\xd3\x68\x99\x61\x6a\x56\x15\x4c\x82\xa4\xad\xea\x2c\x64\x63\x3f\xf2\x03\xbe\x07\xca\x7a\x8d\xda\x60\xfa\xe6\x0b\x4b\x59\x2c\x4f\x31\xa5\x7a\x3a\x85\xa8\x06\xeb\x53\x4c\x56\x2a\x83\x7f\x86\x88\x73\x47\x5e\xad\x93\x70\xff\x6b\xc2\xc0\xe7\x13\xf6\x1d\x96\x56\xe7\xad\x71\xd2\xf9\x4b\xda\x08\xa9\x21\xe0\xc7\x5c\x95\x44\x3e\x7f\x84\xb9\xd5\x88\x40\x77\xcc\xe3\xfe\x66\x05\x77\xe0\x3e\x77\xdb\xe4\x36\x13\x9f\x47\x2c\x8c\xc1\xe7\x71\x70\xe4\x35\x12\xb1\x29\x1b\xc7\x7b\x46\x3e\x60\xdc\xdd\x5e\xef\x9e\xe6\x72\x4d\x33\x61\x2c\xa5\xf8\x6c\xb5\xc8\x2c\xc5\x46\x65\x4b\x58\x68\x55\x41\x89\x79\x81\x3a\xed\x8a\x97\xa7\xc2\x3a\x70\x0e\x97\x9e\xe7\x39\x20\x0c\xcc\x65\x21\x6b\xeb\x0c\xbf\xd1\xc6\xb7\xbb\x16\xea\xbf\x35\xc8\x81\x9e\x6f\x8c\x6d\x56\xd5\xa6\x48\x55\x5b\x9b\xc1\x69\x9f\xeb\xf1\xc0\xed\xed\xa2\x54\xc2\xc2\xff\x70\x58\x66\x70\xba\x45\x0f\x6a\x57\xe2\xf9\x1d\xda\x95\xac\xdf\x41\x2f\xa4\x36\xf6\x2d\x3c\x04\xe1\x84\x85\x70\xff\xa3\x6f\xba\x6a\x50\x77\x03\xab\xed\x22\x9c\x29\x9d\xa3\x3e\xdb\xc8\x96\xe2\xdf\xaa\x92\x87\x30\xf8\xd2\x33\xdd\xc8\x34\x64\x1c\x06\x51\x04\x9f\x03\x9f\x03\xfd\x36\x9a\x26\x2c\x02\x7a\xe3\xb5\x16\x70\x81\x7e\xf2\x5e\x57\x57\x37\xfd\xf2\xe3\xcd\x75\xbb\xf4\x1c\x07\x46\xd1\xc0\x0d\x06\xe8\x76\xe3\x90\xc7\x30\x48\x66\xed\x57\x2f\x5d\xf8\xe0\xc2\x95\x0b\xd7\x77\x84\x0c\x87\xf9\x44\x3d\xd5\x64\x12\x06\xb3\xd3\xc3\x3c\x13\x26\x13\x39\xde\x91\x9f\x03\x00\xb5\x7b\xc3\x8a\x0d\x06\x00\x00")
+
+func migrations13_create_trade_aggregations_tableSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations13_create_trade_aggregations_tableSql,
+		"migrations/13_create_trade_aggregations_table.sql",
+	)
+}
+
+func migrations13_create_trade_aggregations_tableSql() (*asset, error) {
+	bytes, err := migrations13_create_trade_aggregations_tableSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/13_create_trade_aggregations_table.sql", size: 1549, mode: os.FileMode(420), modTime: time.Unix(1792199412, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations14_index_trade_aggregations_by_timeSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\xcd\xb1\xca\xc2\x30\x10\xc0\xf1\xfd\x9e\xe2\xc6\xef\x43\xfb\x04\x9d\xc4\x04\xe9\x92\x4a\xb5\xe0\x16\xae\xf4\x48\x03\xa6\x29\x97\x13\xe9\xdb\x4b\x36\x17\xe7\x3f\x7f\x7e\x4d\x83\x87\x14\x83\x90\x32\x8e\x1b\xc0\x79\xb0\xa7\xbb\xc5\xce\x19\xfb\xc0\x45\x65\xf6\x14\x82\x9f\x76\xaf\x31\x31\xf6\x0e\x97\x58\x34\xcb\xee\x55\x68\xe6\x1a\x85\x03\x69\xcc\x6b\xc1\xf1\xd6\xb9\x0b\x4e\x2a\xcc\xf8\x27\x5c\xf2\xf3\x55\xc3\x11\xeb\x5b\x94\xd2\xf6\xdf\x02\x7c\x8b\x26\xbf\x57\x00\x33\xf4\xd7\x1f\x62\x0b\x9f\x01\x00\x62\x93\xff\x76\xa1\x00\x00\x00")
+
+func migrations14_index_trade_aggregations_by_timeSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations14_index_trade_aggregations_by_timeSql,
+		"migrations/14_index_trade_aggregations_by_time.sql",
+	)
+}
+
+func migrations14_index_trade_aggregations_by_timeSql() (*asset, error) {
+	bytes, err := migrations14_index_trade_aggregations_by_timeSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/14_index_trade_aggregations_by_time.sql", size: 161, mode: os.FileMode(420), modTime: time.Unix(1792199635, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations15_create_account_creations_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x92\x4d\x6e\x83\x30\x10\x85\xf7\x3e\xc5\x5b\x82\x9a\x9c\x80\x55\x12\xdc\x08\x09\x99\x96\x60\xa9\x3b\xcb\xd8\xa3\xc4\x8b\xe0\xc8\xb8\xaa\x72\xfb\x4a\xb4\x44\xd0\x5f\x91\xf5\xbc\xf9\x3e\x7b\x66\xd6\x6b\x3c\x9c\xdd\x31\xe8\x48\x90\x17\xc6\x76\x35\xdf\x34\x1c\xcd\x66\x5b\x72\x9c\x5c\x1f\x7d\xb8\x2a\x6d\x8c\x7f\xed\xa2\x32\x81\x74\x74\xbe\xeb\x91\x30\x00\xb7\xba\xbf\x50\x18\x0a\xca\x59\x6c\x8b\x7d\x21\x1a\x88\xaa\x81\x90\x65\xb9\x9a\x25\x47\xd2\xf7\x1c\x6a\xfe\xc8\x6b\x2e\x76\xfc\xf0\x35\xdd\x27\xce\xa6\x1f\x9c\xe1\x05\x64\x55\x7b\xbd\x1b\x71\xa6\x70\x24\xab\x5c\x17\xfd\x84\xb1\xa4\xb5\xfd\xf1\xc7\x2c\xcd\x6e\xe3\x93\xa2\x78\x96\x1c\x85\xc8\xf9\x0b\x4e\xda\xcc\x5a\x50\x89\x3f\x26\x2b\x0f\x85\xd8\xa3\x8d\x81\x08\xc9\x18\x9b\xea\xd2\x6c\xb4\xcc\xf0\x9f\xa4\x3b\xe0\x63\xcc\xd9\x15\x16\x08\x07\xaa\x0f\x0b\x84\xb3\xe5\xfd\xea\x62\xd3\x93\xcc\xfd\x5b\xc7\x58\x5e\x57\x4f\xff\x9e\xa4\xd1\xbd\xd1\x96\x32\xf6\x3e\x00\x39\x9a\x6c\xf3\xd3\x02\x00\x00")
+
+func migrations15_create_account_creations_tableSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations15_create_account_creations_tableSql,
+		"migrations/15_create_account_creations_table.sql",
+	)
+}
+
+func migrations15_create_account_creations_tableSql() (*asset, error) {
+	bytes, err := migrations15_create_account_creations_tableSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/15_create_account_creations_table.sql", size: 723, mode: os.FileMode(420), modTime: time.Unix(1792204292, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations16_create_balance_snapshots_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x91\x41\x4f\xbb\x40\x10\xc5\xef\xfb\x29\xde\x11\xf2\x6f\x93\xff\x9d\x68\xd2\xc2\x88\x1b\xc9\xa2\x14\x12\x6f\x64\x59\x26\x85\xa4\x42\x65\xb7\x31\x7e\x7b\xa3\x15\x52\xca\x41\xbd\xce\xbc\xfc\xde\xbc\x37\xeb\x35\xfe\xbd\xb4\xfb\x41\x3b\x46\x71\x14\x61\x46\x9b\x9c\x90\x6f\xb6\x09\xa1\x69\xad\xeb\x87\xf7\xb2\xd2\x07\xdd\x19\x2e\x6d\xa7\x8f\xb6\xe9\x9d\x85\x27\x00\x4c\x7b\x6d\x4c\x7f\xea\x5c\xd9\xd6\xd8\xca\x58\xaa\x1c\x2a\xcd\xa1\x8a\x24\x41\x46\x77\x94\x91\x0a\x69\x77\xad\xb6\x5e\x5b\xfb\xab\x39\xc7\x5a\xfe\x35\xe5\x53\x7b\xc1\x38\x70\xbd\xe7\xa1\xb4\xfc\x7a\xe2\xce\x30\xa4\xca\x29\xa6\x6c\x62\x9c\x9d\xbe\x93\x2c\x0c\xc2\x7b\x0a\x1f\xe0\x8d\xeb\xdb\x1b\xfc\xf7\x85\x1f\x88\xb1\x8f\x42\xc9\xa7\x82\x20\x55\x44\xcf\x68\x2a\x5b\x56\x53\x0e\xa4\x6a\x3a\x6a\x59\x54\xb1\x93\x2a\x46\xe5\x06\x66\x78\xcb\xbe\x56\xf3\x3c\x5f\x93\xab\x24\x7e\x30\x1e\x31\x73\x3f\xab\xfe\x60\xbe\xc4\x8a\xcb\xdf\x47\xfd\x5b\x27\xa2\x2c\x7d\xfc\xf1\xf7\x46\x5b\xa3\x6b\x0e\xc4\xc7\x00\x56\x87\x35\x42\x3b\x02\x00\x00")
+
+func migrations16_create_balance_snapshots_tableSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations16_create_balance_snapshots_tableSql,
+		"migrations/16_create_balance_snapshots_table.sql",
+	)
+}
+
+func migrations16_create_balance_snapshots_tableSql() (*asset, error) {
+	bytes, err := migrations16_create_balance_snapshots_tableSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/16_create_balance_snapshots_table.sql", size: 571, mode: os.FileMode(420), modTime: time.Unix(1792202392, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -298,6 +446,26 @@ func migrations8_create_asset_stats_tableSql() (*asset, error) {
 	return a, nil
 }
 
+var _migrations9_create_offer_events_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x93\x5f\x8b\xe2\x30\x14\xc5\xdf\xfb\x29\x2e\x3e\x55\x56\x61\xdf\x65\x17\x6a\x9b\x75\xc3\xd4\xd4\xe9\x1f\x98\xb7\x10\x9b\xab\x06\x6a\x53\x92\x38\x83\xdf\x7e\xa8\xd3\xaa\xf8\x0f\x99\x79\x3e\x27\xbf\xc3\xbd\x39\x77\x3c\x86\x5f\x5b\xb5\x36\xc2\x21\x14\x8d\x17\xa6\x24\xc8\x09\xe4\xc1\x34\x26\xb0\x51\xd6\x69\xb3\xe7\x7a\xb5\x42\xc3\xf1\x1d\x6b\x67\xc1\xf7\x00\xe0\x24\x35\x68\x84\x53\xba\xe6\x4a\xc2\x94\xce\x28\xcb\x81\x25\x39\xb0\x22\x8e\x47\x07\xe7\x40\x1b\x89\x66\x00\x94\xe5\x64\x46\xd2\x0b\xf5\x0b\x7d\xef\xad\xdb\x37\x08\xd9\x3c\x88\xe3\x6b\xad\x42\xb9\x46\xc3\xcb\x4a\x5b\x94\x5c\x38\xc8\xe9\x9c\x64\x79\x30\x5f\x5c\x18\x2d\x56\x15\x1a\x2e\xca\x52\xef\x6a\x77\x23\x0b\x52\xf2\x8f\xa4\x84\x85\x24\x3b\xce\xd5\xb9\xad\xaf\xe4\xf0\x84\x51\xf5\x9a\x0b\x6b\xf1\x69\x4a\xeb\x3d\x63\x2c\x77\xfb\x9f\x22\xc4\xb6\x9d\xe2\xea\x65\xf8\x9f\x84\x2f\xe0\x77\xea\xdf\x3f\xf0\xbb\xf3\x37\x46\x95\x58\xdf\xd9\xfe\x41\x94\x8f\x44\x88\x92\xa2\xad\xc2\x22\x25\x21\xcd\x68\xc2\x8e\x26\x6f\x38\xf1\xfa\xba\x14\x8c\xbe\x16\x04\x28\x8b\xc8\x1b\x6c\x34\xf2\x46\x49\x48\xd8\xed\x02\x15\x19\x65\x33\x58\x3a\x83\x08\xfe\xad\x1e\x8d\xfa\xce\x0c\x27\x7d\xc0\x89\xbc\xec\x68\xcf\xe1\xfb\x7a\x8d\xe0\x3b\x41\xed\x97\x3f\x9b\x74\xd5\xb2\x76\x3d\xe7\xc7\x15\xe9\x8f\xda\x8b\xd2\x64\xf1\xe8\xb8\x4a\x61\x4b\x21\x71\xe2\x7d\x0e\x00\x81\x92\xe5\x67\x97\x03\x00\x00")
+
+func migrations9_create_offer_events_tableSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations9_create_offer_events_tableSql,
+		"migrations/9_create_offer_events_table.sql",
+	)
+}
+
+func migrations9_create_offer_events_tableSql() (*asset, error) {
+	bytes, err := migrations9_create_offer_events_tableSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/9_create_offer_events_table.sql", size: 919, mode: os.FileMode(420), modTime: time.Unix(1792196748, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -352,6 +520,13 @@ func AssetNames() []string {
 var _bindata = map[string]func() (*asset, error){
 	".DS_Store": Ds_store,
 	"latest.sql": latestSql,
+	"migrations/10_index_operations_and_effects_by_type.sql": migrations10_index_operations_and_effects_by_typeSql,
+	"migrations/11_index_transactions_by_memo.sql": migrations11_index_transactions_by_memoSql,
+	"migrations/12_create_order_book_snapshots_table.sql": migrations12_create_order_book_snapshots_tableSql,
+	"migrations/13_create_trade_aggregations_table.sql": migrations13_create_trade_aggregations_tableSql,
+	"migrations/14_index_trade_aggregations_by_time.sql": migrations14_index_trade_aggregations_by_timeSql,
+	"migrations/15_create_account_creations_table.sql": migrations15_create_account_creations_tableSql,
+	"migrations/16_create_balance_snapshots_table.sql": migrations16_create_balance_snapshots_tableSql,
 	"migrations/1_initial_schema.sql": migrations1_initial_schemaSql,
 	"migrations/2_index_participants_by_toid.sql": migrations2_index_participants_by_toidSql,
 	"migrations/3_use_sequence_in_history_accounts.sql": migrations3_use_sequence_in_history_accountsSql,
@@ -361,6 +536,7 @@ var _bindata = map[string]func() (*asset, error){
 	"migrations/7_modify_trades_table.sql": migrations7_modify_trades_tableSql,
 	"migrations/8_add_aggregators.sql": migrations8_add_aggregatorsSql,
 	"migrations/8_create_asset_stats_table.sql": migrations8_create_asset_stats_tableSql,
+	"migrations/9_create_offer_events_table.sql": migrations9_create_offer_events_tableSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -406,6 +582,13 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	".DS_Store": &bintree{Ds_store, map[string]*bintree{}},
 	"latest.sql": &bintree{latestSql, map[string]*bintree{}},
 	"migrations": &bintree{nil, map[string]*bintree{
+		"10_index_operations_and_effects_by_type.sql": &bintree{migrations10_index_operations_and_effects_by_typeSql, map[string]*bintree{}},
+		"11_index_transactions_by_memo.sql": &bintree{migrations11_index_transactions_by_memoSql, map[string]*bintree{}},
+		"12_create_order_book_snapshots_table.sql": &bintree{migrations12_create_order_book_snapshots_tableSql, map[string]*bintree{}},
+		"13_create_trade_aggregations_table.sql": &bintree{migrations13_create_trade_aggregations_tableSql, map[string]*bintree{}},
+		"14_index_trade_aggregations_by_time.sql": &bintree{migrations14_index_trade_aggregations_by_timeSql, map[string]*bintree{}},
+		"15_create_account_creations_table.sql": &bintree{migrations15_create_account_creations_tableSql, map[string]*bintree{}},
+		"16_create_balance_snapshots_table.sql": &bintree{migrations16_create_balance_snapshots_tableSql, map[string]*bintree{}},
 		"1_initial_schema.sql": &bintree{migrations1_initial_schemaSql, map[string]*bintree{}},
 		"2_index_participants_by_toid.sql": &bintree{migrations2_index_participants_by_toidSql, map[string]*bintree{}},
 		"3_use_sequence_in_history_accounts.sql": &bintree{migrations3_use_sequence_in_history_accountsSql, map[string]*bintree{}},
@@ -415,6 +598,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"7_modify_trades_table.sql": &bintree{migrations7_modify_trades_tableSql, map[string]*bintree{}},
 		"8_add_aggregators.sql": &bintree{migrations8_add_aggregatorsSql, map[string]*bintree{}},
 		"8_create_asset_stats_table.sql": &bintree{migrations8_create_asset_stats_tableSql, map[string]*bintree{}},
+		"9_create_offer_events_table.sql": &bintree{migrations9_create_offer_events_tableSql, map[string]*bintree{}},
 	}},
 }}
 
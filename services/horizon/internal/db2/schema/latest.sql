@@ -91,6 +91,19 @@ CREATE SEQUENCE history_accounts_id_seq
     CACHE 1;
 
 
+--
+-- Name: history_account_creations; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE history_account_creations (
+    history_operation_id bigint NOT NULL,
+    history_account_id bigint NOT NULL,
+    created_by_id bigint NOT NULL,
+    merged_into_id bigint,
+    merged_by_operation_id bigint
+);
+
+
 --
 -- Name: history_accounts; Type: TABLE; Schema: public; Owner: -
 --
@@ -132,6 +145,19 @@ CREATE SEQUENCE history_assets_id_seq
 ALTER SEQUENCE history_assets_id_seq OWNED BY history_assets.id;
 
 
+--
+-- Name: history_balance_snapshots; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE history_balance_snapshots (
+    history_account_id bigint NOT NULL,
+    history_asset_id bigint NOT NULL,
+    ledger_sequence integer NOT NULL,
+    balance bigint NOT NULL,
+    CONSTRAINT history_balance_snapshots_balance_check CHECK ((balance >= 0))
+);
+
+
 --
 -- Name: history_effects; Type: TABLE; Schema: public; Owner: -
 --
@@ -169,6 +195,27 @@ CREATE TABLE history_ledgers (
 );
 
 
+--
+-- Name: history_offer_events; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE history_offer_events (
+    history_operation_id bigint NOT NULL,
+    "order" integer NOT NULL,
+    offer_id bigint NOT NULL,
+    type smallint NOT NULL,
+    ledger_closed_at timestamp without time zone NOT NULL,
+    seller_account_id bigint NOT NULL,
+    selling_asset_id bigint NOT NULL,
+    buying_asset_id bigint NOT NULL,
+    amount bigint NOT NULL,
+    pricen integer NOT NULL,
+    priced integer NOT NULL,
+    price double precision NOT NULL,
+    CONSTRAINT history_offer_events_amount_check CHECK ((amount >= 0))
+);
+
+
 --
 -- Name: history_operation_participants; Type: TABLE; Schema: public; Owner: -
 --
@@ -213,6 +260,39 @@ CREATE TABLE history_operations (
 );
 
 
+--
+-- Name: history_order_book_snapshots; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE history_order_book_snapshots (
+    ledger_sequence integer NOT NULL,
+    selling_asset_id bigint NOT NULL,
+    buying_asset_id bigint NOT NULL,
+    levels jsonb NOT NULL
+);
+
+
+--
+-- Name: history_trade_aggregations; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE history_trade_aggregations (
+    resolution bigint NOT NULL,
+    base_asset_id bigint NOT NULL,
+    counter_asset_id bigint NOT NULL,
+    "timestamp" bigint NOT NULL,
+    count bigint NOT NULL,
+    base_volume bigint NOT NULL,
+    counter_volume bigint NOT NULL,
+    price_sum double precision NOT NULL,
+    inverse_price_sum double precision NOT NULL,
+    high double precision NOT NULL,
+    low double precision NOT NULL,
+    open double precision NOT NULL,
+    close double precision NOT NULL
+);
+
+
 --
 -- Name: history_trades; Type: TABLE; Schema: public; Owner: -
 --
@@ -331,6 +411,20 @@ INSERT INTO gorp_migrations VALUES ('6_create_assets_table.sql', '2017-11-29 18:
 INSERT INTO gorp_migrations VALUES ('7_modify_trades_table.sql', '2017-11-29 18:22:17.06929-08');
 INSERT INTO gorp_migrations VALUES ('8_add_aggregators.sql', '2017-11-29 18:22:17.071867-08');
 INSERT INTO gorp_migrations VALUES ('8_create_asset_stats_table.sql', '2017-11-29 18:22:17.074882-08');
+INSERT INTO gorp_migrations VALUES ('9_create_offer_events_table.sql', '2017-12-20 10:14:52.113281-08');
+INSERT INTO gorp_migrations VALUES ('10_index_operations_and_effects_by_type.sql', '2018-01-09 11:02:37.418733-08');
+INSERT INTO gorp_migrations VALUES ('11_index_transactions_by_memo.sql', '2018-01-11 16:47:05.902114-08');
+INSERT INTO gorp_migrations VALUES ('12_create_order_book_snapshots_table.sql', '2018-01-16 10:12:41.513307-08');
+INSERT INTO gorp_migrations VALUES ('13_create_trade_aggregations_table.sql', '2018-01-18 14:31:09.225861-08');
+INSERT INTO gorp_migrations VALUES ('14_index_trade_aggregations_by_time.sql', '2018-01-22 09:57:16.304552-08');
+INSERT INTO gorp_migrations VALUES ('15_create_account_creations_table.sql', '2018-01-24 15:03:27.719048-08');
+INSERT INTO gorp_migrations VALUES ('16_create_balance_snapshots_table.sql', '2018-01-26 11:42:08.127366-08');
+
+
+--
+-- Data for Name: history_account_creations; Type: TABLE DATA; Schema: public; Owner: -
+--
+
 
 
 --
@@ -359,6 +453,12 @@ SELECT pg_catalog.setval('history_accounts_id_seq', 1, false);
 SELECT pg_catalog.setval('history_assets_id_seq', 1, false);
 
 
+--
+-- Data for Name: history_balance_snapshots; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
 --
 -- Data for Name: history_effects; Type: TABLE DATA; Schema: public; Owner: -
 --
@@ -371,6 +471,12 @@ SELECT pg_catalog.setval('history_assets_id_seq', 1, false);
 
 
 
+--
+-- Data for Name: history_offer_events; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
 --
 -- Data for Name: history_operation_participants; Type: TABLE DATA; Schema: public; Owner: -
 --
@@ -390,6 +496,18 @@ SELECT pg_catalog.setval('history_operation_participants_id_seq', 1, false);
 
 
 
+--
+-- Data for Name: history_order_book_snapshots; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
+--
+-- Data for Name: history_trade_aggregations; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
 --
 -- Data for Name: history_trades; Type: TABLE DATA; Schema: public; Owner: -
 --
@@ -498,6 +616,48 @@ CREATE INDEX by_hash ON history_transactions USING btree (transaction_hash);
 CREATE INDEX by_ledger ON history_transactions USING btree (ledger_sequence, application_order);
 
 
+--
+-- Name: by_memo; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX by_memo ON history_transactions USING btree (memo_type, memo, id);
+
+
+--
+-- Name: hac_by_account; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX hac_by_account ON history_account_creations USING btree (history_account_id, history_operation_id);
+
+
+--
+-- Name: hac_by_creator; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX hac_by_creator ON history_account_creations USING btree (created_by_id, history_operation_id);
+
+
+--
+-- Name: hac_by_operation; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX hac_by_operation ON history_account_creations USING btree (history_operation_id);
+
+
+--
+-- Name: hbs_by_account; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX hbs_by_account ON history_balance_snapshots USING btree (history_account_id, history_asset_id, ledger_sequence);
+
+
+--
+-- Name: hbs_by_ledger; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX hbs_by_ledger ON history_balance_snapshots USING btree (ledger_sequence);
+
+
 --
 -- Name: hist_e_by_order; Type: INDEX; Schema: public; Owner: -
 --
@@ -505,6 +665,13 @@ CREATE INDEX by_ledger ON history_transactions USING btree (ledger_sequence, app
 CREATE UNIQUE INDEX hist_e_by_order ON history_effects USING btree (history_operation_id, "order");
 
 
+--
+-- Name: hist_e_by_type; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX hist_e_by_type ON history_effects USING btree (type, history_operation_id, "order");
+
+
 --
 -- Name: hist_e_id; Type: INDEX; Schema: public; Owner: -
 --
@@ -512,6 +679,13 @@ CREATE UNIQUE INDEX hist_e_by_order ON history_effects USING btree (history_oper
 CREATE UNIQUE INDEX hist_e_id ON history_effects USING btree (history_account_id, history_operation_id, "order");
 
 
+--
+-- Name: hist_op_by_type; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX hist_op_by_type ON history_operations USING btree (type, id);
+
+
 --
 -- Name: hist_op_p_id; Type: INDEX; Schema: public; Owner: -
 --
@@ -526,6 +700,34 @@ CREATE UNIQUE INDEX hist_op_p_id ON history_operation_participants USING btree (
 CREATE UNIQUE INDEX hist_tx_p_id ON history_transaction_participants USING btree (history_account_id, history_transaction_id);
 
 
+--
+-- Name: hobs_by_pair; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX hobs_by_pair ON history_order_book_snapshots USING btree (selling_asset_id, buying_asset_id, ledger_sequence);
+
+
+--
+-- Name: hoe_by_offer; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX hoe_by_offer ON history_offer_events USING btree (offer_id, history_operation_id, "order");
+
+
+--
+-- Name: hoe_by_seller; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX hoe_by_seller ON history_offer_events USING btree (seller_account_id);
+
+
+--
+-- Name: hoe_pid; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX hoe_pid ON history_offer_events USING btree (history_operation_id, "order");
+
+
 --
 -- Name: hop_by_hoid; Type: INDEX; Schema: public; Owner: -
 --
@@ -554,6 +756,20 @@ CREATE UNIQUE INDEX hs_transaction_by_id ON history_transactions USING btree (id
 CREATE INDEX htp_by_htid ON history_transaction_participants USING btree (history_transaction_id);
 
 
+--
+-- Name: htrd_agg_by_pair; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX htrd_agg_by_pair ON history_trade_aggregations USING btree (base_asset_id, counter_asset_id, resolution, "timestamp");
+
+
+--
+-- Name: htrd_agg_by_time; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX htrd_agg_by_time ON history_trade_aggregations USING btree (resolution, "timestamp");
+
+
 --
 -- Name: htrd_by_offer; Type: INDEX; Schema: public; Owner: -
 --
@@ -695,6 +911,102 @@ ALTER TABLE ONLY asset_stats
     ADD CONSTRAINT asset_stats_id_fkey FOREIGN KEY (id) REFERENCES history_assets(id) ON UPDATE RESTRICT ON DELETE CASCADE;
 
 
+--
+-- Name: history_account_creations history_account_creations_created_by_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_account_creations
+    ADD CONSTRAINT history_account_creations_created_by_id_fkey FOREIGN KEY (created_by_id) REFERENCES history_accounts(id);
+
+
+--
+-- Name: history_account_creations history_account_creations_history_account_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_account_creations
+    ADD CONSTRAINT history_account_creations_history_account_id_fkey FOREIGN KEY (history_account_id) REFERENCES history_accounts(id);
+
+
+--
+-- Name: history_account_creations history_account_creations_merged_into_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_account_creations
+    ADD CONSTRAINT history_account_creations_merged_into_id_fkey FOREIGN KEY (merged_into_id) REFERENCES history_accounts(id);
+
+
+--
+-- Name: history_balance_snapshots history_balance_snapshots_history_account_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_balance_snapshots
+    ADD CONSTRAINT history_balance_snapshots_history_account_id_fkey FOREIGN KEY (history_account_id) REFERENCES history_accounts(id);
+
+
+--
+-- Name: history_balance_snapshots history_balance_snapshots_history_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_balance_snapshots
+    ADD CONSTRAINT history_balance_snapshots_history_asset_id_fkey FOREIGN KEY (history_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_offer_events history_offer_events_buying_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_offer_events
+    ADD CONSTRAINT history_offer_events_buying_asset_id_fkey FOREIGN KEY (buying_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_offer_events history_offer_events_seller_account_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_offer_events
+    ADD CONSTRAINT history_offer_events_seller_account_id_fkey FOREIGN KEY (seller_account_id) REFERENCES history_accounts(id);
+
+
+--
+-- Name: history_offer_events history_offer_events_selling_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_offer_events
+    ADD CONSTRAINT history_offer_events_selling_asset_id_fkey FOREIGN KEY (selling_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_order_book_snapshots history_order_book_snapshots_buying_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_order_book_snapshots
+    ADD CONSTRAINT history_order_book_snapshots_buying_asset_id_fkey FOREIGN KEY (buying_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_order_book_snapshots history_order_book_snapshots_selling_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_order_book_snapshots
+    ADD CONSTRAINT history_order_book_snapshots_selling_asset_id_fkey FOREIGN KEY (selling_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_trade_aggregations history_trade_aggregations_base_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_trade_aggregations
+    ADD CONSTRAINT history_trade_aggregations_base_asset_id_fkey FOREIGN KEY (base_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_trade_aggregations history_trade_aggregations_counter_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_trade_aggregations
+    ADD CONSTRAINT history_trade_aggregations_counter_asset_id_fkey FOREIGN KEY (counter_asset_id) REFERENCES history_assets(id);
+
+
 --
 -- Name: history_trades history_trades_base_account_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
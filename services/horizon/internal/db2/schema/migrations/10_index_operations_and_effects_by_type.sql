@@ -0,0 +1,9 @@
+-- +migrate Up
+
+CREATE INDEX hist_op_by_type ON history_operations USING btree (type, id);
+CREATE INDEX hist_e_by_type ON history_effects USING btree (type, history_operation_id, "order");
+
+-- +migrate Down
+
+DROP INDEX hist_op_by_type;
+DROP INDEX hist_e_by_type;
@@ -0,0 +1,7 @@
+-- +migrate Up
+
+CREATE INDEX by_memo ON history_transactions USING btree (memo_type, memo, id);
+
+-- +migrate Down
+
+DROP INDEX by_memo;
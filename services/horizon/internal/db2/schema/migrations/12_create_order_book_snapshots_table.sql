@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE history_order_book_snapshots (
+    ledger_sequence INTEGER NOT NULL,
+    selling_asset_id BIGINT NOT NULL REFERENCES history_assets(id),
+    buying_asset_id BIGINT NOT NULL REFERENCES history_assets(id),
+    levels JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX hobs_by_pair ON history_order_book_snapshots USING btree (selling_asset_id, buying_asset_id, ledger_sequence);
+
+-- +migrate Down
+DROP TABLE history_order_book_snapshots cascade;
@@ -0,0 +1,40 @@
+-- +migrate Up
+CREATE TABLE history_trade_aggregations (
+    resolution BIGINT NOT NULL,
+    base_asset_id BIGINT NOT NULL REFERENCES history_assets(id),
+    counter_asset_id BIGINT NOT NULL REFERENCES history_assets(id),
+    timestamp BIGINT NOT NULL,
+    count BIGINT NOT NULL,
+    base_volume BIGINT NOT NULL,
+    counter_volume BIGINT NOT NULL,
+    price_sum DOUBLE PRECISION NOT NULL,
+    inverse_price_sum DOUBLE PRECISION NOT NULL,
+    high DOUBLE PRECISION NOT NULL,
+    low DOUBLE PRECISION NOT NULL,
+    open DOUBLE PRECISION NOT NULL,
+    close DOUBLE PRECISION NOT NULL
+);
+
+CREATE UNIQUE INDEX htrd_agg_by_pair ON history_trade_aggregations USING btree (base_asset_id, counter_asset_id, resolution, timestamp);
+
+INSERT INTO history_trade_aggregations
+SELECT
+    resolution,
+    base_asset_id,
+    counter_asset_id,
+    div(cast((extract(epoch from ledger_closed_at) * 1000) as bigint), resolution) * resolution as timestamp,
+    count(*),
+    sum(base_amount),
+    sum(counter_amount),
+    sum(counter_amount::float / base_amount),
+    sum(base_amount::float / counter_amount),
+    max(counter_amount::float / base_amount),
+    min(counter_amount::float / base_amount),
+    first(counter_amount::float / base_amount ORDER BY history_operation_id, "order"),
+    last(counter_amount::float / base_amount ORDER BY history_operation_id, "order")
+FROM history_trades
+CROSS JOIN (VALUES (60000), (900000), (3600000), (86400000)) AS resolutions (resolution)
+GROUP BY 1, 2, 3, 4;
+
+-- +migrate Down
+DROP TABLE history_trade_aggregations cascade;
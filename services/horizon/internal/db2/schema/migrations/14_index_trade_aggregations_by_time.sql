@@ -0,0 +1,7 @@
+-- +migrate Up
+
+CREATE INDEX htrd_agg_by_time ON history_trade_aggregations USING btree (resolution, timestamp);
+
+-- +migrate Down
+
+DROP INDEX htrd_agg_by_time;
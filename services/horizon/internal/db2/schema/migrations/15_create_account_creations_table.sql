@@ -0,0 +1,17 @@
+-- +migrate Up
+
+CREATE TABLE history_account_creations (
+    history_operation_id BIGINT NOT NULL,
+    history_account_id BIGINT NOT NULL REFERENCES history_accounts(id),
+    created_by_id BIGINT NOT NULL REFERENCES history_accounts(id),
+    merged_into_id BIGINT REFERENCES history_accounts(id),
+    merged_by_operation_id BIGINT
+);
+
+CREATE UNIQUE INDEX hac_by_operation ON history_account_creations USING btree (history_operation_id);
+CREATE INDEX hac_by_account ON history_account_creations USING btree (history_account_id, history_operation_id);
+CREATE INDEX hac_by_creator ON history_account_creations USING btree (created_by_id, history_operation_id);
+
+-- +migrate Down
+
+DROP TABLE history_account_creations cascade;
@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE history_balance_snapshots (
+    history_account_id BIGINT NOT NULL REFERENCES history_accounts(id),
+    history_asset_id BIGINT NOT NULL REFERENCES history_assets(id),
+    ledger_sequence INTEGER NOT NULL,
+    balance BIGINT NOT NULL CHECK (balance >= 0)
+);
+
+CREATE UNIQUE INDEX hbs_by_account ON history_balance_snapshots USING btree (history_account_id, history_asset_id, ledger_sequence);
+CREATE INDEX hbs_by_ledger ON history_balance_snapshots USING btree (ledger_sequence);
+
+-- +migrate Down
+DROP TABLE history_balance_snapshots cascade;
@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE history_offer_events (
+    history_operation_id BIGINT NOT NULL,
+    "order" INTEGER NOT NULL,
+    offer_id BIGINT NOT NULL,
+    type SMALLINT NOT NULL,
+    ledger_closed_at TIMESTAMP NOT NULL,
+    seller_account_id BIGINT NOT NULL REFERENCES history_accounts(id),
+    selling_asset_id BIGINT NOT NULL REFERENCES history_assets(id),
+    buying_asset_id BIGINT NOT NULL REFERENCES history_assets(id),
+    amount BIGINT NOT NULL CHECK (amount >= 0),
+    pricen INTEGER NOT NULL,
+    priced INTEGER NOT NULL,
+    price DOUBLE PRECISION NOT NULL
+);
+
+CREATE UNIQUE INDEX hoe_pid ON history_offer_events USING btree (history_operation_id, "order");
+CREATE INDEX hoe_by_offer ON history_offer_events USING btree (offer_id, history_operation_id, "order");
+CREATE INDEX hoe_by_seller ON history_offer_events USING btree (seller_account_id);
+
+-- +migrate Down
+DROP TABLE history_offer_events cascade;
@@ -0,0 +1,56 @@
+// Package feestats provides a central location to store a cached snapshot of
+// the fees paid by transactions in the most recently ingested ledgers.  Like
+// the ledger package, it is intended to be at the lowest levels of horizon's
+// dependency tree, please keep it free of dependencies to other horizon
+// packages.
+package feestats
+
+import (
+	"sync"
+)
+
+// DefaultLedgerCount is the number of recent ledgers that fee statistics are
+// computed over when no other value is configured.
+const DefaultLedgerCount = 5
+
+// State represents a snapshot of the fees paid per operation, in stroops, by
+// the transactions included in the `LedgerCount` ledgers ending with
+// `LastLedger`.
+type State struct {
+	LastLedger          int32
+	LastLedgerBaseFee   int32
+	LedgerCount         int32
+	LedgerCapacityUsage float64
+
+	Min  int64
+	Mode int64
+	P10  int64
+	P20  int64
+	P30  int64
+	P40  int64
+	P50  int64
+	P60  int64
+	P70  int64
+	P80  int64
+	P90  int64
+	P95  int64
+	P99  int64
+}
+
+// CurrentState returns the cached snapshot of fee statistics
+func CurrentState() State {
+	lock.RLock()
+	ret := current
+	lock.RUnlock()
+	return ret
+}
+
+// SetState updates the cached snapshot of fee statistics
+func SetState(next State) {
+	lock.Lock()
+	current = next
+	lock.Unlock()
+}
+
+var current State
+var lock sync.RWMutex
@@ -0,0 +1,193 @@
+package ingest
+
+import (
+	"encoding/hex"
+	"io"
+
+	"github.com/stellar/go/historyarchive"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+var _ LedgerSource = &ArchiveLedgerSource{}
+
+// NewArchiveLedgerSource returns a source that reads the ledgers of `network`
+// from `archive`.
+func NewArchiveLedgerSource(archive *historyarchive.Archive, network string) *ArchiveLedgerSource {
+	return &ArchiveLedgerSource{
+		Archive: archive,
+		Network: network,
+	}
+}
+
+// LoadLedger implements LedgerSource.  The checkpoint containing the ledger is
+// read in full and kept until a ledger from another checkpoint is loaded, so
+// loading consecutive ledgers reads each checkpoint once.
+func (s *ArchiveLedgerSource) LoadLedger(lb *LedgerBundle) error {
+	seq := uint32(lb.Sequence)
+
+	err := s.loadCheckpoint(historyarchive.NextCheckpoint(seq))
+	if err != nil {
+		return err
+	}
+
+	entry, ok := s.headers[seq]
+	if !ok {
+		return errors.Errorf("ledger %d not found in history archive", seq)
+	}
+
+	lb.Header = core.LedgerHeader{
+		LedgerHash:     hex.EncodeToString(entry.Hash[:]),
+		PrevHash:       hex.EncodeToString(entry.Header.PreviousLedgerHash[:]),
+		BucketListHash: hex.EncodeToString(entry.Header.BucketListHash[:]),
+		CloseTime:      int64(entry.Header.ScpValue.CloseTime),
+		Sequence:       seq,
+		Data:           entry.Header,
+	}
+
+	err = s.loadTransactions(lb)
+	if err != nil {
+		return errors.Wrap(err, "failed to load transactions")
+	}
+
+	lb.MetaUnavailable = true
+	return nil
+}
+
+// loadCheckpoint reads the ledger headers, transaction sets and results of the
+// checkpoint `chk`, unless they are already loaded.
+func (s *ArchiveLedgerSource) loadCheckpoint(chk uint32) error {
+	if s.headers != nil && s.checkpoint == chk {
+		return nil
+	}
+
+	headers := map[uint32]xdr.LedgerHeaderHistoryEntry{}
+	err := s.readCategory("ledger", chk, func(stream *historyarchive.XdrStream) error {
+		var entry xdr.LedgerHeaderHistoryEntry
+		err := stream.ReadOne(&entry)
+		if err == nil {
+			headers[uint32(entry.Header.LedgerSeq)] = entry
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	transactions := map[uint32]xdr.TransactionHistoryEntry{}
+	err = s.readCategory("transactions", chk, func(stream *historyarchive.XdrStream) error {
+		var entry xdr.TransactionHistoryEntry
+		err := stream.ReadOne(&entry)
+		if err == nil {
+			transactions[uint32(entry.LedgerSeq)] = entry
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	results := map[uint32]xdr.TransactionHistoryResultEntry{}
+	err = s.readCategory("results", chk, func(stream *historyarchive.XdrStream) error {
+		var entry xdr.TransactionHistoryResultEntry
+		err := stream.ReadOne(&entry)
+		if err == nil {
+			results[uint32(entry.LedgerSeq)] = entry
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.checkpoint = chk
+	s.headers = headers
+	s.transactions = transactions
+	s.results = results
+	return nil
+}
+
+// loadTransactions fills in the transactions of `lb`, in the order they were
+// applied.  Transaction sets are stored in hash order, so each result is
+// matched with its envelope by hash.  The meta of each transaction is empty.
+func (s *ArchiveLedgerSource) loadTransactions(lb *LedgerBundle) error {
+	seq := uint32(lb.Sequence)
+	lb.Transactions = []core.Transaction{}
+	lb.TransactionFees = []core.TransactionFee{}
+
+	results, ok := s.results[seq]
+	if !ok {
+		return nil
+	}
+
+	envelopes := map[string]xdr.TransactionEnvelope{}
+	for _, env := range s.transactions[seq].TxSet.Txs {
+		hash, err := network.HashTransaction(&env.Tx, s.Network)
+		if err != nil {
+			return errors.Wrap(err, "failed to hash transaction")
+		}
+		envelopes[hex.EncodeToString(hash[:])] = env
+	}
+
+	for i, result := range results.TxResultSet.Results {
+		hash := hex.EncodeToString(result.TransactionHash[:])
+
+		env, ok := envelopes[hash]
+		if !ok {
+			return errors.Errorf("transaction %s not found in history archive", hash)
+		}
+
+		opmeta := make([]xdr.OperationMeta, len(env.Tx.Operations))
+		txmeta, err := xdr.NewTransactionMeta(0, opmeta)
+		if err != nil {
+			return errors.Wrap(err, "failed to build transaction meta")
+		}
+
+		lb.Transactions = append(lb.Transactions, core.Transaction{
+			TransactionHash: hash,
+			LedgerSequence:  lb.Sequence,
+			Index:           int32(i + 1),
+			Envelope:        env,
+			Result:          result,
+			ResultMeta:      txmeta,
+		})
+
+		lb.TransactionFees = append(lb.TransactionFees, core.TransactionFee{
+			TransactionHash: hash,
+			LedgerSequence:  lb.Sequence,
+			Index:           int32(i + 1),
+			Changes:         xdr.LedgerEntryChanges{},
+		})
+	}
+
+	return nil
+}
+
+// readCategory calls `read` on the stream of the `cat` file of checkpoint
+// `chk` until it reaches the end of the file.
+func (s *ArchiveLedgerSource) readCategory(
+	cat string,
+	chk uint32,
+	read func(*historyarchive.XdrStream) error,
+) error {
+	path := historyarchive.CategoryCheckpointPath(cat, chk)
+
+	stream, err := s.Archive.GetXdrStream(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", path)
+	}
+	defer stream.Close()
+
+	for {
+		err = read(stream)
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", path)
+		}
+	}
+}
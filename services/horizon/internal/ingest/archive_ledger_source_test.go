@@ -0,0 +1,238 @@
+package ingest
+
+import (
+	"compress/gzip"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stellar/go/historyarchive"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/xdr"
+)
+
+func TestArchiveLedgerSource(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	bundles, archive, cleanup := newTestArchive(tt)
+	defer cleanup()
+	latest := bundles[len(bundles)-1].Sequence
+	source := NewArchiveLedgerSource(archive, network.TestNetworkPassphrase)
+
+	for _, expected := range bundles {
+		lb := LedgerBundle{Sequence: expected.Sequence}
+		err := source.LoadLedger(&lb)
+		if !tt.Assert.NoError(err) {
+			continue
+		}
+
+		tt.Assert.True(lb.MetaUnavailable)
+		tt.Assert.Equal(expected.Header.LedgerHash, lb.Header.LedgerHash)
+		tt.Assert.Equal(expected.Header.PrevHash, lb.Header.PrevHash)
+		tt.Assert.Equal(expected.Header.CloseTime, lb.Header.CloseTime)
+		tt.Assert.Equal(expected.Header.Sequence, lb.Header.Sequence)
+
+		// transactions are loaded in application order
+		if tt.Assert.Len(lb.Transactions, len(expected.Transactions)) {
+			for i := range lb.Transactions {
+				tt.Assert.Equal(expected.Transactions[i].TransactionHash, lb.Transactions[i].TransactionHash)
+				tt.Assert.Equal(expected.Transactions[i].Envelope, lb.Transactions[i].Envelope)
+				tt.Assert.Equal(expected.Transactions[i].Result, lb.Transactions[i].Result)
+				tt.Assert.Len(lb.Transactions[i].ResultMeta.MustOperations(), len(lb.Transactions[i].Envelope.Tx.Operations))
+			}
+		}
+		tt.Assert.Len(lb.TransactionFees, len(expected.Transactions))
+	}
+
+	// ledgers missing from the archive
+	lb := LedgerBundle{Sequence: latest + 1}
+	tt.Assert.Error(source.LoadLedger(&lb))
+
+	lb = LedgerBundle{Sequence: 100}
+	tt.Assert.Error(source.LoadLedger(&lb))
+
+	// reingest the scenario's history from the archive
+	sys := sys(tt)
+	sys.HistoryArchive = archive
+	tt.Require.NoError(sys.ClearAll())
+
+	ingested, err := sys.ReingestRange(1, latest)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int(latest), ingested)
+
+	var coreCount, historyCount int
+	tt.Require.NoError(tt.CoreSession().GetRaw(&coreCount, "SELECT COUNT(*) FROM txhistory"))
+	tt.Require.NoError(tt.HorizonSession().GetRaw(&historyCount, "SELECT COUNT(*) FROM history_transactions"))
+	tt.Assert.Equal(coreCount, historyCount)
+}
+
+// TestArchiveReingestion checks that ledgers ingested from a history archive,
+// which lack the trust line effects and offer events derived from meta, are
+// tagged as incomplete and rebuilt in full from stellar-core.
+func TestArchiveReingestion(t *testing.T) {
+	tt := test.Start(t).Scenario("trades")
+	defer tt.Finish()
+
+	bundles, archive, cleanup := newTestArchive(tt)
+	defer cleanup()
+	latest := bundles[len(bundles)-1].Sequence
+
+	sys := sys(tt)
+	sys.HistoryArchive = archive
+
+	count := func(query string) int {
+		var found int
+		tt.Require.NoError(tt.HorizonSession().GetRaw(&found, query))
+		return found
+	}
+	trustlineEffects := func() int {
+		return count(fmt.Sprintf(
+			"SELECT COUNT(*) FROM history_effects WHERE type = %d",
+			history.EffectTrustlineCreated,
+		))
+	}
+	offerEvents := func() int {
+		return count("SELECT COUNT(*) FROM history_offer_events")
+	}
+	archived := func() int {
+		return count(fmt.Sprintf(
+			"SELECT COUNT(*) FROM history_ledgers WHERE importer_version = %d",
+			ArchiveVersion,
+		))
+	}
+
+	reingestFromArchive := func() {
+		tt.Require.NoError(sys.ClearAll())
+		ingested, err := sys.ReingestRange(1, latest)
+		tt.Require.NoError(err)
+		tt.Require.Equal(int(latest), ingested)
+	}
+
+	// ledgers ingested from the archive lack the data derived from meta, and
+	// are reported as an incomplete gap
+	reingestFromArchive()
+	tt.Assert.Equal(int(latest), archived())
+	tt.Assert.Zero(trustlineEffects())
+	tt.Assert.Zero(offerEvents())
+
+	report, err := sys.CheckGaps()
+	tt.Require.NoError(err)
+	tt.Assert.Equal([]history.LedgerGap{
+		{Start: 1, End: latest, Incomplete: true},
+	}, report.Gaps)
+
+	// healing the gap rebuilds them from stellar-core
+	healed, err := sys.HealGaps(report, latest)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(latest, healed)
+	tt.Assert.Zero(archived())
+	tt.Assert.NotZero(trustlineEffects())
+	tt.Assert.NotZero(offerEvents())
+
+	report, err = sys.CheckGaps()
+	tt.Require.NoError(err)
+	tt.Assert.Empty(report.Gaps)
+
+	// as does reingesting outdated ledgers, even with an archive configured
+	reingestFromArchive()
+	ingested, err := sys.ReingestOutdated()
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int(latest), ingested)
+	tt.Assert.Zero(archived())
+	tt.Assert.NotZero(trustlineEffects())
+	tt.Assert.NotZero(offerEvents())
+}
+
+// newTestArchive loads the ledgers of the current scenario from stellar-core
+// and writes them to a history archive in a temporary directory.  The returned
+// func removes the directory.
+func newTestArchive(tt *test.T) ([]LedgerBundle, *historyarchive.Archive, func()) {
+	var latest int32
+	cq := &core.Q{Session: tt.CoreSession()}
+	tt.Require.NoError(cq.LatestLedger(&latest))
+
+	bundles := make([]LedgerBundle, 0, latest)
+	for seq := int32(1); seq <= latest; seq++ {
+		lb := LedgerBundle{Sequence: seq}
+		tt.Require.NoError(lb.Load(tt.CoreSession()))
+		bundles = append(bundles, lb)
+	}
+
+	dir, err := ioutil.TempDir("", "horizon-archive")
+	tt.Require.NoError(err)
+	cleanup := func() { os.RemoveAll(dir) }
+	writeTestArchive(tt, dir, bundles)
+
+	archive, err := historyarchive.Connect("file://"+dir, nil)
+	if err != nil {
+		cleanup()
+		tt.Require.NoError(err)
+	}
+
+	return bundles, archive, cleanup
+}
+
+// writeTestArchive writes the checkpoint files of a history archive containing
+// `bundles`, which must all belong to the first checkpoint, to `dir`.
+func writeTestArchive(tt *test.T, dir string, bundles []LedgerBundle) {
+	var (
+		headers      []interface{}
+		transactions []interface{}
+		results      []interface{}
+	)
+
+	for _, lb := range bundles {
+		var hash xdr.Hash
+		raw, err := hex.DecodeString(lb.Header.LedgerHash)
+		tt.Require.NoError(err)
+		copy(hash[:], raw)
+
+		headers = append(headers, xdr.LedgerHeaderHistoryEntry{
+			Hash:   hash,
+			Header: lb.Header.Data,
+		})
+
+		if len(lb.Transactions) == 0 {
+			continue
+		}
+
+		// transaction sets are not stored in application order
+		txs := xdr.TransactionHistoryEntry{LedgerSeq: xdr.Uint32(lb.Sequence)}
+		trs := xdr.TransactionHistoryResultEntry{LedgerSeq: xdr.Uint32(lb.Sequence)}
+		for i := range lb.Transactions {
+			tx := lb.Transactions[len(lb.Transactions)-1-i]
+			txs.TxSet.Txs = append(txs.TxSet.Txs, tx.Envelope)
+			trs.TxResultSet.Results = append(trs.TxResultSet.Results, lb.Transactions[i].Result)
+		}
+
+		transactions = append(transactions, txs)
+		results = append(results, trs)
+	}
+
+	chk := historyarchive.NextCheckpoint(uint32(bundles[0].Sequence))
+	writeTestArchiveFile(tt, dir, historyarchive.CategoryCheckpointPath("ledger", chk), headers)
+	writeTestArchiveFile(tt, dir, historyarchive.CategoryCheckpointPath("transactions", chk), transactions)
+	writeTestArchiveFile(tt, dir, historyarchive.CategoryCheckpointPath("results", chk), results)
+}
+
+func writeTestArchiveFile(tt *test.T, dir string, path string, entries []interface{}) {
+	path = filepath.Join(dir, path)
+	tt.Require.NoError(os.MkdirAll(filepath.Dir(path), 0755))
+
+	file, err := os.Create(path)
+	tt.Require.NoError(err)
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	for _, entry := range entries {
+		tt.Require.NoError(historyarchive.WriteFramedXdr(gz, entry))
+	}
+	tt.Require.NoError(gz.Close())
+}
@@ -71,7 +71,7 @@ func (assetsModified AssetsModified) IngestOperation(err error, op *xdr.Operatio
 
 // UpdateAssetStats updates the db with the latest asset stats for the assets that were modified
 func (assetsModified AssetsModified) UpdateAssetStats(is *Session) {
-	if is.Err != nil {
+	if is.Err != nil || is.SkipAggregates {
 		return
 	}
 
@@ -0,0 +1,128 @@
+package ingest
+
+import (
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/xdr"
+)
+
+// balanceSnapshot is the balance of a single asset held by an account.
+type balanceSnapshot struct {
+	account xdr.AccountId
+	asset   xdr.Asset
+	balance xdr.Int64
+}
+
+// key returns a string that uniquely identifies the balance.
+func (s balanceSnapshot) key() string {
+	return s.account.Address() + "/" + s.asset.String()
+}
+
+// ingestBalanceSnapshots records, for every balance modified by the current
+// ledger, the balance held at the close of the ledger, as reported by the meta
+// of its transactions.  Removed accounts and trust lines are recorded with a
+// balance of zero.
+func (is *Session) ingestBalanceSnapshots() {
+	if is.Err != nil {
+		return
+	}
+
+	// NOTE: without meta, we cannot tell which balances changed.
+	if !is.Cursor.MetaAvailable() {
+		return
+	}
+
+	// index the final state of each balance, keeping the order in which they
+	// were first modified so that snapshots are inserted deterministically.
+	var keys []string
+	latest := map[string]balanceSnapshot{}
+
+	for _, change := range is.Cursor.LedgerChanges() {
+		snapshot, ok := balanceSnapshotForChange(change)
+		if !ok {
+			continue
+		}
+
+		if !is.Filter.MatchBalance(snapshot.account.Address(), snapshot.asset) {
+			continue
+		}
+
+		key := snapshot.key()
+		if _, seen := latest[key]; !seen {
+			keys = append(keys, key)
+		}
+		latest[key] = snapshot
+	}
+
+	q := history.Q{Session: is.Ingestion.DB}
+	for _, key := range keys {
+		snapshot := latest[key]
+
+		var aid int64
+		aid, is.Err = q.GetCreateAccountID(snapshot.account)
+		if is.Err != nil {
+			return
+		}
+
+		is.Err = q.InsertBalanceSnapshot(
+			aid,
+			snapshot.asset,
+			is.Cursor.LedgerSequence(),
+			snapshot.balance,
+		)
+		if is.Err != nil {
+			return
+		}
+	}
+}
+
+// balanceSnapshotForChange returns the balance that results from `change`.
+// Returns false if the change does not affect a balance.
+func balanceSnapshotForChange(change xdr.LedgerEntryChange) (balanceSnapshot, bool) {
+	var entry xdr.LedgerEntry
+
+	switch change.Type {
+	case xdr.LedgerEntryChangeTypeLedgerEntryCreated:
+		entry = change.MustCreated()
+	case xdr.LedgerEntryChangeTypeLedgerEntryUpdated:
+		entry = change.MustUpdated()
+	case xdr.LedgerEntryChangeTypeLedgerEntryRemoved:
+		key := change.MustRemoved()
+		switch key.Type {
+		case xdr.LedgerEntryTypeAccount:
+			native, _ := xdr.NewAsset(xdr.AssetTypeAssetTypeNative, nil)
+			return balanceSnapshot{
+				account: key.MustAccount().AccountId,
+				asset:   native,
+			}, true
+		case xdr.LedgerEntryTypeTrustline:
+			tl := key.MustTrustLine()
+			return balanceSnapshot{
+				account: tl.AccountId,
+				asset:   tl.Asset,
+			}, true
+		}
+		return balanceSnapshot{}, false
+	default:
+		return balanceSnapshot{}, false
+	}
+
+	switch entry.Data.Type {
+	case xdr.LedgerEntryTypeAccount:
+		account := entry.Data.MustAccount()
+		native, _ := xdr.NewAsset(xdr.AssetTypeAssetTypeNative, nil)
+		return balanceSnapshot{
+			account: account.AccountId,
+			asset:   native,
+			balance: account.Balance,
+		}, true
+	case xdr.LedgerEntryTypeTrustline:
+		tl := entry.Data.MustTrustLine()
+		return balanceSnapshot{
+			account: tl.AccountId,
+			asset:   tl.Asset,
+			balance: tl.Balance,
+		}, true
+	}
+
+	return balanceSnapshot{}, false
+}
@@ -59,6 +59,27 @@ func (c *Cursor) LedgerID() int64 {
 	return toid.New(c.lg, 0, 0).ToInt64()
 }
 
+// LedgerChanges returns all of the LedgerEntryChanges that occurred in the
+// course of closing the current ledger, in the order they were applied.  The
+// fees of every transaction are charged before any transaction is applied.
+func (c *Cursor) LedgerChanges() (ret xdr.LedgerEntryChanges) {
+	for i := range c.data.TransactionFees {
+		ret = append(ret, c.data.TransactionFees[i].Changes...)
+	}
+
+	for i := range c.data.Transactions {
+		ops, ok := c.data.Transactions[i].ResultMeta.GetOperations()
+		if !ok {
+			continue
+		}
+		for _, op := range ops {
+			ret = append(ret, op.Changes...)
+		}
+	}
+
+	return
+}
+
 // LedgerRange returns the beginning and end of id values that map to the
 // current ledger.  Useful for clearing a ledgers worth of data.
 func (c *Cursor) LedgerRange() (start int64, end int64) {
@@ -98,7 +119,11 @@ func (c *Cursor) NextLedger() bool {
 
 	c.data = &LedgerBundle{Sequence: c.lg}
 	start := time.Now()
-	c.Err = c.data.Load(c.DB)
+	if c.Source != nil {
+		c.Err = c.Source.LoadLedger(c.data)
+	} else {
+		c.Err = c.data.Load(c.DB)
+	}
 	if c.Err != nil {
 		return false
 	}
@@ -139,6 +164,12 @@ func (c *Cursor) Operation() *xdr.Operation {
 	return &c.data.Transactions[c.tx].Envelope.Tx.Operations[c.op]
 }
 
+// MetaAvailable returns false when the current ledger was loaded without the
+// meta of its transactions.
+func (c *Cursor) MetaAvailable() bool {
+	return !c.data.MetaUnavailable
+}
+
 // OperationChanges returns all of LedgerEntryChanges that occurred in the
 // course of applying the current operation.
 func (c *Cursor) OperationChanges() xdr.LedgerEntryChanges {
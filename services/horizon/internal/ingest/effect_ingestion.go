@@ -6,7 +6,8 @@ import (
 )
 
 // Add writes an effect to the database while automatically tracking the index
-// to use.
+// to use.  Effects of accounts excluded by the filter are skipped, keeping the
+// index of the effects that follow.
 func (ei *EffectIngestion) Add(aid xdr.AccountId, typ history.EffectType, details interface{}) bool {
 	q := history.Q{Session: ei.parent.DB}
 
@@ -15,6 +16,9 @@ func (ei *EffectIngestion) Add(aid xdr.AccountId, typ history.EffectType, detail
 	}
 
 	ei.added++
+	if !ei.Filter.MatchAccount(aid) {
+		return false
+	}
 	var haid int64
 
 	haid, ei.err = q.GetCreateAccountID(aid)
@@ -27,6 +31,15 @@ func (ei *EffectIngestion) Add(aid xdr.AccountId, typ history.EffectType, detail
 		return false
 	}
 
+	event := EffectEvent{
+		OperationID: ei.OperationID,
+		Order:       ei.added,
+		Account:     aid.Address(),
+		Type:        typ,
+		Details:     details,
+	}
+	ei.Dest.emit(func(p Processor) error { return p.ProcessEffect(event) })
+
 	return true
 }
 
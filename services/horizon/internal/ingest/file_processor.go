@@ -0,0 +1,70 @@
+package ingest
+
+import (
+	"os"
+
+	"github.com/stellar/go/support/errors"
+)
+
+var _ Processor = &FileProcessor{}
+
+// NewFileProcessor returns a processor that appends events to the file at
+// `path`, creating it if it does not exist.
+func NewFileProcessor(path string) (*FileProcessor, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open file")
+	}
+
+	return &FileProcessor{Path: path, file: file}, nil
+}
+
+// Close closes the file events are appended to.
+func (p *FileProcessor) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.file.Close()
+}
+
+// ProcessLedger implements Processor.
+func (p *FileProcessor) ProcessLedger(event LedgerEvent) error {
+	return p.write("ledger", event)
+}
+
+// ProcessTransaction implements Processor.
+func (p *FileProcessor) ProcessTransaction(event TransactionEvent) error {
+	return p.write("transaction", event)
+}
+
+// ProcessOperation implements Processor.
+func (p *FileProcessor) ProcessOperation(event OperationEvent) error {
+	return p.write("operation", event)
+}
+
+// ProcessEffect implements Processor.
+func (p *FileProcessor) ProcessEffect(event EffectEvent) error {
+	return p.write("effect", event)
+}
+
+// ProcessTrade implements Processor.
+func (p *FileProcessor) ProcessTrade(event TradeEvent) error {
+	return p.write("trade", event)
+}
+
+func (p *FileProcessor) write(typ string, event interface{}) error {
+	line, err := marshalEvent(typ, event)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event")
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	_, err = p.file.Write(append(line, '\n'))
+	if err != nil {
+		return errors.Wrapf(err, "failed to write to %s", p.Path)
+	}
+
+	return nil
+}
@@ -0,0 +1,242 @@
+package ingest
+
+import (
+	"strings"
+
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/ingest/participants"
+	"github.com/stellar/go/services/horizon/internal/resource/operations"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// NewFilter returns a filter for the accounts given by address, the assets
+// given as `native` or `CODE:ISSUER`, and the operation types given by their
+// names in horizon's responses.  Empty lists leave the corresponding
+// criterion unset.
+func NewFilter(accounts, assets, operationTypes []string) (*Filter, error) {
+	f := &Filter{
+		Accounts:       map[string]bool{},
+		Assets:         map[string]bool{},
+		OperationTypes: map[xdr.OperationType]bool{},
+	}
+
+	for _, address := range accounts {
+		var aid xdr.AccountId
+		err := aid.SetAddress(address)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid account %s", address)
+		}
+		f.Accounts[address] = true
+	}
+
+	for _, raw := range assets {
+		asset, err := parseFilterAsset(raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid asset %s", raw)
+		}
+		f.Assets[asset.String()] = true
+	}
+
+	names := map[string]xdr.OperationType{}
+	for typ, name := range operations.TypeNames {
+		names[name] = typ
+	}
+
+	for _, name := range operationTypes {
+		typ, ok := names[name]
+		if !ok {
+			return nil, errors.Errorf("unknown operation type %s", name)
+		}
+		f.OperationTypes[typ] = true
+	}
+
+	return f, nil
+}
+
+// MatchTransaction returns true when `tx` meets every criterion of the filter.
+// A nil filter matches every transaction.
+func (f *Filter) MatchTransaction(tx *core.Transaction, fee *core.TransactionFee) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+
+	if len(f.Accounts) > 0 {
+		p, err := participants.ForTransaction(&tx.Envelope.Tx, &tx.ResultMeta, &fee.Changes)
+		if err != nil {
+			return false, err
+		}
+
+		if !f.matchAccounts(p) {
+			return false, nil
+		}
+	}
+
+	if len(f.Assets) == 0 && len(f.OperationTypes) == 0 {
+		return true, nil
+	}
+
+	for _, op := range tx.Envelope.Tx.Operations {
+		if f.matchOperation(tx, op) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// MatchOperation returns true when `op`, an operation of `tx` in which the
+// accounts `p` participate, meets every criterion of the filter.  Only the
+// matching operations of a matching transaction are written.  A nil filter
+// matches every operation.
+func (f *Filter) MatchOperation(tx *core.Transaction, op xdr.Operation, p []xdr.AccountId) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.Accounts) > 0 && !f.matchAccounts(p) {
+		return false
+	}
+
+	return f.matchOperation(tx, op)
+}
+
+// MatchAccount returns true when records of the account `aid`, such as its
+// effects and its participation in transactions and operations, are written.
+// A nil filter matches every account.
+func (f *Filter) MatchAccount(aid xdr.AccountId) bool {
+	if f == nil || len(f.Accounts) == 0 {
+		return true
+	}
+
+	return f.Accounts[aid.Address()]
+}
+
+// matchingAccounts returns the accounts of `aids` that match the filter.
+func (f *Filter) matchingAccounts(aids []xdr.AccountId) []xdr.AccountId {
+	if f == nil || len(f.Accounts) == 0 {
+		return aids
+	}
+
+	var result []xdr.AccountId
+	for _, aid := range aids {
+		if f.MatchAccount(aid) {
+			result = append(result, aid)
+		}
+	}
+	return result
+}
+
+// MatchBalance returns true when the balance of `asset` held by the account
+// `address` is relevant to the filter.  A nil filter matches every balance.
+func (f *Filter) MatchBalance(address string, asset xdr.Asset) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.Accounts) > 0 && !f.Accounts[address] {
+		return false
+	}
+
+	if len(f.Assets) > 0 && !f.Assets[asset.String()] {
+		return false
+	}
+
+	return true
+}
+
+func (f *Filter) matchAccounts(aids []xdr.AccountId) bool {
+	for i := range aids {
+		if f.Accounts[aids[i].Address()] {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Filter) matchOperation(tx *core.Transaction, op xdr.Operation) bool {
+	if len(f.OperationTypes) > 0 && !f.OperationTypes[op.Body.Type] {
+		return false
+	}
+
+	if len(f.Assets) == 0 {
+		return true
+	}
+
+	for _, asset := range operationAssets(tx, op) {
+		if f.Assets[asset.String()] {
+			return true
+		}
+	}
+	return false
+}
+
+// operationAssets returns the assets that `op`, an operation of `tx`, moves,
+// trades, or changes or allows trust in.
+func operationAssets(tx *core.Transaction, op xdr.Operation) []xdr.Asset {
+	native, _ := xdr.NewAsset(xdr.AssetTypeAssetTypeNative, nil)
+
+	switch op.Body.Type {
+	case xdr.OperationTypeCreateAccount, xdr.OperationTypeAccountMerge, xdr.OperationTypeInflation:
+		return []xdr.Asset{native}
+	case xdr.OperationTypePayment:
+		return []xdr.Asset{op.Body.MustPaymentOp().Asset}
+	case xdr.OperationTypePathPayment:
+		pp := op.Body.MustPathPaymentOp()
+		return append([]xdr.Asset{pp.SendAsset, pp.DestAsset}, pp.Path...)
+	case xdr.OperationTypeManageOffer:
+		mo := op.Body.MustManageOfferOp()
+		return []xdr.Asset{mo.Selling, mo.Buying}
+	case xdr.OperationTypeCreatePassiveOffer:
+		po := op.Body.MustCreatePassiveOfferOp()
+		return []xdr.Asset{po.Selling, po.Buying}
+	case xdr.OperationTypeChangeTrust:
+		return []xdr.Asset{op.Body.MustChangeTrustOp().Line}
+	case xdr.OperationTypeAllowTrust:
+		issuer := tx.Envelope.Tx.SourceAccount
+		if op.SourceAccount != nil {
+			issuer = *op.SourceAccount
+		}
+		return []xdr.Asset{op.Body.MustAllowTrustOp().Asset.ToAsset(issuer)}
+	}
+
+	return nil
+}
+
+// parseFilterAsset decodes an asset given as `native` or `CODE:ISSUER`.
+func parseFilterAsset(raw string) (xdr.Asset, error) {
+	if raw == "native" {
+		return xdr.NewAsset(xdr.AssetTypeAssetTypeNative, nil)
+	}
+
+	parts := strings.SplitN(raw, ":", 2)
+	if len(parts) != 2 {
+		return xdr.Asset{}, errors.New("must be native or of the form CODE:ISSUER")
+	}
+
+	var issuer xdr.AccountId
+	err := issuer.SetAddress(parts[1])
+	if err != nil {
+		return xdr.Asset{}, err
+	}
+
+	code := parts[0]
+	for _, c := range code {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			return xdr.Asset{}, errors.New("asset code must be alphanumeric")
+		}
+	}
+
+	switch {
+	case len(code) >= 1 && len(code) <= 4:
+		a := xdr.AssetAlphaNum4{Issuer: issuer}
+		copy(a.AssetCode[:], []byte(code))
+		return xdr.NewAsset(xdr.AssetTypeAssetTypeCreditAlphanum4, a)
+	case len(code) >= 5 && len(code) <= 12:
+		a := xdr.AssetAlphaNum12{Issuer: issuer}
+		copy(a.AssetCode[:], []byte(code))
+		return xdr.NewAsset(xdr.AssetTypeAssetTypeCreditAlphanum12, a)
+	default:
+		return xdr.Asset{}, errors.New("asset code length is invalid")
+	}
+}
@@ -0,0 +1,135 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/xdr"
+)
+
+func TestNewFilter(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	address := "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2"
+
+	f, err := NewFilter(
+		[]string{address},
+		[]string{"native", "USD:" + address, "SCOTTCOIN:" + address},
+		[]string{"payment", "path_payment"},
+	)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(map[string]bool{address: true}, f.Accounts)
+	tt.Assert.Equal(map[string]bool{
+		"native":                                 true,
+		"credit_alphanum4/USD/" + address:        true,
+		"credit_alphanum12/SCOTTCOIN/" + address: true,
+	}, f.Assets)
+	tt.Assert.Equal(map[xdr.OperationType]bool{
+		xdr.OperationTypePayment:     true,
+		xdr.OperationTypePathPayment: true,
+	}, f.OperationTypes)
+
+	_, err = NewFilter([]string{"GBAD"}, nil, nil)
+	tt.Assert.Error(err)
+
+	_, err = NewFilter(nil, []string{"USD"}, nil)
+	tt.Assert.Error(err)
+
+	_, err = NewFilter(nil, []string{"TOOLONGASSETCODE:" + address}, nil)
+	tt.Assert.Error(err)
+
+	_, err = NewFilter(nil, []string{"US-D:" + address}, nil)
+	tt.Assert.Error(err)
+
+	_, err = NewFilter(nil, nil, []string{"not_an_operation"})
+	tt.Assert.Error(err)
+
+	// a nil filter matches every transaction
+	var nilFilter *Filter
+	match, err := nilFilter.MatchTransaction(nil, nil)
+	tt.Require.NoError(err)
+	tt.Assert.True(match)
+}
+
+func TestFilteredIngestion(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+
+	address := "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2"
+
+	sys := sys(tt)
+	filter, err := NewFilter([]string{address}, nil, []string{"payment"})
+	tt.Require.NoError(err)
+	sys.Filter = filter
+
+	s := sys.Tick()
+	tt.Require.NoError(s.Err)
+	tt.Assert.Equal(57, s.Ingested)
+
+	var ledgers, coreTxs, txs, unrelated, withoutPayment int
+	tt.Require.NoError(tt.HorizonSession().GetRaw(&ledgers, `SELECT COUNT(*) FROM history_ledgers`))
+	tt.Require.NoError(tt.CoreSession().GetRaw(&coreTxs, `SELECT COUNT(*) FROM txhistory`))
+	tt.Require.NoError(tt.HorizonSession().GetRaw(&txs, `SELECT COUNT(*) FROM history_transactions`))
+
+	// every ledger is recorded, but only the transactions matching the filter
+	tt.Assert.Equal(57, ledgers)
+	tt.Assert.NotZero(txs)
+	tt.Assert.True(txs < coreTxs)
+
+	tt.Require.NoError(tt.HorizonSession().GetRaw(&unrelated, `
+		SELECT COUNT(*)
+		FROM history_transactions ht
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM history_transaction_participants htp
+			JOIN history_accounts ha ON ha.id = htp.history_account_id
+			WHERE htp.history_transaction_id = ht.id
+			AND ha.address = ?
+		)`, address))
+	tt.Assert.Zero(unrelated)
+
+	tt.Require.NoError(tt.HorizonSession().GetRaw(&withoutPayment, `
+		SELECT COUNT(*)
+		FROM history_transactions ht
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM history_operations hop
+			WHERE hop.transaction_id = ht.id
+			AND hop.type = ?
+		)`, xdr.OperationTypePayment))
+	tt.Assert.Zero(withoutPayment)
+
+	// only the matching operations of a matching transaction are written, with
+	// the effects and participation of the filtered accounts
+	var otherOps, otherEffects, otherParticipants int
+	tt.Require.NoError(tt.HorizonSession().GetRaw(&otherOps, `
+		SELECT COUNT(*) FROM history_operations WHERE type <> ?`,
+		xdr.OperationTypePayment))
+	tt.Assert.Zero(otherOps)
+
+	tt.Require.NoError(tt.HorizonSession().GetRaw(&otherEffects, `
+		SELECT COUNT(*)
+		FROM history_effects heff
+		JOIN history_accounts ha ON ha.id = heff.history_account_id
+		WHERE ha.address <> ?`, address))
+	tt.Assert.Zero(otherEffects)
+
+	tt.Require.NoError(tt.HorizonSession().GetRaw(&otherParticipants, `
+		SELECT COUNT(*)
+		FROM history_operation_participants hopp
+		JOIN history_accounts ha ON ha.id = hopp.history_account_id
+		WHERE ha.address <> ?`, address))
+	tt.Assert.Zero(otherParticipants)
+
+	// asset stats are updated for the assets of excluded transactions too
+	var filteredStats, stats int
+	tt.Require.NoError(tt.HorizonSession().GetRaw(&filteredStats, `SELECT COUNT(*) FROM asset_stats`))
+
+	sys.Filter = nil
+	_, err = sys.ReingestAll()
+	tt.Require.NoError(err)
+	tt.Require.NoError(tt.HorizonSession().GetRaw(&stats, `SELECT COUNT(*) FROM asset_stats`))
+	tt.Assert.NotZero(stats)
+	tt.Assert.Equal(stats, filteredStats)
+}
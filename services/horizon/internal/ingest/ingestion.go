@@ -12,6 +12,8 @@ import (
 	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/db2/sqx"
+	"github.com/stellar/go/services/horizon/internal/log"
+	"github.com/stellar/go/services/horizon/internal/toid"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/xdr"
 )
@@ -50,14 +52,53 @@ func (ingest *Ingestion) Clear(start int64, end int64) error {
 	if err != nil {
 		return err
 	}
+
+	// the trade aggregation buckets of the cleared trades are recomputed from
+	// the trades that remain in them.
+	q := history.Q{Session: ingest.DB}
+	var buckets []history.TradeBucket
+	err = q.TradeBuckets(&buckets, start, end)
+	if err != nil {
+		return err
+	}
 	err = clear(start, end, "history_trades", "history_operation_id")
 	if err != nil {
 		return err
 	}
+	err = q.RefreshTradeAggregationBuckets(buckets)
+	if err != nil {
+		return err
+	}
+	err = clear(start, end, "history_offer_events", "history_operation_id")
+	if err != nil {
+		return err
+	}
 	err = clear(start, end, "asset_stats", "id")
 	if err != nil {
 		return err
 	}
+	err = clear(start, end, "history_account_creations", "history_operation_id")
+	if err != nil {
+		return err
+	}
+
+	// merges are recorded against the creations they end, which may precede
+	// the cleared range.
+	err = q.ForgetAccountMerges(start, end)
+	if err != nil {
+		return err
+	}
+
+	// balance snapshots are keyed by ledger, rather than by id.
+	err = clear(
+		int64(toid.Parse(start).LedgerSequence),
+		int64(toid.Parse(end).LedgerSequence),
+		"history_balance_snapshots",
+		"ledger_sequence",
+	)
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -95,16 +136,18 @@ func (ingest *Ingestion) Flush() error {
 	return ingest.Start()
 }
 
-// Ledger adds a ledger to the current ingestion
+// Ledger adds a ledger to the current ingestion, tagged with the importer
+// version `version`.
 func (ingest *Ingestion) Ledger(
 	id int64,
 	header *core.LedgerHeader,
 	txs int,
 	ops int,
+	version int,
 ) error {
 
 	sql := ingest.ledgers.Values(
-		CurrentVersion,
+		version,
 		id,
 		header.Sequence,
 		header.LedgerHash,
@@ -127,6 +170,17 @@ func (ingest *Ingestion) Ledger(
 		return err
 	}
 
+	event := LedgerEvent{
+		ID:               id,
+		Sequence:         int32(header.Sequence),
+		Hash:             header.LedgerHash,
+		PrevHash:         header.PrevHash,
+		ClosedAt:         time.Unix(header.CloseTime, 0).UTC(),
+		TransactionCount: txs,
+		OperationCount:   ops,
+	}
+	ingest.emit(func(p Processor) error { return p.ProcessLedger(event) })
+
 	return nil
 }
 
@@ -152,6 +206,16 @@ func (ingest *Ingestion) Operation(
 		return err
 	}
 
+	event := OperationEvent{
+		ID:               id,
+		TransactionID:    txid,
+		ApplicationOrder: order,
+		SourceAccount:    source.Address(),
+		Type:             typ,
+		Details:          details,
+	}
+	ingest.emit(func(p Processor) error { return p.ProcessOperation(event) })
+
 	return nil
 }
 
@@ -178,8 +242,10 @@ func (ingest *Ingestion) OperationParticipants(op int64, aids []xdr.AccountId) e
 	return nil
 }
 
-// Rollback aborts this ingestions transaction
+// Rollback aborts this ingestions transaction, discarding the events recorded
+// since it began.
 func (ingest *Ingestion) Rollback() (err error) {
+	ingest.pending = nil
 	err = ingest.DB.Rollback()
 	return
 }
@@ -300,6 +366,19 @@ func (ingest *Ingestion) Transaction(
 		return err
 	}
 
+	event := TransactionEvent{
+		ID:               id,
+		Hash:             tx.TransactionHash,
+		Ledger:           tx.LedgerSequence,
+		ApplicationOrder: tx.Index,
+		Account:          tx.SourceAddress(),
+		EnvelopeXDR:      tx.EnvelopeXDR(),
+		ResultXDR:        tx.ResultXDR(),
+		ResultMetaXDR:    tx.ResultMetaXDR(),
+		FeeMetaXDR:       fee.ChangesXDR(),
+	}
+	ingest.emit(func(p Processor) error { return p.ProcessTransaction(event) })
+
 	return nil
 }
 
@@ -427,9 +506,55 @@ func (ingest *Ingestion) commit() error {
 		return err
 	}
 
+	ingest.dispatch()
 	return nil
 }
 
+// dispatch delivers the events recorded since the last commit to every
+// processor.  The data is already committed, so a processor that fails does not
+// fail the ingestion, nor stop it receiving the remaining events; the events it
+// failed to receive are logged and dropped.
+func (ingest *Ingestion) dispatch() {
+	pending := ingest.pending
+	ingest.pending = nil
+
+	for _, p := range ingest.Processors {
+		var (
+			failed  int
+			lastErr error
+		)
+
+		for _, deliver := range pending {
+			err := deliver(p)
+			if err != nil {
+				failed++
+				lastErr = err
+			}
+		}
+
+		if failed > 0 {
+			log.
+				WithField("err", lastErr).
+				WithField("dropped", failed).
+				Error("ingest: processor failed")
+		}
+
+		if q, ok := p.(*ProcessorQueue); ok {
+			q.Flush()
+		}
+	}
+}
+
+// emit records an event to be delivered to the processors of this ingestion
+// once its transaction commits.
+func (ingest *Ingestion) emit(deliver func(Processor) error) {
+	if len(ingest.Processors) == 0 {
+		return
+	}
+
+	ingest.pending = append(ingest.pending, deliver)
+}
+
 func (ingest *Ingestion) formatTimeBounds(bounds *xdr.TimeBounds) interface{} {
 	if bounds == nil {
 		return nil
@@ -4,10 +4,12 @@ import (
 	"testing"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/test"
 	testDB "github.com/stellar/go/services/horizon/internal/test/db"
+	"github.com/stellar/go/services/horizon/internal/toid"
 	"github.com/stellar/go/support/db"
 	"github.com/stellar/go/xdr"
 	"github.com/stretchr/testify/assert"
@@ -142,6 +144,58 @@ func TestAssetStatsIngest(t *testing.T) {
 	}, assetStats[2])
 }
 
+func TestAccountCreationIngest(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("account_merge")
+	defer tt.Finish()
+	s := ingest(tt)
+	q := history.Q{Session: s.Ingestion.DB}
+
+	var merged history.AccountCreation
+	err := q.AccountCreationByAddress(&merged, "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU")
+	tt.Require.NoError(err)
+	tt.Assert.Equal("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H", merged.CreatedBy)
+	tt.Assert.Equal("GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2", merged.MergedInto.String)
+
+	var created history.AccountCreation
+	err = q.AccountCreationByAddress(&created, "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2")
+	tt.Require.NoError(err)
+	tt.Assert.Equal("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H", created.CreatedBy)
+	tt.Assert.False(created.MergedInto.Valid)
+}
+
+func TestBalanceSnapshotIngest(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("account_merge")
+	defer tt.Finish()
+	s := ingest(tt)
+	tt.Require.NoError(s.Err)
+	q := history.Q{Session: s.Ingestion.DB}
+
+	var merged history.Account
+	err := q.AccountByAddress(&merged, "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU")
+	tt.Require.NoError(err)
+
+	native, err := xdr.NewAsset(xdr.AssetTypeAssetTypeNative, nil)
+	tt.Require.NoError(err)
+
+	var snapshots []history.BalanceSnapshot
+	err = q.BalanceSnapshots().
+		ForAccount(merged.ID).
+		ForAsset(native).
+		Page(db2.MustPageQuery("", "asc", 10)).
+		Select(&snapshots)
+	tt.Require.NoError(err)
+	tt.Require.True(len(snapshots) >= 2)
+
+	// the starting balance is recorded when the account is created
+	created := snapshots[0]
+	tt.Assert.Equal(created.Balance, created.Change)
+
+	// and a zero balance once it is merged away
+	last := snapshots[len(snapshots)-1]
+	tt.Assert.Equal(xdr.Int64(0), last.Balance)
+	tt.Assert.Equal(-snapshots[len(snapshots)-2].Balance, last.Change)
+}
+
 func TestTradeIngestTimestamp(t *testing.T) {
 	//ingest trade scenario and verify that the trade timestamp
 	//matches the appropriate ledger's timestamp
@@ -160,3 +214,149 @@ func TestTradeIngestTimestamp(t *testing.T) {
 
 	tt.Require.Equal(trades[len(trades)-1].LedgerCloseTime, ledgers[len(ledgers)-1].ClosedAt)
 }
+
+func TestTradeAggregationsIngest(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("trades")
+	defer tt.Finish()
+	s := ingest(tt)
+	q := history.Q{Session: s.Ingestion.DB}
+
+	var trades int64
+	err := q.GetRaw(&trades, `SELECT COUNT(*) FROM history_trades`)
+	tt.Require.NoError(err)
+	tt.Require.NotZero(trades)
+
+	// every trade is counted once at each rolled up resolution
+	for _, resolution := range history.TradeAggregationResolutions {
+		var counted int64
+		err = q.GetRaw(&counted, `
+			SELECT COALESCE(SUM(count), 0) FROM history_trade_aggregations
+			WHERE resolution = ?`, resolution)
+		tt.Require.NoError(err)
+		tt.Assert.Equal(trades, counted, "resolution %d", resolution)
+	}
+}
+
+func TestTradeAggregationsClear(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("trades")
+	defer tt.Finish()
+	s := ingest(tt)
+	q := history.Q{Session: s.Ingestion.DB}
+
+	// clear the ledger of the last trade, leaving the earlier trades in place
+	var last int64
+	err := q.GetRaw(&last, `SELECT MAX(history_operation_id) FROM history_trades`)
+	tt.Require.NoError(err)
+	seq := toid.Parse(last).LedgerSequence
+
+	ingestion := &Ingestion{DB: tt.HorizonSession()}
+	tt.Require.NoError(ingestion.Start())
+	tt.Require.NoError(ingestion.Clear(
+		toid.New(seq, 0, 0).ToInt64(),
+		toid.New(seq+1, 0, 0).ToInt64(),
+	))
+	tt.Require.NoError(ingestion.Close())
+
+	var trades int64
+	err = q.GetRaw(&trades, `SELECT COUNT(*) FROM history_trades`)
+	tt.Require.NoError(err)
+
+	// the buckets no longer count the cleared trades
+	for _, resolution := range history.TradeAggregationResolutions {
+		var counted int64
+		err = q.GetRaw(&counted, `
+			SELECT COALESCE(SUM(count), 0) FROM history_trade_aggregations
+			WHERE resolution = ?`, resolution)
+		tt.Require.NoError(err)
+		tt.Assert.Equal(trades, counted, "resolution %d", resolution)
+	}
+}
+
+func TestOfferEventIngest(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("trades")
+	defer tt.Finish()
+	s := ingest(tt)
+	q := history.Q{Session: s.Ingestion.DB}
+
+	// offer 1 was created by bartek and then partially filled by scott
+	var events []history.OfferEvent
+	pq := db2.MustPageQuery("", "asc", 10)
+	err := q.OfferEvents().ForOffer(1).Page(pq).Select(&events)
+	tt.Require.NoError(err)
+	tt.Require.Len(events, 2)
+
+	tt.Assert.Equal(history.OfferEventCreated, events[0].Type)
+	tt.Assert.Equal(xdr.Int64(1000000000), events[0].Amount)
+	tt.Assert.Equal("GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2", events[0].SellerAccount)
+	tt.Assert.Equal("EUR", events[0].SellingAssetCode)
+	tt.Assert.Equal("USD", events[0].BuyingAssetCode)
+
+	tt.Assert.Equal(history.OfferEventPartiallyFilled, events[1].Type)
+	tt.Assert.Equal(xdr.Int64(500000000), events[1].Amount)
+
+	// the latest event reflects the offer's current state
+	var latest history.OfferEvent
+	err = q.LatestOfferEvent(&latest, 1)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(events[1], latest)
+
+	// offer 4 has only been placed
+	err = q.LatestOfferEvent(&latest, 4)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(history.OfferEventCreated, latest.Type)
+}
+
+func TestOrderBookSnapshotIngest(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("trades")
+	defer tt.Finish()
+	s := ingest(tt)
+	tt.Require.NoError(s.Err)
+	q := history.Q{Session: s.Ingestion.DB}
+
+	var eurIssuer, usdIssuer xdr.AccountId
+	tt.Require.NoError(eurIssuer.SetAddress("GCQPYGH4K57XBDENKKX55KDTWOTK5WDWRQOH2LHEDX3EKVIQRLMESGBG"))
+	tt.Require.NoError(usdIssuer.SetAddress("GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4"))
+
+	var eur, usd xdr.Asset
+	tt.Require.NoError(eur.SetCredit("EUR", eurIssuer))
+	tt.Require.NoError(usd.SetCredit("USD", usdIssuer))
+
+	eurID, err := q.GetAssetID(eur)
+	tt.Require.NoError(err)
+	usdID, err := q.GetAssetID(usd)
+	tt.Require.NoError(err)
+
+	// the offers selling EUR for USD, best price first
+	var snapshot history.OrderBookSnapshot
+	err = q.OrderBookSnapshotAt(&snapshot, eurID, usdID, 6)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int32(6), snapshot.LedgerSequence)
+
+	levels, err := snapshot.Levels()
+	tt.Require.NoError(err)
+	tt.Assert.Equal([]history.OrderBookLevel{
+		{Pricen: 1, Priced: 1, Amount: 500000000},
+		{Pricen: 1111111111, Priced: 1000000000, Amount: 900000000},
+		{Pricen: 5, Priced: 4, Amount: 800000000},
+	}, levels)
+
+	// the opposite side of the book is empty, but was snapshotted too
+	err = q.OrderBookSnapshotAt(&snapshot, usdID, eurID, 6)
+	tt.Require.NoError(err)
+	levels, err = snapshot.Levels()
+	tt.Require.NoError(err)
+	tt.Assert.Len(levels, 0)
+
+	// no snapshot precedes the session
+	err = q.OrderBookSnapshotAt(&snapshot, eurID, usdID, 5)
+	tt.Assert.True(q.NoRows(err))
+
+	// snapshotting the books again at the same ledger records nothing new
+	s.Cursor.OrderBooksModified.UpdateOrderBookSnapshots(s)
+	tt.Require.NoError(s.Err)
+
+	var count int
+	err = q.GetRaw(&count, `SELECT COUNT(*) FROM history_order_book_snapshots WHERE ledger_sequence = 6`)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(2, count)
+}
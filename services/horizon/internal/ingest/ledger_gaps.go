@@ -0,0 +1,213 @@
+package ingest
+
+import (
+	"time"
+
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	herr "github.com/stellar/go/services/horizon/internal/errors"
+	"github.com/stellar/go/services/horizon/internal/log"
+	"github.com/stellar/go/support/errors"
+)
+
+// gapHealBatchSize is the most ledgers reingested by each session that heals a
+// gap.
+const gapHealBatchSize = 100
+
+// Gaps returns the report of the most recent search for gaps in the history
+// database.
+func (i *System) Gaps() GapReport {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	return i.gaps
+}
+
+// CheckGaps searches the history database for gaps, records the result as the
+// system's latest report and updates the gap metrics.
+func (i *System) CheckGaps() (GapReport, error) {
+	q := history.Q{Session: i.HorizonDB.Clone()}
+
+	report := GapReport{CheckedAt: time.Now().UTC()}
+	err := q.LedgerGaps(&report.Gaps, ArchiveVersion)
+	if err != nil {
+		return GapReport{}, errors.Wrap(err, "failed to load ledger gaps")
+	}
+
+	var missing int64
+	for _, gap := range report.Gaps {
+		if gap.Missing {
+			missing += int64(gap.Len())
+		}
+	}
+
+	i.Metrics.GapsGauge.Update(int64(len(report.Gaps)))
+	i.Metrics.MissingLedgersGauge.Update(missing)
+
+	i.lock.Lock()
+	i.gaps = report
+	i.lock.Unlock()
+
+	return report, nil
+}
+
+// HealGaps reingests the gaps of `report` whose ledgers are all still present
+// in the stellar-core database, oldest first, until `limit` ledgers have been
+// reingested, and returns the number of ledgers reingested.  A gap that does
+// not fit within the limit is healed from its start.  Ledgers are always loaded
+// from stellar-core, even when a history archive is configured, since an
+// archive lacks the meta needed to rebuild them in full; this also completes the
+// ledgers that were ingested from an archive.  Gaps that cannot be reingested
+// are logged and skipped.
+func (i *System) HealGaps(report GapReport, limit int32) (int32, error) {
+	cq := core.Q{Session: i.CoreDB.Clone()}
+	var healed int32
+
+	for _, gap := range report.Gaps {
+		if healed >= limit {
+			break
+		}
+
+		var present int32
+		err := cq.LedgerHeaderCount(&present, gap.Start, gap.End)
+		if err != nil {
+			return healed, errors.Wrap(err, "failed to count core ledgers")
+		}
+
+		if present != gap.Len() {
+			log.
+				WithField("start", gap.Start).
+				WithField("end", gap.End).
+				Warn("ingest: gap not in stellar-core, skipping")
+			continue
+		}
+
+		chunk := ledgerChunk{Start: gap.Start, End: gap.End}
+		if remaining := limit - healed; chunk.Len() > remaining {
+			chunk.End = chunk.Start + remaining - 1
+		}
+
+		n, err := i.healGap(chunk)
+		healed += n
+		if err != nil {
+			log.
+				WithField("start", gap.Start).
+				WithField("end", gap.End).
+				WithField("err", err).
+				Error("ingest: failed to reingest gap")
+			continue
+		}
+
+		if chunk.End == gap.End {
+			i.Metrics.HealedGapsCounter.Inc(1)
+		}
+	}
+
+	return healed, nil
+}
+
+// healGap reingests the ledgers of `gap` from stellar-core in batches of
+// gapHealBatchSize ledgers, each committed atomically, so that healing a large
+// gap neither holds a long transaction open nor loses all of its progress to
+// one failure.  A batch that fails is retried, as it may conflict with the
+// ledgers being ingested at the same time.  Returns the number of ledgers
+// reingested before the first batch that could not be.
+func (i *System) healGap(gap ledgerChunk) (int32, error) {
+	var healed int32
+
+	for _, batch := range chunkRange(gap.Start, gap.End, gapHealBatchSize) {
+		var err error
+		for attempt := 0; attempt < chunkAttempts; attempt++ {
+			// the gap is behind the ledger reported to stellar-core, so the cursor
+			// is left where it is.
+			is := NewSession(i)
+			is.Cursor = NewCursor(batch.Start, batch.End, i)
+			is.Cursor.DB = i.CoreDB.Clone()
+			is.ClearExisting = true
+			is.SkipCursorUpdate = true
+			is.Atomic = true
+
+			is.Run()
+			err = is.Err
+			if err == nil {
+				break
+			}
+		}
+
+		if err != nil {
+			return healed, errors.Wrapf(err, "failed to reingest ledgers %d-%d", batch.Start, batch.End)
+		}
+
+		healed += batch.Len()
+	}
+
+	return healed, nil
+}
+
+// checkGapsIfDue starts a search for gaps, followed by the healing of those it
+// finds, when GapCheckInterval has passed since the last search started and
+// that search has finished.  The search runs on a goroutine of its own so that
+// it does not hold up Tick; gaps always lie behind the latest ledger in the
+// history database, so healing them does not overlap with regular ingestion.
+func (i *System) checkGapsIfDue() {
+	if i.GapCheckInterval == 0 {
+		return
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.checkingGaps || time.Now().Before(i.nextGapCheck) {
+		return
+	}
+	i.checkingGaps = true
+	i.nextGapCheck = time.Now().Add(i.GapCheckInterval)
+
+	go i.checkAndHealGaps()
+}
+
+// checkAndHealGaps searches for gaps and, unless GapHealLimit is 0, heals up to
+// GapHealLimit of their ledgers.
+func (i *System) checkAndHealGaps() {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err := herr.FromPanic(rec)
+			log.Errorf("gap check panicked: %s", err)
+			errors.ReportToSentry(err, nil)
+		}
+
+		i.lock.Lock()
+		i.checkingGaps = false
+		i.lock.Unlock()
+	}()
+
+	report, err := i.CheckGaps()
+	if err != nil {
+		log.Errorf("gap check failed: %s", err)
+		return
+	}
+
+	if len(report.Gaps) == 0 {
+		return
+	}
+
+	log.WithField("gaps", len(report.Gaps)).Warn("ingest: ledger gaps detected")
+
+	if i.GapHealLimit == 0 {
+		return
+	}
+
+	healed, err := i.HealGaps(report, i.GapHealLimit)
+	if err != nil {
+		log.Errorf("gap healing failed: %s", err)
+	}
+
+	if healed == 0 {
+		return
+	}
+
+	_, err = i.CheckGaps()
+	if err != nil {
+		log.Errorf("gap check failed: %s", err)
+	}
+}
@@ -0,0 +1,110 @@
+package ingest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/test"
+)
+
+func TestLedgerGaps(t *testing.T) {
+	tt := test.Start(t).Scenario("kahuna")
+	defer tt.Finish()
+	sys := sys(tt)
+
+	tt.Assert.True(sys.Gaps().CheckedAt.IsZero())
+
+	report, err := sys.CheckGaps()
+	tt.Require.NoError(err)
+	tt.Assert.Empty(report.Gaps)
+	tt.Assert.False(sys.Gaps().CheckedAt.IsZero())
+
+	// break the history db: ledgers 10-12 are missing, 20 is missing from both
+	// history and core, and 30 does not follow the hash of 29.
+	_, err = tt.HorizonSession().ExecRaw(
+		`DELETE FROM history_ledgers WHERE sequence IN (10, 11, 12, 20)`,
+	)
+	tt.Require.NoError(err)
+	_, err = tt.CoreSession().ExecRaw(`DELETE FROM ledgerheaders WHERE ledgerseq = 20`)
+	tt.Require.NoError(err)
+	_, err = tt.HorizonSession().ExecRaw(
+		`UPDATE history_ledgers SET previous_ledger_hash = ? WHERE sequence = 30`,
+		"0000000000000000000000000000000000000000000000000000000000000000",
+	)
+	tt.Require.NoError(err)
+
+	report, err = sys.CheckGaps()
+	tt.Require.NoError(err)
+	tt.Assert.Equal([]history.LedgerGap{
+		{Start: 10, End: 12, Missing: true},
+		{Start: 20, End: 20, Missing: true},
+		{Start: 29, End: 30, Missing: false},
+	}, report.Gaps)
+	tt.Assert.Equal(int64(3), sys.Metrics.GapsGauge.Value())
+	tt.Assert.Equal(int64(4), sys.Metrics.MissingLedgersGauge.Value())
+
+	// healing stops once the limit is reached, part way through a gap
+	healed, err := sys.HealGaps(report, 2)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int32(2), healed)
+	tt.Assert.Equal(int64(0), sys.Metrics.HealedGapsCounter.Count())
+
+	report, err = sys.CheckGaps()
+	tt.Require.NoError(err)
+	tt.Assert.Equal([]history.LedgerGap{
+		{Start: 12, End: 12, Missing: true},
+		{Start: 20, End: 20, Missing: true},
+		{Start: 29, End: 30, Missing: false},
+	}, report.Gaps)
+
+	// the gap whose ledger is gone from core is skipped
+	healed, err = sys.HealGaps(report, 100)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int32(3), healed)
+	tt.Assert.Equal(int64(2), sys.Metrics.HealedGapsCounter.Count())
+
+	report, err = sys.CheckGaps()
+	tt.Require.NoError(err)
+	tt.Assert.Equal([]history.LedgerGap{
+		{Start: 20, End: 20, Missing: true},
+	}, report.Gaps)
+	tt.Assert.Equal(int64(1), sys.Metrics.MissingLedgersGauge.Value())
+}
+
+func TestCheckGapsIfDue(t *testing.T) {
+	tt := test.Start(t).Scenario("kahuna")
+	defer tt.Finish()
+	sys := sys(tt)
+
+	_, err := tt.HorizonSession().ExecRaw(
+		`DELETE FROM history_ledgers WHERE sequence IN (10, 11, 12)`,
+	)
+	tt.Require.NoError(err)
+
+	checked := func() bool {
+		sys.lock.Lock()
+		defer sys.lock.Unlock()
+		return !sys.checkingGaps && !sys.gaps.CheckedAt.IsZero()
+	}
+
+	// disabled by default
+	sys.checkGapsIfDue()
+	tt.Assert.False(checked())
+
+	// gaps are found, but not healed, unless a heal limit is set
+	sys.GapCheckInterval = time.Hour
+	sys.checkGapsIfDue()
+	waitFor(tt, checked)
+	tt.Assert.Len(sys.Gaps().Gaps, 1)
+
+	sys.lock.Lock()
+	sys.gaps = GapReport{}
+	sys.nextGapCheck = time.Time{}
+	sys.lock.Unlock()
+
+	sys.GapHealLimit = 100
+	sys.checkGapsIfDue()
+	waitFor(tt, checked)
+	tt.Assert.Empty(sys.Gaps().Gaps)
+}
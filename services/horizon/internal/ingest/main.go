@@ -4,11 +4,16 @@
 package ingest
 
 import (
+	"net/http"
+	"os"
 	"sync"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	metrics "github.com/rcrowley/go-metrics"
+	"github.com/stellar/go/historyarchive"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/support/db"
 	"github.com/stellar/go/xdr"
 )
@@ -22,7 +27,18 @@ const (
 	// Scripts, that have yet to be ported to this codebase can then be leveraged
 	// to re-ingest old data with the new algorithm, providing a seamless
 	// transition when the ingested data's structure changes.
-	CurrentVersion = 11
+	CurrentVersion = 12
+
+	// ArchiveVersion is the version used to tag ledgers ingested from a history
+	// archive.  Archives lack the meta of transactions, so the data derived from
+	// it is missing for these ledgers.  Being older than CurrentVersion, they are
+	// rebuilt from stellar-core by ReingestOutdated, and reported as incomplete
+	// by the search for gaps so that they are healed once stellar-core has them.
+	ArchiveVersion = 0
+
+	// OrderBookSnapshotDepth is the number of price levels recorded for each
+	// side of an order book when it is snapshotted.
+	OrderBookSnapshotDepth = 20
 )
 
 // Cursor iterates through a stellar core database's ledgers
@@ -35,9 +51,12 @@ type Cursor struct {
 	LastLedger int32
 	// DB is the stellar-core db that data is ingested from.
 	DB *db.Session
+	// Source, when set, is where ledgers are loaded from instead of DB.
+	Source LedgerSource
 
-	Metrics        *IngesterMetrics
-	AssetsModified AssetsModified
+	Metrics            *IngesterMetrics
+	AssetsModified     AssetsModified
+	OrderBooksModified OrderBooksModified
 
 	// Err is the error that caused this iteration to fail, if any.
 	Err error
@@ -54,9 +73,11 @@ type Cursor struct {
 type EffectIngestion struct {
 	Dest        *Ingestion
 	OperationID int64
-	err         error
-	added       int
-	parent      *Ingestion
+	// Filter, when set, restricts the effects written to those of its accounts.
+	Filter *Filter
+	err    error
+	added  int
+	parent *Ingestion
 }
 
 // LedgerBundle represents a single ledger's worth of novelty created by one
@@ -66,6 +87,35 @@ type LedgerBundle struct {
 	Header          core.LedgerHeader
 	TransactionFees []core.TransactionFee
 	Transactions    []core.Transaction
+
+	// MetaUnavailable is true when the bundle was loaded from a source that does
+	// not record the meta of transactions, such as a history archive.  The meta
+	// of its transactions is empty, and effects derived from meta are skipped.
+	MetaUnavailable bool
+}
+
+// LedgerSource loads the ledgers a Cursor iterates through.
+type LedgerSource interface {
+	// LoadLedger fills in the records of `lb` for the ledger at lb.Sequence.
+	LoadLedger(lb *LedgerBundle) error
+}
+
+// ArchiveLedgerSource is a LedgerSource that reads ledgers from the checkpoint
+// files of a history archive, allowing history to be ingested without a
+// full-history stellar-core database.  Archives record the headers,
+// transaction sets and results of ledgers but not the meta of transactions.
+type ArchiveLedgerSource struct {
+	// Archive is the history archive that ledgers are read from.
+	Archive *historyarchive.Archive
+
+	// Network is the passphrase for the network whose history is archived,
+	// used to match the transactions of a ledger with their results.
+	Network string
+
+	checkpoint   uint32
+	headers      map[uint32]xdr.LedgerHeaderHistoryEntry
+	transactions map[uint32]xdr.TransactionHistoryEntry
+	results      map[uint32]xdr.TransactionHistoryResultEntry
 }
 
 // System represents the data ingestion subsystem of horizon.
@@ -96,8 +146,69 @@ type System struct {
 	// ledger.  0 represents "all ledgers".
 	HistoryRetentionCount uint
 
+	// HistoryArchive, when set, is where reingestion loads ledgers from instead
+	// of the stellar-core database.
+	HistoryArchive *historyarchive.Archive
+
+	// Processors receive the data of every ledger ingested by this system's
+	// sessions once it has been committed.
+	Processors []Processor
+
+	// Filter, when set, restricts the transactions written to the history
+	// database by this system's sessions.
+	Filter *Filter
+
+	// GapCheckInterval is how often Tick starts a search of the history
+	// database for gaps, which runs on a goroutine of its own.  0, the default,
+	// disables the search.
+	GapCheckInterval time.Duration
+
+	// GapHealLimit is the most ledgers reingested, from the gaps that the
+	// stellar-core database still has the ledgers for, after each search for
+	// gaps.  0, the default, disables healing.
+	GapHealLimit int32
+
 	lock    sync.Mutex
 	current *Session
+
+	gaps         GapReport
+	checkingGaps bool
+	nextGapCheck time.Time
+}
+
+// GapReport describes the gaps found in the history database by the most
+// recent search.
+type GapReport struct {
+	// CheckedAt is when the search ran, zero if no search has completed.
+	CheckedAt time.Time
+
+	// Gaps are the gaps found, in ascending order.
+	Gaps []history.LedgerGap
+}
+
+// Filter restricts the transactions written to the history database to those
+// relevant to a set of accounts, assets or operation types, for horizons that
+// only serve part of the network.  Each criterion that is set must be met for
+// a transaction to be written, and again for each of its operations, along
+// with their trades; the operation type and asset criteria must be met by the
+// same operation.  Only the effects and participants of the Accounts are
+// written.  Ledgers are always written, so that history remains contiguous.
+// Balance snapshots are recorded for the balances that the Accounts hold in
+// the Assets, whichever transaction changed them.
+type Filter struct {
+	// Accounts, keyed by address, restricts ingestion to transactions that one
+	// of them participates in.
+	Accounts map[string]bool
+
+	// Assets, keyed by their xdr.Asset string, restricts ingestion to
+	// transactions with an operation that moves, trades, or changes or allows
+	// trust in one of them.  Accounts are created, merged and paid inflation
+	// in the native asset.
+	Assets map[string]bool
+
+	// OperationTypes restricts ingestion to transactions with an operation of
+	// one of the types.
+	OperationTypes map[xdr.OperationType]bool
 }
 
 // IngesterMetrics tracks all the metrics for the ingestion subsystem
@@ -105,11 +216,22 @@ type IngesterMetrics struct {
 	ClearLedgerTimer  metrics.Timer
 	IngestLedgerTimer metrics.Timer
 	LoadLedgerTimer   metrics.Timer
+
+	GapsGauge           metrics.Gauge
+	MissingLedgersGauge metrics.Gauge
+	HealedGapsCounter   metrics.Counter
+	DroppedEventsMeter  metrics.Meter
+
+	DroppedOrderBookSnapshotsMeter metrics.Meter
 }
 
 // AssetsModified tracks all the assets modified during a cycle of ingestion
 type AssetsModified map[string]xdr.Asset
 
+// OrderBooksModified tracks the order books whose offers were modified during a
+// cycle of ingestion, keyed so that both orientations of a pair share an entry.
+type OrderBooksModified map[string][2]xdr.Asset
+
 // Ingestion receives write requests from a Session
 type Ingestion struct {
 	// DB is the sql connection to be used for writing any rows into the horizon
@@ -125,6 +247,168 @@ type Ingestion struct {
 	accounts                 sq.InsertBuilder
 	trades                   sq.InsertBuilder
 	assetStats               sq.InsertBuilder
+
+	// Processors receive the events recorded by this ingestion each time its
+	// transaction is committed.
+	Processors []Processor
+
+	pending []func(Processor) error
+}
+
+// Processor receives the data written by ingestion, allowing other services to
+// react to new ledgers without polling horizon.  Its methods are called once
+// the transaction containing the data has been committed, in the order the
+// data was ingested: each ledger is followed by its transactions, and each
+// transaction by its operations along with their effects and trades.
+//
+// Data is delivered again when its ledgers are reingested, and sessions may run
+// concurrently, so implementations must be safe for concurrent use and should
+// treat the ids of events as idempotency keys.  Methods are called by the
+// ingesting goroutine, so processors that may be slow should be wrapped in a
+// ProcessorQueue.
+type Processor interface {
+	ProcessLedger(LedgerEvent) error
+	ProcessTransaction(TransactionEvent) error
+	ProcessOperation(OperationEvent) error
+	ProcessEffect(EffectEvent) error
+	ProcessTrade(TradeEvent) error
+}
+
+// BatchProcessor is a Processor that can receive the events of a ledger in a
+// single call.  A ProcessorQueue delivers batches to the processors that
+// implement it.
+type BatchProcessor interface {
+	Processor
+	ProcessBatch([]Event) error
+}
+
+// Event is an event delivered to a processor, along with the kind of the
+// event: "ledger", "transaction", "operation", "effect" or "trade".
+type Event struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+
+	// deliver delivers the event to a processor one event at a time.
+	deliver func(Processor) error
+}
+
+// LedgerEvent describes an ingested ledger.
+type LedgerEvent struct {
+	ID               int64     `json:"id"`
+	Sequence         int32     `json:"sequence"`
+	Hash             string    `json:"hash"`
+	PrevHash         string    `json:"prev_hash"`
+	ClosedAt         time.Time `json:"closed_at"`
+	TransactionCount int       `json:"transaction_count"`
+	OperationCount   int       `json:"operation_count"`
+}
+
+// TransactionEvent describes an ingested transaction.
+type TransactionEvent struct {
+	ID               int64  `json:"id"`
+	Hash             string `json:"hash"`
+	Ledger           int32  `json:"ledger"`
+	ApplicationOrder int32  `json:"application_order"`
+	Account          string `json:"source_account"`
+	EnvelopeXDR      string `json:"envelope_xdr"`
+	ResultXDR        string `json:"result_xdr"`
+	ResultMetaXDR    string `json:"result_meta_xdr"`
+	FeeMetaXDR       string `json:"fee_meta_xdr"`
+}
+
+// OperationEvent describes an ingested operation.
+type OperationEvent struct {
+	ID               int64                  `json:"id"`
+	TransactionID    int64                  `json:"transaction_id"`
+	ApplicationOrder int32                  `json:"application_order"`
+	SourceAccount    string                 `json:"source_account"`
+	Type             xdr.OperationType      `json:"type_i"`
+	Details          map[string]interface{} `json:"details"`
+}
+
+// EffectEvent describes an ingested effect.
+type EffectEvent struct {
+	OperationID int64              `json:"operation_id"`
+	Order       int                `json:"order"`
+	Account     string             `json:"account"`
+	Type        history.EffectType `json:"type_i"`
+	Details     interface{}        `json:"details"`
+}
+
+// TradeEvent describes an ingested trade, from the point of view of the seller
+// whose offer was taken.
+type TradeEvent struct {
+	OperationID     int64     `json:"operation_id"`
+	Order           int32     `json:"order"`
+	LedgerCloseTime time.Time `json:"ledger_close_time"`
+	OfferID         int64     `json:"offer_id"`
+	Seller          string    `json:"seller"`
+	Buyer           string    `json:"buyer"`
+	SoldAsset       string    `json:"sold_asset"`
+	SoldAmount      string    `json:"sold_amount"`
+	BoughtAsset     string    `json:"bought_asset"`
+	BoughtAmount    string    `json:"bought_amount"`
+}
+
+// FileProcessor is a Processor that appends each event, as a line of JSON, to a
+// local file.
+type FileProcessor struct {
+	// Path is the path of the file events are appended to.
+	Path string
+
+	lock sync.Mutex
+	file *os.File
+}
+
+// ProcessorQueue is a Processor that buffers events in memory and delivers
+// them to another processor on its own goroutine, so that a slow or unavailable
+// processor does not hold up ingestion.  Events are grouped into a batch per
+// ledger, which is delivered in a single call to processors that implement
+// BatchProcessor.  A delivery that fails is retried with exponential backoff.
+//
+// Delivery is at most once: events are dropped when the buffer is full, when
+// they still fail after MaxAttempts deliveries, or when they are not delivered
+// within CloseTimeout of the queue being closed.  Every drop is logged and
+// counted by Dropped.
+type ProcessorQueue struct {
+	// Processor receives the queued events, in the order they were queued.
+	Processor Processor
+
+	// MaxAttempts is the number of times delivery of a batch is attempted
+	// before it is dropped.
+	MaxAttempts int
+
+	// Backoff is how long to wait before retrying a failed delivery.  It doubles
+	// after each retry of the same batch, up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// CloseTimeout is how long Close waits for the queued batches to be
+	// delivered before dropping them.
+	CloseTimeout time.Duration
+
+	// Dropped counts the events that were dropped.
+	Dropped metrics.Meter
+
+	lock    sync.Mutex
+	batch   []Event
+	closed  bool
+	batches chan []Event
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// WebhookProcessor is a Processor that POSTs each event, as a JSON document, to
+// a url, and each batch of events as a JSON array.  Events are posted before
+// the call returns, so horizon delivers events to webhooks through a
+// ProcessorQueue, which posts the events of each ledger together.
+type WebhookProcessor struct {
+	// URL is the endpoint events are posted to.  Any response other than a 2xx
+	// is treated as a failure to deliver the event.
+	URL string
+
+	// Client is the http client used to post events.
+	Client *http.Client
 }
 
 // Session represents a single attempt at ingesting data into the history
@@ -148,6 +432,19 @@ type Session struct {
 	// stellar-core
 	SkipCursorUpdate bool
 
+	// Atomic causes the session to commit all of the ledgers it ingests in a
+	// single database transaction, rather than committing after each ledger.
+	Atomic bool
+
+	// Filter, when set, restricts the transactions the session writes.
+	Filter *Filter
+
+	// SkipAggregates causes the session to skip updating the asset stats and
+	// trade aggregations derived from the ledgers it ingests.  Sessions that run
+	// concurrently over adjacent ranges set it, so that the aggregates can be
+	// updated once all of them have committed.
+	SkipAggregates bool
+
 	// Metrics is a reference to where the session should record its metric information
 	Metrics *IngesterMetrics
 
@@ -176,17 +473,23 @@ func New(network string, coreURL string, core, horizon *db.Session) *System {
 	i.Metrics.ClearLedgerTimer = metrics.NewTimer()
 	i.Metrics.IngestLedgerTimer = metrics.NewTimer()
 	i.Metrics.LoadLedgerTimer = metrics.NewTimer()
+	i.Metrics.GapsGauge = metrics.NewGauge()
+	i.Metrics.MissingLedgersGauge = metrics.NewGauge()
+	i.Metrics.HealedGapsCounter = metrics.NewCounter()
+	i.Metrics.DroppedEventsMeter = metrics.NewMeter()
+	i.Metrics.DroppedOrderBookSnapshotsMeter = metrics.NewMeter()
 	return i
 }
 
 // NewCursor initializes a new ingestion cursor
 func NewCursor(first, last int32, i *System) *Cursor {
 	return &Cursor{
-		FirstLedger:    first,
-		LastLedger:     last,
-		DB:             i.CoreDB,
-		Metrics:        &i.Metrics,
-		AssetsModified: AssetsModified(make(map[string]xdr.Asset)),
+		FirstLedger:        first,
+		LastLedger:         last,
+		DB:                 i.CoreDB,
+		Metrics:            &i.Metrics,
+		AssetsModified:     AssetsModified(make(map[string]xdr.Asset)),
+		OrderBooksModified: OrderBooksModified(make(map[string][2]xdr.Asset)),
 	}
 }
 
@@ -196,11 +499,13 @@ func NewSession(i *System) *Session {
 
 	return &Session{
 		Ingestion: &Ingestion{
-			DB: hdb,
+			DB:         hdb,
+			Processors: i.Processors,
 		},
 		Network:          i.Network,
 		StellarCoreURL:   i.StellarCoreURL,
 		SkipCursorUpdate: i.SkipCursorUpdate,
+		Filter:           i.Filter,
 		Metrics:          &i.Metrics,
 	}
 }
@@ -0,0 +1,142 @@
+package ingest
+
+import (
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/log"
+	"github.com/stellar/go/xdr"
+)
+
+// add records that the offers selling `selling` for `buying` were modified.
+func (obm OrderBooksModified) add(selling xdr.Asset, buying xdr.Asset) {
+	if selling.String() > buying.String() {
+		selling, buying = buying, selling
+	}
+
+	obm[selling.String()+"/"+buying.String()] = [2]xdr.Asset{selling, buying}
+}
+
+// UpdateOrderBookSnapshots records, for every order book modified during the
+// session, the best price levels of both sides of the book.
+//
+// Snapshots are read from the offers table of stellar-core, which only
+// reflects its latest ledger, so they are labelled with the ledger that
+// stellar-core reports rather than the last ledger ingested, and are not taken
+// when re-ingesting historical ledgers.
+func (obm OrderBooksModified) UpdateOrderBookSnapshots(is *Session) {
+	if is.Err != nil || is.Cursor.Err != nil {
+		return
+	}
+
+	if is.ClearExisting || len(obm) == 0 {
+		return
+	}
+
+	coreQ := &core.Q{Session: is.Cursor.DB}
+	q := history.Q{Session: is.Ingestion.DB}
+
+	// stellar-core may close a ledger while the books are being read, in which
+	// case they are read again.
+	var (
+		seq       int32
+		snapshots []orderBookSnapshot
+	)
+	for attempt := 0; attempt < orderBookSnapshotAttempts; attempt++ {
+		var before, after int32
+		is.Err = coreQ.LatestLedger(&before)
+		if is.Err != nil {
+			return
+		}
+
+		snapshots, is.Err = obm.load(coreQ)
+		if is.Err != nil {
+			return
+		}
+
+		is.Err = coreQ.LatestLedger(&after)
+		if is.Err != nil {
+			return
+		}
+
+		if before == after {
+			seq = before
+			break
+		}
+		snapshots = nil
+	}
+
+	if seq == 0 {
+		log.
+			WithField("ledger", is.Cursor.LastLedger).
+			WithField("attempts", orderBookSnapshotAttempts).
+			Warn("ingest: order books changed while being read, snapshot dropped")
+		if is.Metrics != nil {
+			is.Metrics.DroppedOrderBookSnapshotsMeter.Mark(int64(len(obm)))
+		}
+		return
+	}
+
+	for _, snapshot := range snapshots {
+		is.Err = q.InsertOrderBookSnapshot(seq, snapshot.selling, snapshot.buying, snapshot.levels)
+		if is.Err != nil {
+			return
+		}
+	}
+}
+
+// orderBookSnapshotAttempts is the number of times the order books are read
+// from stellar-core before giving up on snapshotting them.
+const orderBookSnapshotAttempts = 3
+
+// orderBookSnapshot is the best price levels of the offers selling `selling`
+// for `buying`.
+type orderBookSnapshot struct {
+	selling xdr.Asset
+	buying  xdr.Asset
+	levels  []history.OrderBookLevel
+}
+
+// load reads both sides of every order book in `obm` from stellar-core.
+func (obm OrderBooksModified) load(coreQ *core.Q) ([]orderBookSnapshot, error) {
+	var snapshots []orderBookSnapshot
+
+	for _, pair := range obm {
+		var summary core.OrderBookSummary
+		err := coreQ.GetOrderBookSummary(
+			&summary,
+			pair[0],
+			pair[1],
+			OrderBookSnapshotDepth,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// the asks of the summary are the offers selling pair[0], and the bids
+		// are the offers selling pair[1] with their prices inverted.
+		asks := make([]history.OrderBookLevel, 0, OrderBookSnapshotDepth)
+		for _, level := range summary.Asks() {
+			asks = append(asks, history.OrderBookLevel{
+				Pricen: level.Pricen,
+				Priced: level.Priced,
+				Amount: level.Amount,
+			})
+		}
+
+		bids := make([]history.OrderBookLevel, 0, OrderBookSnapshotDepth)
+		for _, level := range summary.Bids() {
+			bids = append(bids, history.OrderBookLevel{
+				Pricen: level.Priced,
+				Priced: level.Pricen,
+				Amount: level.Amount,
+			})
+		}
+
+		snapshots = append(snapshots,
+			orderBookSnapshot{selling: pair[0], buying: pair[1], levels: asks},
+			orderBookSnapshot{selling: pair[1], buying: pair[0], levels: bids},
+		)
+	}
+
+	return snapshots, nil
+}
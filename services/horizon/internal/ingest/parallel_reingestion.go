@@ -0,0 +1,345 @@
+package ingest
+
+import (
+	"sync"
+
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/log"
+	"github.com/stellar/go/services/horizon/internal/toid"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// chunkAttempts is the number of times a chunk is ingested before its error is
+// reported.  Concurrent sessions can conflict when they record the same new
+// account or update the same account row, in which case postgres aborts one of
+// them; retrying once the other has committed succeeds.
+const chunkAttempts = 3
+
+// ledgerChunk is a range of ledgers, inclusive, that is reingested by a single
+// session and committed atomically.
+type ledgerChunk struct {
+	Start int32
+	End   int32
+}
+
+// Len returns the number of ledgers in the chunk.
+func (c ledgerChunk) Len() int32 {
+	return c.End - c.Start + 1
+}
+
+// chunkRange splits the range of ledgers from `start` to `end`, inclusive, into
+// chunks of at most `size` ledgers.
+func chunkRange(start, end, size int32) []ledgerChunk {
+	var chunks []ledgerChunk
+	for cur := start; cur <= end; cur += size {
+		last := cur + size - 1
+		if last > end || last < cur {
+			last = end
+		}
+		chunks = append(chunks, ledgerChunk{Start: cur, End: last})
+
+		if last == end {
+			break
+		}
+	}
+	return chunks
+}
+
+// ReingestRangeParallel reingests the ledgers from `start` to `end`, inclusive,
+// in chunks of `chunkSize` ledgers.  Up to `parallel` chunks are ingested at
+// once, each by its own session with its own connections to the core and
+// horizon databases, and each chunk is committed atomically.
+//
+// Chunks whose ledgers were all ingested with the current version are skipped
+// unless `force` is set, which allows an interrupted reingestion to be resumed
+// by running it again.  Asset stats and trade aggregations are updated for the
+// whole range once every chunk has been committed, including the chunks that
+// were skipped, as an interrupted run may have committed them without updating
+// their aggregates.  Account merges are then recorded again for the whole
+// range, as a chunk cannot see the account creations of the chunks committed
+// concurrently with it.
+func (i *System) ReingestRangeParallel(
+	start, end, chunkSize int32,
+	parallel int,
+	force bool,
+) (int, error) {
+	if start < 1 || start > end {
+		return 0, errors.Errorf("invalid ledger range: %d-%d", start, end)
+	}
+
+	if chunkSize < 1 {
+		return 0, errors.New("chunk size must be positive")
+	}
+
+	if parallel < 1 {
+		return 0, errors.New("parallelism must be positive")
+	}
+
+	chunks := chunkRange(start, end, chunkSize)
+	pending, err := i.pendingChunks(chunks, force)
+	if err != nil {
+		return 0, err
+	}
+
+	log.
+		WithField("start", start).
+		WithField("end", end).
+		WithField("chunks", len(chunks)).
+		WithField("pending", len(pending)).
+		WithField("parallel", parallel).
+		Info("reingest: range")
+
+	var (
+		wg        sync.WaitGroup
+		lock      sync.Mutex
+		ingested  int
+		completed int
+		failure   error
+		assets    = AssetsModified{}
+		work      = make(chan ledgerChunk)
+	)
+
+	for w := 0; w < parallel; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for chunk := range work {
+				is := i.reingestChunk(chunk)
+				for attempt := 1; is.Err != nil && attempt < chunkAttempts; attempt++ {
+					log.
+						WithField("start", chunk.Start).
+						WithField("end", chunk.End).
+						WithField("err", is.Err).
+						Warn("reingest: retrying chunk")
+					is = i.reingestChunk(chunk)
+				}
+
+				lock.Lock()
+				if is.Err != nil {
+					if failure == nil {
+						failure = errors.Wrapf(is.Err, "failed to reingest ledgers %d-%d", chunk.Start, chunk.End)
+					}
+					lock.Unlock()
+					continue
+				}
+
+				ingested += is.Ingested
+				completed++
+				for key, asset := range is.Cursor.AssetsModified {
+					assets[key] = asset
+				}
+
+				log.
+					WithField("start", chunk.Start).
+					WithField("end", chunk.End).
+					WithField("completed", completed).
+					WithField("total", len(pending)).
+					Info("reingest: chunk complete")
+				lock.Unlock()
+			}
+		}()
+	}
+
+	for _, chunk := range pending {
+		lock.Lock()
+		failed := failure != nil
+		lock.Unlock()
+
+		if failed {
+			break
+		}
+
+		work <- chunk
+	}
+	close(work)
+	wg.Wait()
+
+	if failure != nil {
+		return ingested, failure
+	}
+
+	err = i.recordAccountMerges(start, end)
+	if err != nil {
+		return ingested, errors.Wrap(err, "failed to record account merges")
+	}
+
+	err = i.collectSkippedAssets(chunks, pending, assets)
+	if err != nil {
+		return ingested, errors.Wrap(err, "failed to collect modified assets")
+	}
+
+	err = i.updateAggregates(chunks, assets)
+	if err != nil {
+		return ingested, errors.Wrap(err, "failed to update aggregates")
+	}
+
+	return ingested, nil
+}
+
+// pendingChunks returns the chunks that need to be reingested.  Unless
+// `force` is set, chunks whose ledgers were all ingested with the current
+// version are omitted, as are, when reingesting from a history archive, those
+// whose ledgers were ingested from one.
+func (i *System) pendingChunks(chunks []ledgerChunk, force bool) ([]ledgerChunk, error) {
+	if force {
+		return chunks, nil
+	}
+
+	q := history.Q{Session: i.HorizonDB}
+	pending := make([]ledgerChunk, 0, len(chunks))
+
+	for _, chunk := range chunks {
+		var current int32
+		err := q.CurrentLedgerCount(&current, chunk.Start, chunk.End, CurrentVersion)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to count current ledgers")
+		}
+
+		if i.HistoryArchive != nil {
+			var archived int32
+			err = q.LedgerCountWithVersion(&archived, chunk.Start, chunk.End, ArchiveVersion)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to count archived ledgers")
+			}
+			current += archived
+		}
+
+		if current == chunk.Len() {
+			continue
+		}
+
+		pending = append(pending, chunk)
+	}
+
+	return pending, nil
+}
+
+// collectSkippedAssets adds to `assets` every asset recorded in the history
+// database when any of `chunks` is not `pending`.  The assets modified by a
+// skipped chunk are among them, and are found without reading its ledgers
+// again from a stellar-core that may no longer have them.
+func (i *System) collectSkippedAssets(chunks, pending []ledgerChunk, assets AssetsModified) error {
+	if len(pending) == len(chunks) {
+		return nil
+	}
+
+	var recorded []history.Asset
+	q := history.Q{Session: i.HorizonDB}
+	err := q.IssuedAssets(&recorded)
+	if err != nil {
+		return errors.Wrap(err, "failed to load assets")
+	}
+
+	for _, a := range recorded {
+		var issuer xdr.AccountId
+		err = issuer.SetAddress(a.Issuer)
+		if err != nil {
+			return errors.Wrapf(err, "invalid issuer of asset %d", a.ID)
+		}
+
+		var asset xdr.Asset
+		err = asset.SetCredit(a.Code, issuer)
+		if err != nil {
+			return errors.Wrapf(err, "invalid asset %d", a.ID)
+		}
+
+		assets.add(asset)
+	}
+
+	return nil
+}
+
+// recordAccountMerges atomically records the account merges of the ledgers
+// from `start` to `end`, inclusive, against the creations they end.
+func (i *System) recordAccountMerges(start, end int32) error {
+	q := history.Q{Session: i.HorizonDB.Clone()}
+
+	err := q.Begin()
+	if err != nil {
+		return err
+	}
+	defer q.Rollback()
+
+	err = q.RecordAccountMerges(
+		toid.New(start, 0, 0).ToInt64(),
+		toid.New(end+1, 0, 0).ToInt64(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return q.Commit()
+}
+
+// reingestChunk runs a session that clears and reingests the ledgers of
+// `chunk` in a single database transaction, leaving the aggregates derived from
+// them untouched.
+func (i *System) reingestChunk(chunk ledgerChunk) *Session {
+	is := NewSession(i)
+	is.Cursor = i.newReingestCursor(chunk.Start, chunk.End)
+	is.Cursor.DB = i.CoreDB.Clone()
+	is.ClearExisting = true
+	is.Atomic = true
+	is.SkipAggregates = true
+
+	is.Run()
+	return is
+}
+
+// updateAggregates updates the asset stats of `assets` and refreshes the trade
+// aggregations of every chunk.  It runs after all chunks are committed so that
+// buckets spanning two chunks are computed from the trades of both.
+func (i *System) updateAggregates(chunks []ledgerChunk, assets AssetsModified) error {
+	is := NewSession(i)
+	is.Cursor = NewCursor(chunks[0].Start, chunks[len(chunks)-1].End, i)
+
+	err := is.Ingestion.Start()
+	if err != nil {
+		return err
+	}
+	defer is.Ingestion.Rollback()
+
+	assets.UpdateAssetStats(is)
+	if is.Err != nil {
+		return is.Err
+	}
+
+	err = is.Ingestion.Close()
+	if err != nil {
+		return err
+	}
+
+	for _, chunk := range chunks {
+		err = i.refreshTradeAggregations(chunk)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.WithField("chunks", len(chunks)).Info("reingest: aggregates updated")
+	return nil
+}
+
+// refreshTradeAggregations atomically refreshes the trade aggregations for the
+// buckets touched by the trades of `chunk`.
+func (i *System) refreshTradeAggregations(chunk ledgerChunk) error {
+	q := history.Q{Session: i.HorizonDB.Clone()}
+
+	err := q.Begin()
+	if err != nil {
+		return err
+	}
+	defer q.Rollback()
+
+	err = q.RefreshTradeAggregations(
+		toid.New(chunk.Start, 0, 0).ToInt64(),
+		toid.New(chunk.End+1, 0, 0).ToInt64(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return q.Commit()
+}
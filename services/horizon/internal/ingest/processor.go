@@ -0,0 +1,252 @@
+package ingest
+
+import (
+	"encoding/json"
+	"time"
+
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/stellar/go/services/horizon/internal/log"
+	"github.com/stellar/go/support/errors"
+)
+
+var _ Processor = &ProcessorQueue{}
+
+// DefaultProcessorQueueSize is the number of ledgers whose events a
+// ProcessorQueue buffers by default.
+const DefaultProcessorQueueSize = 1000
+
+// NewProcessorQueue returns a queue that buffers the events of up to `size`
+// ledgers and delivers them to `p`.  Its fields may be changed before the first
+// event is queued.
+func NewProcessorQueue(p Processor, size int) *ProcessorQueue {
+	q := &ProcessorQueue{
+		Processor:    p,
+		MaxAttempts:  5,
+		Backoff:      time.Second,
+		MaxBackoff:   time.Minute,
+		CloseTimeout: 10 * time.Second,
+		Dropped:      metrics.NewMeter(),
+		batches:      make(chan []Event, size),
+		done:         make(chan struct{}),
+	}
+
+	q.wg.Add(1)
+	go q.run()
+	return q
+}
+
+// Close stops the queue from accepting events and waits up to CloseTimeout for
+// the events already queued to be delivered.  Those that are not are dropped.
+func (q *ProcessorQueue) Close() error {
+	q.lock.Lock()
+	if q.closed {
+		q.lock.Unlock()
+		return nil
+	}
+	q.enqueue()
+	q.closed = true
+	close(q.batches)
+	q.lock.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-time.After(q.CloseTimeout):
+		close(q.done)
+		<-finished
+	}
+
+	return nil
+}
+
+// Flush queues the events added since the last ledger for delivery.  It is
+// called once the events of a commit have all been added.
+func (q *ProcessorQueue) Flush() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.enqueue()
+}
+
+// ProcessLedger implements Processor.  The events added until the next ledger
+// event are delivered in the same batch.
+func (q *ProcessorQueue) ProcessLedger(event LedgerEvent) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.enqueue()
+	q.add(Event{
+		Type:    "ledger",
+		Data:    event,
+		deliver: func(p Processor) error { return p.ProcessLedger(event) },
+	})
+	return nil
+}
+
+// ProcessTransaction implements Processor.
+func (q *ProcessorQueue) ProcessTransaction(event TransactionEvent) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.add(Event{
+		Type:    "transaction",
+		Data:    event,
+		deliver: func(p Processor) error { return p.ProcessTransaction(event) },
+	})
+	return nil
+}
+
+// ProcessOperation implements Processor.
+func (q *ProcessorQueue) ProcessOperation(event OperationEvent) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.add(Event{
+		Type:    "operation",
+		Data:    event,
+		deliver: func(p Processor) error { return p.ProcessOperation(event) },
+	})
+	return nil
+}
+
+// ProcessEffect implements Processor.
+func (q *ProcessorQueue) ProcessEffect(event EffectEvent) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.add(Event{
+		Type:    "effect",
+		Data:    event,
+		deliver: func(p Processor) error { return p.ProcessEffect(event) },
+	})
+	return nil
+}
+
+// ProcessTrade implements Processor.
+func (q *ProcessorQueue) ProcessTrade(event TradeEvent) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.add(Event{
+		Type:    "trade",
+		Data:    event,
+		deliver: func(p Processor) error { return p.ProcessTrade(event) },
+	})
+	return nil
+}
+
+// add appends an event to the batch being built.  The caller must hold q.lock.
+func (q *ProcessorQueue) add(event Event) {
+	if q.closed {
+		q.drop(1, errors.New("processor queue is closed"))
+		return
+	}
+
+	q.batch = append(q.batch, event)
+}
+
+// enqueue queues the batch being built for delivery, without blocking, and
+// starts a new one.  The batch is dropped if the queue is full.  The caller
+// must hold q.lock.
+func (q *ProcessorQueue) enqueue() {
+	batch := q.batch
+	q.batch = nil
+
+	if len(batch) == 0 || q.closed {
+		return
+	}
+
+	select {
+	case q.batches <- batch:
+	default:
+		q.drop(len(batch), errors.New("processor queue is full"))
+	}
+}
+
+func (q *ProcessorQueue) run() {
+	defer q.wg.Done()
+
+	for batch := range q.batches {
+		select {
+		case <-q.done:
+			q.drop(len(batch), errors.New("processor queue closed before delivery"))
+		default:
+			q.deliver(batch)
+		}
+	}
+}
+
+// deliver delivers a batch of events, retrying from the first event that was
+// not delivered until they all are, MaxAttempts is reached or the queue is
+// closed.
+func (q *ProcessorQueue) deliver(batch []Event) {
+	backoff := q.Backoff
+
+	for attempt := 1; ; attempt++ {
+		var err error
+		batch, err = q.deliverOnce(batch)
+		if err == nil {
+			return
+		}
+
+		if attempt >= q.MaxAttempts {
+			q.drop(len(batch), err)
+			return
+		}
+
+		select {
+		case <-q.done:
+			q.drop(len(batch), err)
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > q.MaxBackoff {
+			backoff = q.MaxBackoff
+		}
+	}
+}
+
+// deliverOnce makes one attempt at delivering `batch`, and returns the events
+// that remain to be delivered.
+func (q *ProcessorQueue) deliverOnce(batch []Event) ([]Event, error) {
+	if bp, ok := q.Processor.(BatchProcessor); ok {
+		err := bp.ProcessBatch(batch)
+		if err != nil {
+			return batch, err
+		}
+		return nil, nil
+	}
+
+	for len(batch) > 0 {
+		err := batch[0].deliver(q.Processor)
+		if err != nil {
+			return batch, err
+		}
+		batch = batch[1:]
+	}
+
+	return nil, nil
+}
+
+// drop logs and counts `n` events that will not be delivered.
+func (q *ProcessorQueue) drop(n int, err error) {
+	q.Dropped.Mark(int64(n))
+	log.
+		WithField("err", err).
+		WithField("dropped", n).
+		Error("ingest: processor events dropped")
+}
+
+// marshalEvent encodes an event delivered to a processor as a JSON document
+// that names the kind of the event, for sinks that write every kind of event
+// to the same destination.
+func marshalEvent(typ string, event interface{}) ([]byte, error) {
+	return json.Marshal(Event{Type: typ, Data: event})
+}
@@ -0,0 +1,369 @@
+package ingest
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/services/horizon/internal/test"
+)
+
+func TestProcessors(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("trades")
+	defer tt.Finish()
+
+	recorder := &recordingProcessor{}
+	failing := &recordingProcessor{err: errors.New("broken")}
+
+	sys := sys(tt)
+	sys.Processors = []Processor{failing, recorder}
+
+	s := sys.Tick()
+	tt.Require.NoError(s.Err)
+
+	count := func(table string) int {
+		var n int
+		tt.Require.NoError(tt.HorizonSession().GetRaw(&n, "SELECT COUNT(*) FROM "+table))
+		return n
+	}
+
+	tt.Assert.Len(recorder.ledgers, count("history_ledgers"))
+	tt.Assert.Len(recorder.transactions, count("history_transactions"))
+	tt.Assert.Len(recorder.operations, count("history_operations"))
+	tt.Assert.Len(recorder.effects, count("history_effects"))
+	tt.Assert.Len(recorder.trades, count("history_trades"))
+	tt.Assert.NotEmpty(recorder.trades)
+
+	// events are delivered in the order they were ingested
+	for i, ledger := range recorder.ledgers {
+		tt.Assert.Equal(recorder.ledgers[0].Sequence+int32(i), ledger.Sequence)
+	}
+
+	// a failing processor keeps receiving the events of the commit that failed,
+	// without affecting other processors.
+	tt.Assert.Len(failing.ledgers, len(recorder.ledgers))
+	tt.Assert.Len(failing.transactions, len(recorder.transactions))
+	tt.Assert.Len(failing.trades, len(recorder.trades))
+
+	// events are discarded when the transaction is rolled back
+	recorder = &recordingProcessor{}
+	ingestion := &Ingestion{DB: tt.HorizonSession(), Processors: []Processor{recorder}}
+	tt.Require.NoError(ingestion.Start())
+	ingestion.emit(func(p Processor) error { return p.ProcessLedger(LedgerEvent{}) })
+	tt.Require.NoError(ingestion.Rollback())
+	tt.Require.NoError(ingestion.Start())
+	tt.Require.NoError(ingestion.Close())
+	tt.Assert.Empty(recorder.ledgers)
+}
+
+func TestFileProcessor(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	dir, err := ioutil.TempDir("", "horizon-events")
+	tt.Require.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.jsonl")
+
+	p, err := NewFileProcessor(path)
+	tt.Require.NoError(err)
+	tt.Require.NoError(p.ProcessLedger(LedgerEvent{Sequence: 3}))
+	tt.Require.NoError(p.ProcessTrade(TradeEvent{OfferID: 7}))
+	tt.Require.NoError(p.Close())
+
+	// events are appended to existing files
+	p, err = NewFileProcessor(path)
+	tt.Require.NoError(err)
+	tt.Require.NoError(p.ProcessEffect(EffectEvent{Order: 1}))
+	tt.Require.NoError(p.Close())
+
+	file, err := os.Open(path)
+	tt.Require.NoError(err)
+	defer file.Close()
+
+	var types []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}
+		tt.Require.NoError(json.Unmarshal(scanner.Bytes(), &line))
+		types = append(types, line.Type)
+	}
+	tt.Require.NoError(scanner.Err())
+	tt.Assert.Equal([]string{"ledger", "trade", "effect"}, types)
+}
+
+func TestWebhookProcessor(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	var received []LedgerEvent
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Type string      `json:"type"`
+			Data LedgerEvent `json:"data"`
+		}
+		tt.Assert.Equal("application/json", r.Header.Get("Content-Type"))
+		tt.Assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+		tt.Assert.Equal("ledger", body.Type)
+		received = append(received, body.Data)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	p := NewWebhookProcessor(server.URL)
+	tt.Require.NoError(p.ProcessLedger(LedgerEvent{Sequence: 3, Hash: "abc"}))
+	if tt.Assert.Len(received, 1) {
+		tt.Assert.Equal(int32(3), received[0].Sequence)
+		tt.Assert.Equal("abc", received[0].Hash)
+	}
+
+	status = http.StatusInternalServerError
+	tt.Assert.Error(p.ProcessLedger(LedgerEvent{Sequence: 4}))
+}
+
+func TestWebhookProcessorBatch(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body []struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}
+		tt.Assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+		for _, event := range body {
+			received = append(received, event.Type)
+		}
+	}))
+	defer server.Close()
+
+	p := NewWebhookProcessor(server.URL)
+	tt.Require.NoError(p.ProcessBatch([]Event{
+		{Type: "ledger", Data: LedgerEvent{Sequence: 3}},
+		{Type: "trade", Data: TradeEvent{OfferID: 7}},
+	}))
+	tt.Assert.Equal([]string{"ledger", "trade"}, received)
+}
+
+func TestProcessorQueue(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	// a processor that fails the first delivery of every event
+	recorder := &recordingProcessor{}
+	flaky := &flakyProcessor{Processor: recorder}
+	q := NewProcessorQueue(flaky, 2)
+	q.Backoff = time.Millisecond
+	q.MaxBackoff = time.Millisecond
+
+	tt.Require.NoError(q.ProcessLedger(LedgerEvent{Sequence: 3}))
+	tt.Require.NoError(q.ProcessTrade(TradeEvent{OfferID: 7}))
+	q.Flush()
+	waitFor(tt, func() bool {
+		recorder.lock.Lock()
+		defer recorder.lock.Unlock()
+		return len(recorder.trades) == 2
+	})
+
+	// each event is retried until delivered, in the order queued
+	tt.Assert.Equal([]LedgerEvent{{Sequence: 3}, {Sequence: 3}}, recorder.ledgers)
+	tt.Assert.Equal([]TradeEvent{{OfferID: 7}, {OfferID: 7}}, recorder.trades)
+	tt.Assert.NoError(q.Close())
+	tt.Assert.Zero(q.Dropped.Count())
+
+	// batch processors receive the events of each ledger together
+	batches := &batchRecorder{}
+	q = NewProcessorQueue(batches, 10)
+	tt.Require.NoError(q.ProcessLedger(LedgerEvent{Sequence: 3}))
+	tt.Require.NoError(q.ProcessTransaction(TransactionEvent{Ledger: 3}))
+	tt.Require.NoError(q.ProcessLedger(LedgerEvent{Sequence: 4}))
+	tt.Require.NoError(q.ProcessEffect(EffectEvent{Order: 1}))
+
+	// events still queued are delivered on close
+	tt.Assert.NoError(q.Close())
+	if tt.Assert.Len(batches.batches, 2) {
+		tt.Assert.Equal([]string{"ledger", "transaction"}, eventTypes(batches.batches[0]))
+		tt.Assert.Equal([]string{"ledger", "effect"}, eventTypes(batches.batches[1]))
+	}
+
+	// events are dropped after MaxAttempts failed deliveries
+	failing := &recordingProcessor{err: errors.New("broken")}
+	q = NewProcessorQueue(failing, 1)
+	q.MaxAttempts = 3
+	q.Backoff = time.Millisecond
+	q.MaxBackoff = time.Millisecond
+
+	tt.Require.NoError(q.ProcessLedger(LedgerEvent{Sequence: 3}))
+	q.Flush()
+	waitFor(tt, func() bool { return q.Dropped.Count() == 1 })
+	tt.Assert.Len(failing.ledgers, 3)
+	tt.Assert.NoError(q.Close())
+
+	// batches are dropped, without blocking, once the queue is full
+	blocked := &blockingProcessor{release: make(chan struct{})}
+	q = NewProcessorQueue(blocked, 1)
+	tt.Require.NoError(q.ProcessLedger(LedgerEvent{Sequence: 1}))
+	q.Flush()
+	waitFor(tt, func() bool {
+		blocked.lock.Lock()
+		defer blocked.lock.Unlock()
+		return blocked.started
+	})
+	tt.Require.NoError(q.ProcessLedger(LedgerEvent{Sequence: 2}))
+	tt.Require.NoError(q.ProcessLedger(LedgerEvent{Sequence: 3}))
+	tt.Require.NoError(q.ProcessTrade(TradeEvent{OfferID: 7}))
+	q.Flush()
+	tt.Assert.Equal(int64(2), q.Dropped.Count())
+	close(blocked.release)
+	tt.Assert.NoError(q.Close())
+
+	// events added once the queue is closed are dropped
+	tt.Require.NoError(q.ProcessLedger(LedgerEvent{Sequence: 4}))
+	tt.Assert.Equal(int64(3), q.Dropped.Count())
+}
+
+// eventTypes returns the types of `events`.
+func eventTypes(events []Event) []string {
+	types := make([]string, len(events))
+	for i, event := range events {
+		types[i] = event.Type
+	}
+	return types
+}
+
+// batchRecorder is a BatchProcessor that records the batches it receives.
+type batchRecorder struct {
+	recordingProcessor
+	batches [][]Event
+}
+
+func (p *batchRecorder) ProcessBatch(events []Event) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.batches = append(p.batches, events)
+	return nil
+}
+
+// waitFor polls `done` until it returns true, failing the test after 5 seconds.
+func waitFor(tt *test.T, done func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !done() {
+		if time.Now().After(deadline) {
+			tt.Require.FailNow("timed out")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// flakyProcessor is a Processor that fails the first delivery of each ledger
+// and trade, after passing it on to the wrapped processor.
+type flakyProcessor struct {
+	Processor
+
+	lock   sync.Mutex
+	failed map[interface{}]bool
+}
+
+func (p *flakyProcessor) ProcessLedger(event LedgerEvent) error {
+	p.Processor.ProcessLedger(event)
+	return p.fail(event)
+}
+
+func (p *flakyProcessor) ProcessTrade(event TradeEvent) error {
+	p.Processor.ProcessTrade(event)
+	return p.fail(event)
+}
+
+func (p *flakyProcessor) fail(event interface{}) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.failed[event] {
+		return nil
+	}
+	if p.failed == nil {
+		p.failed = map[interface{}]bool{}
+	}
+	p.failed[event] = true
+	return errors.New("flaky")
+}
+
+// blockingProcessor is a Processor whose ProcessLedger blocks until release is
+// closed.
+type blockingProcessor struct {
+	recordingProcessor
+	release chan struct{}
+
+	lock    sync.Mutex
+	started bool
+}
+
+func (p *blockingProcessor) ProcessLedger(event LedgerEvent) error {
+	p.lock.Lock()
+	p.started = true
+	p.lock.Unlock()
+
+	<-p.release
+	return nil
+}
+
+// recordingProcessor is a Processor that records the events it receives.  When
+// err is set, every call records its event and then fails with err.
+type recordingProcessor struct {
+	err error
+
+	lock         sync.Mutex
+	ledgers      []LedgerEvent
+	transactions []TransactionEvent
+	operations   []OperationEvent
+	effects      []EffectEvent
+	trades       []TradeEvent
+}
+
+func (p *recordingProcessor) ProcessLedger(event LedgerEvent) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.ledgers = append(p.ledgers, event)
+	return p.err
+}
+
+func (p *recordingProcessor) ProcessTransaction(event TransactionEvent) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.transactions = append(p.transactions, event)
+	return p.err
+}
+
+func (p *recordingProcessor) ProcessOperation(event OperationEvent) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.operations = append(p.operations, event)
+	return p.err
+}
+
+func (p *recordingProcessor) ProcessEffect(event EffectEvent) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.effects = append(p.effects, event)
+	return p.err
+}
+
+func (p *recordingProcessor) ProcessTrade(event TradeEvent) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.trades = append(p.trades, event)
+	return p.err
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/ingest/participants"
+	"github.com/stellar/go/services/horizon/internal/toid"
 	"github.com/stellar/go/support/errors"
 	sTime "github.com/stellar/go/support/time"
 	"github.com/stellar/go/xdr"
@@ -44,6 +45,8 @@ func (is *Session) Run() {
 		}
 	}
 	is.Cursor.AssetsModified.UpdateAssetStats(is)
+	is.Cursor.OrderBooksModified.UpdateOrderBookSnapshots(is)
+	is.updateTradeAggregations()
 
 	if is.Err != nil {
 		is.Ingestion.Rollback()
@@ -95,7 +98,7 @@ func (is *Session) effectFlagDetails(flagDetails map[string]bool, flagPtr *xdr.U
 }
 
 func (is *Session) flush() {
-	if is.Err != nil {
+	if is.Err != nil || is.Atomic {
 		return
 	}
 	is.Err = is.Ingestion.Flush()
@@ -109,10 +112,12 @@ func (is *Session) ingestEffects() {
 	effects := &EffectIngestion{
 		Dest:        is.Ingestion,
 		OperationID: is.Cursor.OperationID(),
+		Filter:      is.Filter,
 		parent:      is.Ingestion,
 	}
 	source := is.Cursor.OperationSourceAccount()
 	opbody := is.Cursor.Operation().Body
+	q := history.Q{Session: is.Ingestion.DB}
 
 	switch is.Cursor.OperationType() {
 	case xdr.OperationTypeCreateAccount:
@@ -138,6 +143,11 @@ func (is *Session) ingestEffects() {
 			},
 		)
 
+		is.Err = q.RecordAccountCreation(op.Destination, source, effects.OperationID)
+		if is.Err != nil {
+			return
+		}
+
 	case xdr.OperationTypePayment:
 		op := opbody.MustPaymentOp()
 		dets := map[string]interface{}{"amount": amount.String(op.Amount)}
@@ -262,6 +272,11 @@ func (is *Session) ingestEffects() {
 		effects.Add(source, history.EffectAccountDebited, dets)
 		effects.Add(dest, history.EffectAccountCredited, dets)
 		effects.Add(source, history.EffectAccountRemoved, map[string]interface{}{})
+
+		is.Err = q.RecordAccountMerge(source, dest, effects.OperationID)
+		if is.Err != nil {
+			return
+		}
 	case xdr.OperationTypeInflation:
 		payouts := is.Cursor.OperationResult().MustInflationResult().MustPayouts()
 		for _, payout := range payouts {
@@ -281,6 +296,12 @@ func (is *Session) ingestEffects() {
 		key.SetData(source, string(op.DataName))
 
 		before, after, err := is.Cursor.BeforeAndAfter(key)
+
+		// NOTE: without meta, we cannot tell how the data entry changed.
+		if err == meta.ErrMetaNotFound && !is.Cursor.MetaAvailable() {
+			return
+		}
+
 		if err != nil {
 			is.Err = err
 			return
@@ -318,12 +339,20 @@ func (is *Session) ingestLedger() {
 		return
 	}
 
+	// ledgers ingested without meta are incomplete, and tagged as outdated so
+	// that they are rebuilt from stellar-core.
+	version := CurrentVersion
+	if !is.Cursor.MetaAvailable() {
+		version = ArchiveVersion
+	}
+
 	start := time.Now()
 	is.Err = is.Ingestion.Ledger(
 		is.Cursor.LedgerID(),
 		is.Cursor.Ledger(),
 		is.Cursor.SuccessfulTransactionCount(),
 		is.Cursor.SuccessfulLedgerOperationCount(),
+		version,
 	)
 
 	if is.Err != nil {
@@ -334,6 +363,8 @@ func (is *Session) ingestLedger() {
 		is.ingestTransaction()
 	}
 
+	is.ingestBalanceSnapshots()
+
 	is.Ingested++
 	if is.Metrics != nil {
 		is.Metrics.IngestLedgerTimer.Update(time.Since(start))
@@ -347,6 +378,22 @@ func (is *Session) ingestOperation() {
 		return
 	}
 
+	// Find the participants
+	var p []xdr.AccountId
+	p, is.Err = participants.ForOperation(
+		&is.Cursor.Transaction().Envelope.Tx,
+		is.Cursor.Operation(),
+	)
+	if is.Err != nil {
+		return
+	}
+
+	// skip ingesting operations excluded by the filter
+	if !is.Filter.MatchOperation(is.Cursor.Transaction(), *is.Cursor.Operation(), p) {
+		is.ingestAssetsModified()
+		return
+	}
+
 	is.Err = is.Ingestion.Operation(
 		is.Cursor.OperationID(),
 		is.Cursor.TransactionID(),
@@ -359,9 +406,16 @@ func (is *Session) ingestOperation() {
 		return
 	}
 
-	is.ingestOperationParticipants()
+	is.ingestOperationParticipants(p)
 	is.ingestEffects()
 	is.ingestTrades()
+	is.ingestOfferEvents()
+	is.ingestAssetsModified()
+}
+
+// ingestAssetsModified records the assets whose stats may be changed by the
+// current operation, so that they are updated once the session completes.
+func (is *Session) ingestAssetsModified() {
 	is.Err = is.Cursor.AssetsModified.IngestOperation(
 		is.Err,
 		is.Cursor.Operation(),
@@ -370,31 +424,30 @@ func (is *Session) ingestOperation() {
 	)
 }
 
-func (is *Session) ingestOperationParticipants() {
+func (is *Session) ingestOperationParticipants(p []xdr.AccountId) {
 	if is.Err != nil {
 		return
 	}
 
-	// Find the participants
-	var p []xdr.AccountId
-	p, is.Err = participants.ForOperation(
-		&is.Cursor.Transaction().Envelope.Tx,
-		is.Cursor.Operation(),
+	is.Err = is.Ingestion.OperationParticipants(
+		is.Cursor.OperationID(),
+		is.Filter.matchingAccounts(p),
 	)
 	if is.Err != nil {
 		return
 	}
-
-	is.Err = is.Ingestion.OperationParticipants(is.Cursor.OperationID(), p)
-	if is.Err != nil {
-		return
-	}
 }
 
 func (is *Session) ingestSignerEffects(effects *EffectIngestion, op xdr.SetOptionsOp) {
 	source := is.Cursor.OperationSourceAccount()
 
 	be, ae, err := is.Cursor.BeforeAndAfter(source.LedgerKey())
+
+	// NOTE: without meta, we cannot tell how the signers changed.
+	if err == meta.ErrMetaNotFound && !is.Cursor.MetaAvailable() {
+		return
+	}
+
 	if err != nil {
 		is.Err = err
 		return
@@ -441,34 +494,99 @@ func (is *Session) ingestSignerEffects(effects *EffectIngestion, op xdr.SetOptio
 
 }
 
-func (is *Session) ingestTrades() {
+func (is *Session) ingestOfferEvents() {
 	if is.Err != nil {
 		return
 	}
 
-	buyer := is.Cursor.OperationSourceAccount()
-	trades := []xdr.ClaimOfferAtom{}
+	// index the non-empty claims made by this operation by offer id, so that we
+	// can tell whether a change to an offer was caused by a trade.
+	claimed := map[xdr.Uint64]bool{}
+	for _, claim := range is.offersClaimed() {
+		if claim.AmountBought == 0 && claim.AmountSold == 0 {
+			continue
+		}
+		claimed[claim.OfferId] = true
+	}
 
-	switch is.Cursor.OperationType() {
-	case xdr.OperationTypePathPayment:
-		trades = is.Cursor.OperationResult().
-			MustPathPaymentResult().
-			MustSuccess().
-			Offers
+	// the offer being managed by the operation's source account, if any.
+	var managedOfferID xdr.Uint64
+	if op, ok := is.Cursor.Operation().Body.GetManageOfferOp(); ok {
+		managedOfferID = op.OfferId
+	}
 
-	case xdr.OperationTypeManageOffer:
-		trades = is.Cursor.OperationResult().MustManageOfferResult().MustSuccess().OffersClaimed
-	case xdr.OperationTypeCreatePassiveOffer:
-		result := is.Cursor.OperationResult()
+	source := is.Cursor.OperationSourceAccount()
+	q := history.Q{Session: is.Ingestion.DB}
+	seen := []xdr.LedgerKey{}
+	order := int32(0)
 
-		// KNOWN ISSUE:  stellar-core creates results for CreatePassiveOffer operations
-		// with the wrong result arm set.
-		if result.Type == xdr.OperationTypeManageOffer {
-			trades = result.MustManageOfferResult().MustSuccess().OffersClaimed
-		} else {
-			trades = result.MustCreatePassiveOfferResult().MustSuccess().OffersClaimed
+	for _, change := range is.Cursor.OperationChanges() {
+		key := change.LedgerKey()
+		if key.Type != xdr.LedgerEntryTypeOffer {
+			continue
 		}
+
+		if containsLedgerKey(seen, key) {
+			continue
+		}
+		seen = append(seen, key)
+
+		before, after, err := is.Cursor.BeforeAndAfter(key)
+		if err != nil {
+			is.Err = err
+			return
+		}
+
+		var (
+			typ   history.OfferEventType
+			offer xdr.OfferEntry
+		)
+
+		switch {
+		case before == nil && after == nil:
+			continue
+		case before == nil:
+			typ = history.OfferEventCreated
+			offer = after.Data.MustOffer()
+		case after == nil:
+			offer = before.Data.MustOffer()
+			typ = history.OfferEventCanceled
+			if claimed[offer.OfferId] {
+				typ = history.OfferEventFilled
+			}
+		default:
+			offer = after.Data.MustOffer()
+			typ = history.OfferEventUpdated
+			isOwner := offer.SellerId.Address() == source.Address() &&
+				offer.OfferId == managedOfferID
+			if claimed[offer.OfferId] && !isOwner {
+				typ = history.OfferEventPartiallyFilled
+			}
+		}
+
+		is.Cursor.OrderBooksModified.add(offer.Selling, offer.Buying)
+
+		is.Err = q.InsertOfferEvent(
+			is.Cursor.OperationID(),
+			order,
+			typ,
+			offer,
+			sTime.MillisFromSeconds(is.Cursor.Ledger().CloseTime),
+		)
+		if is.Err != nil {
+			return
+		}
+		order++
 	}
+}
+
+func (is *Session) ingestTrades() {
+	if is.Err != nil {
+		return
+	}
+
+	buyer := is.Cursor.OperationSourceAccount()
+	trades := is.offersClaimed()
 
 	q := history.Q{Session: is.Ingestion.DB}
 	for i, trade := range trades {
@@ -492,6 +610,20 @@ func (is *Session) ingestTrades() {
 		if is.Err != nil {
 			return
 		}
+
+		event := TradeEvent{
+			OperationID:     is.Cursor.OperationID(),
+			Order:           int32(i),
+			LedgerCloseTime: time.Unix(is.Cursor.Ledger().CloseTime, 0).UTC(),
+			OfferID:         int64(trade.OfferId),
+			Seller:          trade.SellerId.Address(),
+			Buyer:           buyer.Address(),
+			SoldAsset:       trade.AssetSold.String(),
+			SoldAmount:      amount.String(trade.AmountSold),
+			BoughtAsset:     trade.AssetBought.String(),
+			BoughtAmount:    amount.String(trade.AmountBought),
+		}
+		is.Ingestion.emit(func(p Processor) error { return p.ProcessTrade(event) })
 	}
 }
 
@@ -539,6 +671,24 @@ func (is *Session) ingestTransaction() {
 	if !is.Cursor.Transaction().IsSuccessful() {
 		return
 	}
+
+	// skip ingesting transactions excluded by the filter.  Asset stats cover
+	// every account, so the assets they modify are still recorded.
+	var match bool
+	match, is.Err = is.Filter.MatchTransaction(
+		is.Cursor.Transaction(),
+		is.Cursor.TransactionFee(),
+	)
+	if is.Err != nil {
+		return
+	}
+
+	if !match {
+		for is.Cursor.NextOp() {
+			is.ingestAssetsModified()
+		}
+		return
+	}
 	is.Err = is.Ingestion.Transaction(
 		is.Cursor.TransactionID(),
 		is.Cursor.Transaction(),
@@ -571,7 +721,10 @@ func (is *Session) ingestTransactionParticipants() {
 		return
 	}
 
-	is.Err = is.Ingestion.TransactionParticipants(is.Cursor.TransactionID(), p)
+	is.Err = is.Ingestion.TransactionParticipants(
+		is.Cursor.TransactionID(),
+		is.Filter.matchingAccounts(p),
+	)
 	if is.Err != nil {
 		return
 	}
@@ -732,6 +885,32 @@ func (is *Session) operationDetails() map[string]interface{} {
 	return details
 }
 
+// offersClaimed returns the offers claimed by the current operation, including
+// any zeroed claims that stellar-core emits when garbage collecting unfunded
+// offers.
+func (is *Session) offersClaimed() []xdr.ClaimOfferAtom {
+	switch is.Cursor.OperationType() {
+	case xdr.OperationTypePathPayment:
+		return is.Cursor.OperationResult().
+			MustPathPaymentResult().
+			MustSuccess().
+			Offers
+	case xdr.OperationTypeManageOffer:
+		return is.Cursor.OperationResult().MustManageOfferResult().MustSuccess().OffersClaimed
+	case xdr.OperationTypeCreatePassiveOffer:
+		result := is.Cursor.OperationResult()
+
+		// KNOWN ISSUE:  stellar-core creates results for CreatePassiveOffer operations
+		// with the wrong result arm set.
+		if result.Type == xdr.OperationTypeManageOffer {
+			return result.MustManageOfferResult().MustSuccess().OffersClaimed
+		}
+		return result.MustCreatePassiveOfferResult().MustSuccess().OffersClaimed
+	}
+
+	return nil
+}
+
 // operationFlagDetails sets the account flag details for `f` on `result`.
 func (is *Session) operationFlagDetails(result map[string]interface{}, f int32, prefix string) {
 	var (
@@ -781,3 +960,27 @@ func (is *Session) reportCursorState() error {
 
 	return nil
 }
+
+// updateTradeAggregations refreshes the rolled up trade aggregations for the
+// buckets touched by the trades of the ledgers ingested during this session.
+func (is *Session) updateTradeAggregations() {
+	if is.Err != nil || is.Cursor.Err != nil || is.SkipAggregates {
+		return
+	}
+
+	q := history.Q{Session: is.Ingestion.DB}
+	is.Err = q.RefreshTradeAggregations(
+		toid.New(is.Cursor.FirstLedger, 0, 0).ToInt64(),
+		toid.New(is.Cursor.LastLedger+1, 0, 0).ToInt64(),
+	)
+}
+
+// containsLedgerKey returns true if `keys` includes an entry equal to `key`.
+func containsLedgerKey(keys []xdr.LedgerKey, key xdr.LedgerKey) bool {
+	for _, k := range keys {
+		if k.Equals(key) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,8 @@
 package ingest
 
 import (
+	"io"
+
 	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	herr "github.com/stellar/go/services/horizon/internal/errors"
@@ -113,9 +115,11 @@ func (i *System) ReingestOutdated() (n int, err error) {
 			WithField("batch_size", len(outdated)).
 			Info("reingest: outdated")
 
+		// outdated ledgers, including those ingested from a history archive, are
+		// always rebuilt from stellar-core.
 		var start, end int32
 		flush := func() error {
-			ingested, ferr := i.ReingestRange(start, end)
+			ingested, ferr := i.reingestRange(NewCursor(start, end, i))
 
 			if ferr != nil {
 				return ferr
@@ -155,14 +159,20 @@ func (i *System) ReingestOutdated() (n int, err error) {
 }
 
 // ReingestRange reingests a range of ledgers, from `start` to `end`, inclusive.
+// The ledgers are loaded from the history archive when one is configured.
 func (i *System) ReingestRange(start, end int32) (int, error) {
+	return i.reingestRange(i.newReingestCursor(start, end))
+}
+
+// reingestRange reingests the range of ledgers iterated by `cursor`.
+func (i *System) reingestRange(cursor *Cursor) (int, error) {
 	is := NewSession(i)
-	is.Cursor = NewCursor(start, end, i)
+	is.Cursor = cursor
 	is.ClearExisting = true
 
 	is.Run()
-	log.WithField("start", start).
-		WithField("end", end).
+	log.WithField("start", cursor.FirstLedger).
+		WithField("end", cursor.LastLedger).
 		WithField("err", is.Err).
 		WithField("ingested", is.Ingested).
 		Info("ingest: range complete")
@@ -175,8 +185,25 @@ func (i *System) ReingestSingle(sequence int32) error {
 	return err
 }
 
+// Close closes the processors of the system that hold resources, such as the
+// events buffered by a ProcessorQueue, once ingestion has stopped.
+func (i *System) Close() {
+	for _, p := range i.Processors {
+		c, ok := p.(io.Closer)
+		if !ok {
+			continue
+		}
+
+		err := c.Close()
+		if err != nil {
+			log.WithField("err", err).Error("ingest: failed to close processor")
+		}
+	}
+}
+
 // Tick triggers the ingestion system to ingest any new ledger data, provided
-// that there currently is not an import session in progress.
+// that there currently is not an import session in progress.  It also starts a
+// search for gaps in the history database when one is due.
 func (i *System) Tick() *Session {
 	i.lock.Lock()
 	if i.current != nil {
@@ -189,7 +216,14 @@ func (i *System) Tick() *Session {
 	i.current = is
 	i.lock.Unlock()
 
+	defer func() {
+		i.lock.Lock()
+		i.current = nil
+		i.lock.Unlock()
+	}()
+
 	i.runOnce()
+	i.checkGapsIfDue()
 	return is
 }
 
@@ -235,6 +269,17 @@ func (i *System) newCursor() (*Cursor, error) {
 	return NewCursor(1, ls.CoreLatest, i), nil
 }
 
+// newReingestCursor creates a new ingestion cursor over the ledgers from
+// `start` to `end`, inclusive, that loads them from the history archive when
+// one is configured.
+func (i *System) newReingestCursor(start, end int32) *Cursor {
+	c := NewCursor(start, end, i)
+	if i.HistoryArchive != nil {
+		c.Source = NewArchiveLedgerSource(i.HistoryArchive, i.Network)
+	}
+	return c
+}
+
 // run causes the importer to check stellar-core to see if we can import new
 // data.
 func (i *System) runOnce() {
@@ -258,12 +303,6 @@ func (i *System) runOnce() {
 	is := i.current
 	i.lock.Unlock()
 
-	defer func() {
-		i.lock.Lock()
-		i.current = nil
-		i.lock.Unlock()
-	}()
-
 	if is == nil {
 		log.Warn("ingest: runOnce ran with a nil current session")
 		return
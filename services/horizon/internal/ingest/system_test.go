@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stellar/go/network"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/test"
 )
 
@@ -23,6 +24,30 @@ func TestClearAll(t *testing.T) {
 	tt.Assert.Equal(0, found)
 }
 
+func TestReingestRetainsAccountMerges(t *testing.T) {
+	tt := test.Start(t).Scenario("account_merge")
+	defer tt.Finish()
+	is := sys(tt)
+	q := history.Q{Session: tt.HorizonSession()}
+
+	// the account is created in ledger 2 and merged in ledger 3
+	err := is.ReingestSingle(2)
+	tt.Require.NoError(err)
+
+	var creation history.AccountCreation
+	err = q.AccountCreationByAddress(&creation, "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int64(8589938689), creation.OperationID)
+	tt.Assert.Equal("GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2", creation.MergedInto.String)
+
+	var mergedBy int64
+	err = tt.HorizonSession().GetRaw(&mergedBy, `
+		SELECT merged_by_operation_id FROM history_account_creations
+		WHERE history_operation_id = 8589938689`)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int64(12884905985), mergedBy)
+}
+
 func TestValidation(t *testing.T) {
 	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
 	defer tt.Finish()
@@ -60,6 +85,101 @@ func TestValidation(t *testing.T) {
 	tt.Assert.Contains(err.Error(), "cur and prev ledger hashes don't match")
 }
 
+func TestChunkRange(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	chunks := chunkRange(1, 25, 10)
+	tt.Assert.Equal([]ledgerChunk{
+		{Start: 1, End: 10},
+		{Start: 11, End: 20},
+		{Start: 21, End: 25},
+	}, chunks)
+
+	chunks = chunkRange(5, 5, 10)
+	tt.Assert.Equal([]ledgerChunk{{Start: 5, End: 5}}, chunks)
+
+	chunks = chunkRange(1, 20, 10)
+	tt.Assert.Len(chunks, 2)
+}
+
+func TestReingestRangeParallel(t *testing.T) {
+	tt := test.Start(t).Scenario("kahuna")
+	defer tt.Finish()
+	is := sys(tt)
+
+	countLedgers := func() int {
+		var found int
+		err := tt.HorizonSession().GetRaw(&found, "SELECT COUNT(*) FROM history_ledgers")
+		tt.Require.NoError(err)
+		return found
+	}
+
+	err := is.ClearAll()
+	tt.Require.NoError(err)
+
+	ingested, err := is.ReingestRangeParallel(1, 57, 10, 4, false)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(57, ingested)
+	tt.Assert.Equal(57, countLedgers())
+
+	// chunks that are already current are skipped
+	ingested, err = is.ReingestRangeParallel(1, 57, 10, 4, false)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(0, ingested)
+
+	// the aggregates of skipped chunks are still updated, in case an earlier run
+	// was interrupted before updating them
+	var assetStats, tradeAggregations int
+	err = tt.HorizonSession().GetRaw(&assetStats, "SELECT COUNT(*) FROM asset_stats")
+	tt.Require.NoError(err)
+	tt.Require.NotZero(assetStats)
+	err = tt.HorizonSession().GetRaw(&tradeAggregations, "SELECT COUNT(*) FROM history_trade_aggregations")
+	tt.Require.NoError(err)
+	tt.Require.NotZero(tradeAggregations)
+
+	_, err = tt.HorizonSession().ExecRaw("DELETE FROM asset_stats")
+	tt.Require.NoError(err)
+	_, err = tt.HorizonSession().ExecRaw("DELETE FROM history_trade_aggregations")
+	tt.Require.NoError(err)
+
+	ingested, err = is.ReingestRangeParallel(1, 57, 10, 4, false)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(0, ingested)
+
+	var found int
+	err = tt.HorizonSession().GetRaw(&found, "SELECT COUNT(*) FROM asset_stats")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(assetStats, found)
+	err = tt.HorizonSession().GetRaw(&found, "SELECT COUNT(*) FROM history_trade_aggregations")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(tradeAggregations, found)
+
+	// an interrupted run resumes with the missing chunks
+	_, err = tt.HorizonSession().ExecRaw(
+		`DELETE FROM history_ledgers WHERE sequence BETWEEN 15 AND 17`,
+	)
+	tt.Require.NoError(err)
+
+	ingested, err = is.ReingestRangeParallel(1, 57, 10, 4, false)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(10, ingested)
+	tt.Assert.Equal(57, countLedgers())
+
+	// unless forced
+	ingested, err = is.ReingestRangeParallel(1, 57, 10, 4, true)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(57, ingested)
+
+	// invalid arguments
+	_, err = is.ReingestRangeParallel(10, 1, 10, 4, false)
+	tt.Assert.Error(err)
+	_, err = is.ReingestRangeParallel(1, 10, 0, 4, false)
+	tt.Assert.Error(err)
+	_, err = is.ReingestRangeParallel(1, 10, 10, 0, false)
+	tt.Assert.Error(err)
+}
+
 // TestSystem_newCursor tests the ledger that newCursor picks to start
 // ingestion from in various scenarios.
 func TestSystem_newCursor(t *testing.T) {
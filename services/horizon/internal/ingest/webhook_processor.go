@@ -0,0 +1,82 @@
+package ingest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/stellar/go/support/errors"
+)
+
+var _ BatchProcessor = &WebhookProcessor{}
+
+// webhookTimeout is how long a webhook has to respond to each event before
+// the delivery is considered failed.
+const webhookTimeout = 10 * time.Second
+
+// NewWebhookProcessor returns a processor that posts events to `url`.
+func NewWebhookProcessor(url string) *WebhookProcessor {
+	return &WebhookProcessor{
+		URL:    url,
+		Client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// ProcessLedger implements Processor.
+func (p *WebhookProcessor) ProcessLedger(event LedgerEvent) error {
+	return p.post("ledger", event)
+}
+
+// ProcessTransaction implements Processor.
+func (p *WebhookProcessor) ProcessTransaction(event TransactionEvent) error {
+	return p.post("transaction", event)
+}
+
+// ProcessOperation implements Processor.
+func (p *WebhookProcessor) ProcessOperation(event OperationEvent) error {
+	return p.post("operation", event)
+}
+
+// ProcessEffect implements Processor.
+func (p *WebhookProcessor) ProcessEffect(event EffectEvent) error {
+	return p.post("effect", event)
+}
+
+// ProcessTrade implements Processor.
+func (p *WebhookProcessor) ProcessTrade(event TradeEvent) error {
+	return p.post("trade", event)
+}
+
+// ProcessBatch implements BatchProcessor, posting the events as a JSON array.
+func (p *WebhookProcessor) ProcessBatch(events []Event) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal events")
+	}
+
+	return p.send(body)
+}
+
+func (p *WebhookProcessor) post(typ string, event interface{}) error {
+	body, err := marshalEvent(typ, event)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event")
+	}
+
+	return p.send(body)
+}
+
+func (p *WebhookProcessor) send(body []byte) error {
+	resp, err := p.Client.Post(p.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to post event")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
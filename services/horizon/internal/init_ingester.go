@@ -24,6 +24,35 @@ func initIngester(app *App) {
 
 	app.ingester.SkipCursorUpdate = app.config.SkipCursorUpdate
 	app.ingester.HistoryRetentionCount = app.config.HistoryRetentionCount
+	app.ingester.GapCheckInterval = app.config.GapCheckInterval
+	app.ingester.GapHealLimit = app.config.GapHealLimit
+
+	filter, err := ingest.NewFilter(
+		app.config.FilterAccounts,
+		app.config.FilterAssets,
+		app.config.FilterOperationTypes,
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	app.ingester.Filter = filter
+
+	if app.config.IngestEventsFile != "" {
+		p, err := ingest.NewFileProcessor(app.config.IngestEventsFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		app.ingester.Processors = append(app.ingester.Processors, p)
+	}
+
+	if app.config.IngestWebhookURL != "" {
+		p := ingest.NewProcessorQueue(
+			ingest.NewWebhookProcessor(app.config.IngestWebhookURL),
+			ingest.DefaultProcessorQueueSize,
+		)
+		p.Dropped = app.ingester.Metrics.DroppedEventsMeter
+		app.ingester.Processors = append(app.ingester.Processors, p)
+	}
 }
 
 func init() {
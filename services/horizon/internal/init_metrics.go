@@ -35,6 +35,16 @@ func initIngesterMetrics(app *App) {
 		app.ingester.Metrics.IngestLedgerTimer)
 	app.metrics.Register("ingester.clear_ledger",
 		app.ingester.Metrics.ClearLedgerTimer)
+	app.metrics.Register("ingester.gaps",
+		app.ingester.Metrics.GapsGauge)
+	app.metrics.Register("ingester.missing_ledgers",
+		app.ingester.Metrics.MissingLedgersGauge)
+	app.metrics.Register("ingester.healed_gaps",
+		app.ingester.Metrics.HealedGapsCounter)
+	app.metrics.Register("ingester.dropped_events",
+		app.ingester.Metrics.DroppedEventsMeter)
+	app.metrics.Register("ingester.dropped_order_book_snapshots",
+		app.ingester.Metrics.DroppedOrderBookSnapshotsMeter)
 }
 
 func initLogMetrics(app *App) {
@@ -76,6 +76,7 @@ func initWebActions(app *App) {
 	r := app.web.router
 	r.Get("/", &RootAction{})
 	r.Get("/metrics", &MetricsAction{})
+	r.Get("/health/gaps", &HealthGapsAction{})
 
 	// ledger actions
 	r.Get("/ledgers", &LedgerIndexAction{})
@@ -86,6 +87,7 @@ func initWebActions(app *App) {
 	r.Get("/ledgers/:ledger_id/effects", &EffectIndexAction{})
 
 	// account actions
+	r.Get("/accounts", &AccountBatchAction{})
 	r.Get("/accounts/:id", &AccountShowAction{})
 	r.Get("/accounts/:account_id/transactions", &TransactionIndexAction{})
 	r.Get("/accounts/:account_id/operations", &OperationIndexAction{})
@@ -94,35 +96,63 @@ func initWebActions(app *App) {
 	r.Get("/accounts/:account_id/offers", &OffersByAccountAction{})
 	r.Get("/accounts/:account_id/trades", &TradeEffectIndexAction{})
 	r.Get("/accounts/:account_id/data/:key", &DataShowAction{})
+	r.Get("/accounts/:account_id/balances", &AccountBalancesAction{})
+	r.Get("/accounts/:account_id/balance_history", &AccountBalanceHistoryAction{})
+	r.Get("/accounts/:account_id/signers", &AccountSignersAction{})
+	r.Get("/accounts/:account_id/signer_history", &AccountSignerHistoryAction{})
+	r.Get("/accounts/:account_id/created_by", &AccountCreatedByAction{})
+	r.Get("/accounts/:account_id/created_accounts", &AccountCreatedAccountsAction{})
 
 	// transaction history actions
-	r.Get("/transactions", &TransactionIndexAction{})
+	r.Get("/transactions", queryParamSwitch{
+		param:   "hashes",
+		with:    &TransactionBatchAction{},
+		without: &TransactionIndexAction{},
+	})
 	r.Get("/transactions/:id", &TransactionShowAction{})
 	r.Get("/transactions/:tx_id/operations", &OperationIndexAction{})
 	r.Get("/transactions/:tx_id/payments", &PaymentsIndexAction{})
 	r.Get("/transactions/:tx_id/effects", &EffectIndexAction{})
+	r.Get("/transactions/:tx_id/changes", &TransactionChangesAction{})
 
 	// operation actions
-	r.Get("/operations", &OperationIndexAction{})
+	r.Get("/operations", queryParamSwitch{
+		param:   "ids",
+		with:    &OperationBatchAction{},
+		without: &OperationIndexAction{},
+	})
 	r.Get("/operations/:id", &OperationShowAction{})
 	r.Get("/operations/:op_id/effects", &EffectIndexAction{})
+	r.Get("/operations/:op_id/changes", &OperationChangesAction{})
 
 	r.Get("/payments", &PaymentsIndexAction{})
 	r.Get("/effects", &EffectIndexAction{})
 
+	// ledger entry actions
+	r.Get("/ledger_entries", &LedgerEntryShowAction{})
+	r.Get("/trustlines/:account_id/:asset_code/:asset_issuer", &TrustlineShowAction{})
+
 	// trading related endpoints
 	r.Get("/trades", &TradeIndexAction{})
 	r.Get("/trade_aggregations", &TradeAggregateIndexAction{})
-	r.Get("/offers/:id", &NotImplementedAction{})
+	r.Get("/offers", &OfferIndexAction{})
+	r.Get("/offers/:id", &OfferShowAction{})
+	r.Get("/offers/:offer_id/history", &OfferHistoryAction{})
 	r.Get("/offers/:offer_id/trades", &TradeIndexAction{})
 	r.Get("/order_book", &OrderBookShowAction{})
+	r.Get("/order_book/history", &OrderBookHistoryAction{})
+	r.Get("/ticker", &TickerIndexAction{})
+	r.Get("/ticker/:base/:counter", &TickerShowAction{})
 
 	// Transaction submission API
 	r.Post("/transactions", &TransactionCreateAction{})
+	r.Post("/transactions/simulate", &TransactionSimulateAction{})
 	r.Get("/paths", &PathIndexAction{})
+	r.Get("/fee_stats", &FeeStatsAction{})
 
 	// Asset related endpoints
 	r.Get("/assets", &AssetsAction{})
+	r.Get("/assets/:asset_code/:asset_issuer/holders", &AssetHoldersAction{})
 
 	// friendbot
 	r.Post("/friendbot", &FriendbotAction{})
@@ -131,6 +161,25 @@ func initWebActions(app *App) {
 	r.NotFound(&NotFoundAction{})
 }
 
+// queryParamSwitch routes a request to `with` when its query string has a
+// value for `param`, and to `without` otherwise.  It lets a batch lookup share
+// the path of a collection action.
+type queryParamSwitch struct {
+	param   string
+	with    web.Handler
+	without web.Handler
+}
+
+// ServeHTTPC is a method for web.Handler
+func (s queryParamSwitch) ServeHTTPC(c web.C, w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get(s.param) != "" {
+		s.with.ServeHTTPC(c, w, r)
+		return
+	}
+
+	s.without.ServeHTTPC(c, w, r)
+}
+
 func initWebRateLimiter(app *App) {
 	rateLimitStore := store.NewMemStore(1000)
 
@@ -0,0 +1,3 @@
+// Package export contains the streaming CSV and newline-delimited JSON exports
+// used by horizon to serve the records of many pages in a single response.
+package export
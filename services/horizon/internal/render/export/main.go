@@ -0,0 +1,224 @@
+package export
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/stellar/go/services/horizon/internal/render"
+)
+
+// encoder writes records to an export in a single format.
+type encoder interface {
+	// SetColumns fixes the columns of the export, for formats that have them.
+	SetColumns(columns []string)
+	// Encode writes `record` to the export.
+	Encode(record interface{}) error
+	// Flush writes any buffered data to the underlying writer.
+	Flush() error
+}
+
+// newEncoder returns the encoder for `contentType`, writing to `w`.
+func newEncoder(contentType string, w io.Writer) encoder {
+	switch contentType {
+	case render.MimeCSV:
+		return &csvEncoder{w: csv.NewWriter(w)}
+	case render.MimeNDJSON:
+		return &ndjsonEncoder{w: json.NewEncoder(w)}
+	default:
+		panic(fmt.Sprintf("unsupported export content type: %s", contentType))
+	}
+}
+
+// ndjsonEncoder writes every record as a line of JSON.
+type ndjsonEncoder struct {
+	w *json.Encoder
+}
+
+func (e *ndjsonEncoder) SetColumns(columns []string) {}
+
+func (e *ndjsonEncoder) Encode(record interface{}) error {
+	return e.w.Encode(record)
+}
+
+func (e *ndjsonEncoder) Flush() error {
+	return nil
+}
+
+// csvEncoder writes every record as a row of a CSV file.  The columns are named
+// by the dot separated paths of the fields of the exported records, as
+// returned by Columns, and are written as the header row.  When no columns are
+// set, they are derived from the fields of the first record, which only suits
+// exports whose records always have the same fields.
+type csvEncoder struct {
+	w       *csv.Writer
+	columns []string
+	started bool
+}
+
+func (e *csvEncoder) SetColumns(columns []string) {
+	e.columns = columns
+}
+
+func (e *csvEncoder) Encode(record interface{}) error {
+	fields, err := Flatten(record)
+	if err != nil {
+		return err
+	}
+
+	if !e.started {
+		if e.columns == nil {
+			e.columns = make([]string, 0, len(fields))
+			for column := range fields {
+				e.columns = append(e.columns, column)
+			}
+			sort.Strings(e.columns)
+		}
+
+		err = e.w.Write(e.columns)
+		if err != nil {
+			return err
+		}
+		e.started = true
+	}
+
+	row := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		row[i] = fields[column]
+	}
+
+	return e.w.Write(row)
+}
+
+func (e *csvEncoder) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// Columns returns the columns of a CSV export of records of the types of
+// `prototypes`: the union of the dot separated paths of the fields of their
+// JSON forms, sorted by name.  The columns are derived from the types rather
+// than the values of the prototypes, such that fields omitted from the JSON
+// form when empty are included.  Links are omitted.
+func Columns(prototypes ...interface{}) []string {
+	set := map[string]bool{}
+	for _, prototype := range prototypes {
+		columns(set, nil, reflect.TypeOf(prototype))
+	}
+
+	result := make([]string, 0, len(set))
+	for column := range set {
+		result = append(result, column)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func columns(dest map[string]bool, path []string, typ reflect.Type) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	// values that marshal themselves, and anything other than a struct, are
+	// kept whole, as they are by Flatten.
+	if typ.Kind() != reflect.Struct || marshalsItself(typ) {
+		dest[strings.Join(path, ".")] = true
+		return
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if name == "" && field.Anonymous {
+			// the fields of embedded structs are promoted into their parent
+			columns(dest, path, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if len(path) == 0 && name == "_links" {
+			continue
+		}
+
+		columns(dest, append(path[:len(path):len(path)], name), field.Type)
+	}
+}
+
+func marshalsItself(typ reflect.Type) bool {
+	ptr := reflect.PtrTo(typ)
+	return typ.Implements(jsonMarshalerType) ||
+		ptr.Implements(jsonMarshalerType) ||
+		typ.Implements(textMarshalerType) ||
+		ptr.Implements(textMarshalerType)
+}
+
+// Flatten returns the fields of the JSON form of `record` keyed by their dot
+// separated paths, omitting its links.  Arrays are kept in their JSON form and
+// nulls become empty strings.
+func Flatten(record interface{}) (map[string]string, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	err = dec.Decode(&parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]string{}
+	delete(parsed, "_links")
+	err = flatten(result, nil, parsed)
+	return result, err
+}
+
+func flatten(dest map[string]string, path []string, value interface{}) error {
+	key := strings.Join(path, ".")
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for name, field := range value {
+			err := flatten(dest, append(path, name), field)
+			if err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		dest[key] = string(data)
+	case nil:
+		dest[key] = ""
+	default:
+		dest[key] = fmt.Sprint(value)
+	}
+
+	return nil
+}
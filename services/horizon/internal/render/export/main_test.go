@@ -0,0 +1,137 @@
+package export
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stellar/go/services/horizon/internal/render"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/services/horizon/internal/test"
+)
+
+type testRecord struct {
+	Links struct {
+		Self hal.Link `json:"self"`
+	} `json:"_links"`
+	ID     string   `json:"id"`
+	Amount int      `json:"amount"`
+	Memo   *string  `json:"memo"`
+	Tags   []string `json:"tags"`
+	Asset  struct {
+		Code string `json:"code"`
+	} `json:"asset"`
+}
+
+func (r testRecord) PagingToken() string {
+	return r.ID
+}
+
+type otherRecord struct {
+	testRecord
+	Extra string `json:"extra,omitempty"`
+}
+
+func TestExportPackage(t *testing.T) {
+	ctx, log := test.ContextWithLogBuffer()
+
+	rec := func(id string) testRecord {
+		var r testRecord
+		r.Links.Self = hal.NewLink("/records/" + id)
+		r.ID = id
+		r.Amount = 10
+		r.Tags = []string{"a", "b"}
+		r.Asset.Code = "USD"
+		return r
+	}
+
+	Convey("export.Flatten", t, func() {
+		fields, err := Flatten(rec("1"))
+		So(err, ShouldBeNil)
+		So(fields, ShouldResemble, map[string]string{
+			"id":         "1",
+			"amount":     "10",
+			"memo":       "",
+			"tags":       `["a","b"]`,
+			"asset.code": "USD",
+		})
+	})
+
+	Convey("export.Columns", t, func() {
+		So(Columns(testRecord{}), ShouldResemble, []string{
+			"amount", "asset.code", "id", "memo", "tags",
+		})
+
+		// fields of embedded structs, and fields omitted when empty, are included
+		So(Columns(testRecord{}, otherRecord{}), ShouldResemble, []string{
+			"amount", "asset.code", "extra", "id", "memo", "tags",
+		})
+	})
+
+	Convey("CSV exports", t, func() {
+		w := httptest.NewRecorder()
+		stream := NewStream(ctx, w, render.MimeCSV)
+		stream.Send(rec("1"))
+		stream.Send(rec("2"))
+		stream.Done()
+
+		So(w.Header().Get("Content-Type"), ShouldEqual, "text/csv; charset=utf-8")
+		So(w.Body.String(), ShouldEqual, "amount,asset.code,id,memo,tags\n"+
+			"10,USD,1,,\"[\"\"a\"\",\"\"b\"\"]\"\n"+
+			"10,USD,2,,\"[\"\"a\"\",\"\"b\"\"]\"\n")
+		So(stream.SentCount(), ShouldEqual, 2)
+		So(stream.Cursor(), ShouldEqual, "2")
+		So(stream.IsDone(), ShouldBeTrue)
+	})
+
+	Convey("CSV exports of mixed records", t, func() {
+		w := httptest.NewRecorder()
+		stream := NewStream(ctx, w, render.MimeCSV)
+		stream.SetColumns(Columns(testRecord{}, otherRecord{}))
+		stream.Send(rec("1"))
+		stream.Send(otherRecord{testRecord: rec("2"), Extra: "x"})
+		stream.Done()
+
+		So(w.Body.String(), ShouldEqual, "amount,asset.code,extra,id,memo,tags\n"+
+			"10,USD,,1,,\"[\"\"a\"\",\"\"b\"\"]\"\n"+
+			"10,USD,x,2,,\"[\"\"a\"\",\"\"b\"\"]\"\n")
+	})
+
+	Convey("NDJSON exports", t, func() {
+		w := httptest.NewRecorder()
+		stream := NewStream(ctx, w, render.MimeNDJSON)
+		stream.SetLimit(1)
+		stream.Send(rec("1"))
+		So(stream.IsDone(), ShouldBeTrue)
+
+		// records beyond the limit are dropped
+		stream.Send(rec("2"))
+		stream.Flush()
+
+		So(w.Header().Get("Content-Type"), ShouldEqual, "application/x-ndjson; charset=utf-8")
+		So(w.Body.String(), ShouldStartWith, `{"_links":`)
+		So(w.Body.String(), ShouldContainSubstring, `"id":"1"`)
+		So(w.Body.String(), ShouldNotContainSubstring, `"id":"2"`)
+	})
+
+	Convey("Errors end NDJSON exports", t, func() {
+		w := httptest.NewRecorder()
+		stream := NewStream(ctx, w, render.MimeNDJSON)
+		stream.Send(rec("1"))
+		stream.Err(errors.New("busted"))
+
+		So(w.Body.String(), ShouldEndWith, "{\"error\":\"busted\"}\n")
+		So(stream.IsDone(), ShouldBeTrue)
+		So(log.String(), ShouldContainSubstring, "busted")
+	})
+
+	Convey("Empty exports still respond", t, func() {
+		w := httptest.NewRecorder()
+		stream := NewStream(ctx, w, render.MimeCSV)
+		stream.Done()
+
+		So(w.Code, ShouldEqual, 200)
+		So(w.Body.String(), ShouldEqual, "")
+	})
+}
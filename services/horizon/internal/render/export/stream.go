@@ -0,0 +1,143 @@
+package export
+
+import (
+	"net/http"
+
+	"github.com/stellar/go/services/horizon/internal/log"
+	"github.com/stellar/go/services/horizon/internal/render"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"golang.org/x/net/context"
+)
+
+// Stream represents an export that records can be written to, page by page.
+type Stream interface {
+	Send(hal.Pageable)
+	SentCount() int
+	Cursor() string
+	Flush()
+	Done()
+	SetLimit(limit int)
+	SetColumns(columns []string)
+	IsDone() bool
+	Err(error)
+}
+
+// NewStream creates a new export of type `contentType` against the provided
+// response writer.
+func NewStream(ctx context.Context, w http.ResponseWriter, contentType string) Stream {
+	return &stream{
+		ctx:         ctx,
+		w:           w,
+		contentType: contentType,
+		enc:         newEncoder(contentType, w),
+	}
+}
+
+type stream struct {
+	ctx         context.Context
+	w           http.ResponseWriter
+	contentType string
+	enc         encoder
+	started     bool
+	done        bool
+	sent        int
+	limit       int
+	cursor      string
+}
+
+// Send writes `record` to the export, unless the export is done.
+func (s *stream) Send(record hal.Pageable) {
+	if s.IsDone() {
+		return
+	}
+
+	s.start()
+
+	err := s.enc.Encode(record)
+	if err != nil {
+		s.Err(err)
+		return
+	}
+
+	s.sent++
+	s.cursor = record.PagingToken()
+}
+
+// SentCount returns the number of records written to the export.
+func (s *stream) SentCount() int {
+	return s.sent
+}
+
+// Cursor returns the paging token of the last record written to the export.
+func (s *stream) Cursor() string {
+	return s.cursor
+}
+
+// SetLimit caps the number of records written to the export.  A limit of 0
+// means the export is unlimited.
+func (s *stream) SetLimit(limit int) {
+	s.limit = limit
+}
+
+// SetColumns fixes the columns of CSV exports, as returned by Columns.  It must
+// be called before any record is written to the export.
+func (s *stream) SetColumns(columns []string) {
+	s.enc.SetColumns(columns)
+}
+
+// Flush writes the buffered data of the export to the client.
+func (s *stream) Flush() {
+	if !s.started {
+		return
+	}
+
+	err := s.enc.Flush()
+	if err != nil {
+		log.Ctx(s.ctx).Error(err)
+	}
+
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Done finishes the export, flushing any of its buffered data.
+func (s *stream) Done() {
+	s.start()
+	s.Flush()
+	s.done = true
+}
+
+// IsDone returns true once the export is finished or has reached its limit.
+func (s *stream) IsDone() bool {
+	if s.limit == 0 {
+		return s.done
+	}
+
+	return s.done || s.sent >= s.limit
+}
+
+// Err aborts the export.  The response has already begun, so the error is
+// logged, and reported as the last line of newline-delimited JSON exports.
+func (s *stream) Err(err error) {
+	log.Ctx(s.ctx).Error(err)
+
+	if s.contentType == render.MimeNDJSON {
+		s.enc.Encode(map[string]string{"error": err.Error()})
+	}
+
+	s.Flush()
+	s.done = true
+}
+
+// start writes the headers of the response, if they have not been written yet.
+func (s *stream) start() {
+	if s.started {
+		return
+	}
+
+	s.w.Header().Set("Content-Type", s.contentType+"; charset=utf-8")
+	s.w.Header().Set("Access-Control-Allow-Origin", "*")
+	s.w.WriteHeader(http.StatusOK)
+	s.started = true
+}
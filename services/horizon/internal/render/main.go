@@ -9,9 +9,22 @@ import (
 )
 
 // Negotiate inspects the Accept header of the provided request and determines
-// what the most appropriate response type should be.  Defaults to HAL.
+// what the most appropriate response type should be.  Defaults to HAL.  A
+// `format=xdr` query parameter takes precedence over the Accept header,
+// requesting MimeRaw.
 func Negotiate(ctx context.Context, r *http.Request) string {
-	alternatives := []string{MimeHal, MimeJSON, MimeEventStream, MimeRaw}
+	if r.URL.Query().Get("format") == "xdr" {
+		return MimeRaw
+	}
+
+	alternatives := []string{
+		MimeHal,
+		MimeJSON,
+		MimeEventStream,
+		MimeRaw,
+		MimeCSV,
+		MimeNDJSON,
+	}
 	accept := r.Header.Get("Accept")
 
 	if accept == "" {
@@ -28,6 +28,21 @@ func TestRenderPackage(t *testing.T) {
 			So(Negotiate(ctx, r), ShouldEqual, MimeHal)
 		})
 
+		Convey("Negotiates exports", func() {
+			r.Header.Set("Accept", "text/csv")
+			So(Negotiate(ctx, r), ShouldEqual, MimeCSV)
+
+			r.Header.Set("Accept", "application/x-ndjson")
+			So(Negotiate(ctx, r), ShouldEqual, MimeNDJSON)
+		})
+
+		Convey("Negotiates raw xdr from the format param", func() {
+			r, err := http.NewRequest("GET", "/ledgers/1?format=xdr", nil)
+			So(err, ShouldBeNil)
+			r.Header.Add("Accept", "application/hal+json")
+			So(Negotiate(ctx, r), ShouldEqual, MimeRaw)
+		})
+
 		Convey("Defaults to HAL", func() {
 			r.Header.Set("Accept", "")
 			So(Negotiate(ctx, r), ShouldEqual, MimeHal)
@@ -2,12 +2,16 @@ package render
 
 const (
 
+	//MimeCSV is the mime type for "text/csv"
+	MimeCSV = "text/csv"
 	//MimeEventStream is the mime type for "text/event-stream"
 	MimeEventStream = "text/event-stream"
 	//MimeHal is the mime type for "application/hal+json"
 	MimeHal = "application/hal+json"
 	//MimeJSON is the mime type for "application/json"
 	MimeJSON = "application/json"
+	//MimeNDJSON is the mime type for "application/x-ndjson"
+	MimeNDJSON = "application/x-ndjson"
 	//MimeProblem is the mime type for application/problem+json"
 	MimeProblem = "application/problem+json"
 	//MimeRaw is the mime type for "application/octet-stream"
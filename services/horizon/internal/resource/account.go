@@ -73,6 +73,11 @@ func (this *Account) Populate(
 	return
 }
 
+// PagingToken implementation for hal.Pageable
+func (this Account) PagingToken() string {
+	return this.PT
+}
+
 // MustGetData returns decoded value for a given key. If the key does
 // not exist, empty slice will be returned. If there is an error
 // decoding a value, it will panic.
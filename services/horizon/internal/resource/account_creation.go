@@ -0,0 +1,32 @@
+package resource
+
+import (
+	"fmt"
+
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/httpx"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the resource from a row of the history_account_creations
+// table.
+func (res *AccountCreation) Populate(
+	ctx context.Context,
+	row history.AccountCreation,
+) {
+	res.PT = fmt.Sprintf("%d", row.OperationID)
+	res.AccountID = row.Address
+	res.CreatedBy = row.CreatedBy
+	res.MergedInto = row.MergedInto.String
+
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+	res.Links.Account = lb.Link("/accounts", row.Address)
+	res.Links.CreatedBy = lb.Link("/accounts", row.CreatedBy)
+	res.Links.Operation = lb.Link("/operations", res.PT)
+}
+
+// PagingToken implementation for hal.Pageable
+func (res AccountCreation) PagingToken() string {
+	return res.PT
+}
@@ -2,6 +2,7 @@ package resource
 
 import (
 	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
 )
 
 func (this *AccountThresholds) Populate(row core.Account) {
@@ -9,3 +10,10 @@ func (this *AccountThresholds) Populate(row core.Account) {
 	this.MedThreshold = row.Thresholds[2]
 	this.HighThreshold = row.Thresholds[3]
 }
+
+// PopulateFromState fills out the thresholds from a signer history state.
+func (this *AccountThresholds) PopulateFromState(state history.SignerState) {
+	this.LowThreshold = state.LowThreshold
+	this.MedThreshold = state.MedThreshold
+	this.HighThreshold = state.HighThreshold
+}
@@ -0,0 +1,31 @@
+package resource
+
+import (
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/httpx"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the details of an asset holder from one of the
+// trustlines to the asset, using a paging token suitable for `orderBy`.
+func (res *AssetHolder) Populate(
+	ctx context.Context,
+	row core.Trustline,
+	orderBy string,
+) {
+	res.PT = row.HolderPagingToken(orderBy)
+	res.AccountID = row.Accountid
+	res.Balance = amount.String(row.Balance)
+	res.Limit = amount.String(row.Tlimit)
+	res.Authorized = row.IsAuthorized()
+
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+	res.Links.Account = lb.Link("/accounts", row.Accountid)
+}
+
+// PagingToken implementation for hal.Pageable
+func (res AssetHolder) PagingToken() string {
+	return res.PT
+}
@@ -0,0 +1,58 @@
+package resource
+
+import (
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/httpx"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the balances of an account from the latest snapshot of
+// each of its balances.
+func (res *AccountBalances) Populate(
+	ctx context.Context,
+	address string,
+	ledger int32,
+	latest []history.BalanceSnapshot,
+) {
+	res.AccountID = address
+	res.Ledger = ledger
+	res.Balances = make([]Balance, len(latest))
+	for i, point := range latest {
+		res.Balances[i].Type = point.AssetType
+		res.Balances[i].Code = point.AssetCode
+		res.Balances[i].Issuer = point.AssetIssuer
+		res.Balances[i].Balance = amount.String(point.Balance)
+	}
+
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+	res.Links.Self = lb.Linkf("/accounts/%s/balances?at_ledger=%d", address, ledger)
+	res.Links.Account = lb.Link("/accounts", address)
+	res.Links.History = lb.Link("/accounts", address, "balance_history")
+	res.Links.History.Href += "{?asset_type,asset_code,asset_issuer,cursor,limit,order}"
+	res.Links.History.PopulateTemplated()
+}
+
+// Populate fills out the details of a balance history point.
+func (res *BalanceHistoryPoint) Populate(
+	ctx context.Context,
+	point history.BalanceSnapshot,
+) {
+	res.ID = point.PagingToken()
+	res.PT = point.PagingToken()
+	res.Ledger = point.LedgerSequence
+	res.Change = amount.String(point.Change)
+	res.Balance = amount.String(point.Balance)
+	res.Type = point.AssetType
+	res.Code = point.AssetCode
+	res.Issuer = point.AssetIssuer
+
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+	res.Links.Ledger = lb.Linkf("/ledgers/%d", res.Ledger)
+}
+
+// PagingToken implementation for hal.Pageable
+func (res BalanceHistoryPoint) PagingToken() string {
+	return res.PT
+}
@@ -0,0 +1,29 @@
+package resource
+
+import (
+	"strconv"
+
+	"github.com/stellar/go/services/horizon/internal/feestats"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the fee statistics from a cached snapshot
+func (res *FeeStats) Populate(ctx context.Context, state feestats.State) {
+	res.LastLedger = state.LastLedger
+	res.LastLedgerBaseFee = state.LastLedgerBaseFee
+	res.LedgerCount = state.LedgerCount
+	res.LedgerCapacityUsage = strconv.FormatFloat(state.LedgerCapacityUsage, 'f', 2, 64)
+	res.MinAcceptedFee = state.Min
+	res.ModeAcceptedFee = state.Mode
+	res.P10AcceptedFee = state.P10
+	res.P20AcceptedFee = state.P20
+	res.P30AcceptedFee = state.P30
+	res.P40AcceptedFee = state.P40
+	res.P50AcceptedFee = state.P50
+	res.P60AcceptedFee = state.P60
+	res.P70AcceptedFee = state.P70
+	res.P80AcceptedFee = state.P80
+	res.P90AcceptedFee = state.P90
+	res.P95AcceptedFee = state.P95
+	res.P99AcceptedFee = state.P99
+}
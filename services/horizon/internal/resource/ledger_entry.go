@@ -0,0 +1,194 @@
+package resource
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/meta"
+	"github.com/stellar/go/xdr"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the resource from the state of a ledger entry.
+func (res *LedgerEntry) Populate(ctx context.Context, entry xdr.LedgerEntry) (err error) {
+	res.Type = LedgerEntryTypeNames[entry.Data.Type]
+	res.LastModifiedLedger = int32(entry.LastModifiedLedgerSeq)
+
+	switch entry.Data.Type {
+	case xdr.LedgerEntryTypeAccount:
+		res.Account = &LedgerEntryAccount{}
+		res.Account.Populate(ctx, entry.Data.MustAccount())
+	case xdr.LedgerEntryTypeTrustline:
+		res.Trustline = &LedgerEntryTrustline{}
+		err = res.Trustline.Populate(ctx, entry.Data.MustTrustLine())
+	case xdr.LedgerEntryTypeOffer:
+		res.Offer = &LedgerEntryOffer{}
+		err = res.Offer.Populate(ctx, entry.Data.MustOffer())
+	case xdr.LedgerEntryTypeData:
+		res.Data = &LedgerEntryData{}
+		res.Data.Populate(ctx, entry.Data.MustData())
+	default:
+		err = fmt.Errorf("unknown ledger entry type: %v", entry.Data.Type)
+	}
+	if err != nil {
+		return
+	}
+
+	res.XDR, err = xdr.MarshalBase64(entry)
+	return
+}
+
+// Populate fills out the resource from the body of an account ledger entry.
+// As in the account resource, the master key is included in the signers.
+func (res *LedgerEntryAccount) Populate(ctx context.Context, entry xdr.AccountEntry) {
+	res.AccountID = entry.AccountId.Address()
+	res.Balance = amount.String(entry.Balance)
+	res.Sequence = fmt.Sprintf("%d", entry.SeqNum)
+	res.SubentryCount = int32(entry.NumSubEntries)
+	if entry.InflationDest != nil {
+		res.InflationDestination = entry.InflationDest.Address()
+	}
+	res.HomeDomain = string(entry.HomeDomain)
+
+	res.Thresholds.LowThreshold = entry.Thresholds[1]
+	res.Thresholds.MedThreshold = entry.Thresholds[2]
+	res.Thresholds.HighThreshold = entry.Thresholds[3]
+
+	flags := xdr.AccountFlags(entry.Flags)
+	res.Flags.AuthRequired = (flags & xdr.AccountFlagsAuthRequiredFlag) != 0
+	res.Flags.AuthRevocable = (flags & xdr.AccountFlagsAuthRevocableFlag) != 0
+
+	res.Signers = make([]Signer, 0, len(entry.Signers)+1)
+	for _, signer := range entry.Signers {
+		key := signer.Key.Address()
+		res.Signers = append(res.Signers, Signer{
+			PublicKey: key,
+			Weight:    int32(signer.Weight),
+			Key:       key,
+			Type:      MustKeyTypeFromAddress(key),
+		})
+	}
+
+	res.Signers = append(res.Signers, Signer{
+		PublicKey: res.AccountID,
+		Weight:    int32(entry.Thresholds[0]),
+		Key:       res.AccountID,
+		Type:      MustKeyTypeFromAddress(res.AccountID),
+	})
+}
+
+// Populate fills out the resource from the body of a data ledger entry.
+func (res *LedgerEntryData) Populate(ctx context.Context, entry xdr.DataEntry) {
+	res.AccountID = entry.AccountId.Address()
+	res.Name = string(entry.DataName)
+	res.Value = base64.StdEncoding.EncodeToString(entry.DataValue)
+}
+
+// Populate fills out the resource from the body of an offer ledger entry.
+func (res *LedgerEntryOffer) Populate(ctx context.Context, entry xdr.OfferEntry) (err error) {
+	res.ID = int64(entry.OfferId)
+	res.Seller = entry.SellerId.Address()
+
+	err = res.Selling.Populate(ctx, entry.Selling)
+	if err != nil {
+		return
+	}
+
+	err = res.Buying.Populate(ctx, entry.Buying)
+	if err != nil {
+		return
+	}
+
+	res.Amount = amount.String(entry.Amount)
+	res.PriceR.N = int32(entry.Price.N)
+	res.PriceR.D = int32(entry.Price.D)
+	res.Price = entry.Price.String()
+	res.Passive = (xdr.OfferEntryFlags(entry.Flags) & xdr.OfferEntryFlagsPassiveFlag) != 0
+	return
+}
+
+// Populate fills out the resource from the body of a trustline ledger entry.
+func (res *LedgerEntryTrustline) Populate(ctx context.Context, entry xdr.TrustLineEntry) (err error) {
+	res.AccountID = entry.AccountId.Address()
+
+	err = res.Asset.Populate(ctx, entry.Asset)
+	if err != nil {
+		return
+	}
+
+	res.Balance = amount.String(entry.Balance)
+	res.Limit = amount.String(entry.Limit)
+	res.Authorized = (xdr.TrustLineFlags(entry.Flags) & xdr.TrustLineFlagsAuthorizedFlag) != 0
+	return
+}
+
+// Populate fills out the resource from `change`, one of the changes recorded
+// in `bundle` for the operation at index `opidx`, or for the fee of the
+// transaction when `opidx` is -1.
+func (res *LedgerEntryChange) Populate(
+	ctx context.Context,
+	bundle *meta.Bundle,
+	change xdr.LedgerEntryChange,
+	opidx int,
+) error {
+	key := change.LedgerKey()
+	res.Type = LedgerEntryChangeTypeNames[change.Type]
+	res.EntryType = LedgerEntryTypeNames[key.Type]
+
+	before, err := bundle.StateBefore(key, opidx)
+	if err != nil {
+		return err
+	}
+
+	if before != nil {
+		res.Before = &LedgerEntry{}
+		err = res.Before.Populate(ctx, *before)
+		if err != nil {
+			return err
+		}
+	}
+
+	after, err := bundle.StateAfter(key, opidx)
+	if err != nil {
+		return err
+	}
+
+	if after != nil {
+		res.After = &LedgerEntry{}
+		err = res.After.Populate(ctx, *after)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// populateChanges converts the changes recorded in `bundle` for the operation
+// at index `opidx`, or for the fee when `opidx` is -1, into resources.  State
+// entries only record the state prior to a change, so they are skipped.
+func populateChanges(
+	ctx context.Context,
+	bundle *meta.Bundle,
+	changes xdr.LedgerEntryChanges,
+	opidx int,
+) ([]LedgerEntryChange, error) {
+	result := make([]LedgerEntryChange, 0, len(changes))
+
+	for _, change := range changes {
+		if change.Type == xdr.LedgerEntryChangeTypeLedgerEntryState {
+			continue
+		}
+
+		var res LedgerEntryChange
+		err := res.Populate(ctx, bundle, change, opidx)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, res)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,44 @@
+package resource
+
+import (
+	"time"
+
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/httpx"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the gaps found by the search that ran at `checkedAt`.
+func (res *LedgerGaps) Populate(
+	ctx context.Context,
+	checkedAt time.Time,
+	gaps []history.LedgerGap,
+) {
+	res.CheckedAt = checkedAt
+	res.Gaps = make([]LedgerGap, len(gaps))
+	for i, gap := range gaps {
+		res.Gaps[i].Populate(gap)
+	}
+
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+	res.Links.Self = lb.Link("/health/gaps")
+}
+
+// Populate fills out a single gap.  Its reason is "missing" when the ledgers
+// of the gap are absent, "incomplete" when they were ingested without the meta
+// of their transactions, or "hash_mismatch" when the ledger at its end does
+// not follow the hash of the ledger at its start.
+func (res *LedgerGap) Populate(gap history.LedgerGap) {
+	res.Start = gap.Start
+	res.End = gap.End
+
+	switch {
+	case gap.Missing:
+		res.Reason = "missing"
+	case gap.Incomplete:
+		res.Reason = "incomplete"
+	default:
+		res.Reason = "hash_mismatch"
+	}
+}
@@ -6,12 +6,14 @@ import (
 	"time"
 
 	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/render/export"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
 	"github.com/stellar/go/services/horizon/internal/resource/base"
 	"github.com/stellar/go/services/horizon/internal/resource/effects"
 	"github.com/stellar/go/services/horizon/internal/resource/operations"
 	"github.com/stellar/go/strkey"
 	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
 	"golang.org/x/net/context"
 )
 
@@ -24,6 +26,23 @@ var KeyTypeNames = map[strkey.VersionByte]string{
 	strkey.VersionByteHashTx:    "preauth_tx",
 }
 
+// LedgerEntryTypeNames maps from the types of ledger entries into json string
+// values to use in horizon responses.
+var LedgerEntryTypeNames = map[xdr.LedgerEntryType]string{
+	xdr.LedgerEntryTypeAccount:   "account",
+	xdr.LedgerEntryTypeTrustline: "trustline",
+	xdr.LedgerEntryTypeOffer:     "offer",
+	xdr.LedgerEntryTypeData:      "data",
+}
+
+// LedgerEntryChangeTypeNames maps from the types of ledger entry changes into
+// json string values to use in horizon responses.
+var LedgerEntryChangeTypeNames = map[xdr.LedgerEntryChangeType]string{
+	xdr.LedgerEntryChangeTypeLedgerEntryCreated: "created",
+	xdr.LedgerEntryChangeTypeLedgerEntryUpdated: "updated",
+	xdr.LedgerEntryChangeTypeLedgerEntryRemoved: "removed",
+}
+
 // Account is the summary of an account
 type Account struct {
 	Links struct {
@@ -49,12 +68,56 @@ type Account struct {
 	Data                 map[string]string `json:"data"`
 }
 
+// AccountBalances represents the balances held by an account as of a given
+// ledger, as recorded in the history database.
+type AccountBalances struct {
+	Links struct {
+		Self    hal.Link `json:"self"`
+		Account hal.Link `json:"account"`
+		History hal.Link `json:"balance_history"`
+	} `json:"_links"`
+
+	AccountID string    `json:"account_id"`
+	Ledger    int32     `json:"ledger"`
+	Balances  []Balance `json:"balances"`
+}
+
+// AccountCreation represents the creation of an account by another account,
+// its funder.
+type AccountCreation struct {
+	Links struct {
+		Account   hal.Link `json:"account"`
+		CreatedBy hal.Link `json:"created_by"`
+		Operation hal.Link `json:"operation"`
+	} `json:"_links"`
+
+	PT         string `json:"paging_token"`
+	AccountID  string `json:"account_id"`
+	CreatedBy  string `json:"created_by"`
+	MergedInto string `json:"merged_into,omitempty"`
+}
+
 // AccountFlags represents the state of an account's flags
 type AccountFlags struct {
 	AuthRequired  bool `json:"auth_required"`
 	AuthRevocable bool `json:"auth_revocable"`
 }
 
+// AccountSigners represents the signers and thresholds of an account as of a
+// given ledger, reconstructed from the history database.
+type AccountSigners struct {
+	Links struct {
+		Self    hal.Link `json:"self"`
+		Account hal.Link `json:"account"`
+		History hal.Link `json:"signer_history"`
+	} `json:"_links"`
+
+	AccountID  string            `json:"account_id"`
+	Ledger     int32             `json:"ledger"`
+	Thresholds AccountThresholds `json:"thresholds"`
+	Signers    []Signer          `json:"signers"`
+}
+
 // AccountThresholds represents an accounts "thresholds", the numerical values
 // needed to satisfy the authorization of a given operation.
 type AccountThresholds struct {
@@ -66,6 +129,20 @@ type AccountThresholds struct {
 // Asset represents a single asset
 type Asset base.Asset
 
+// AssetHolder represents an account that holds a trustline to an asset,
+// along with its balance.
+type AssetHolder struct {
+	Links struct {
+		Account hal.Link `json:"account"`
+	} `json:"_links"`
+
+	PT         string `json:"paging_token"`
+	AccountID  string `json:"account_id"`
+	Balance    string `json:"balance"`
+	Limit      string `json:"limit"`
+	Authorized bool   `json:"authorized"`
+}
+
 // AssetStat represents the statistics for a single Asset
 type AssetStat struct {
 	Links struct {
@@ -86,6 +163,43 @@ type Balance struct {
 	base.Asset
 }
 
+// BalanceHistoryPoint represents the balance of a single asset held by an
+// account at the close of a ledger in which that balance changed.
+type BalanceHistoryPoint struct {
+	Links struct {
+		Ledger hal.Link `json:"ledger"`
+	} `json:"_links"`
+
+	ID      string `json:"id"`
+	PT      string `json:"paging_token"`
+	Ledger  int32  `json:"ledger"`
+	Change  string `json:"change"`
+	Balance string `json:"balance"`
+	base.Asset
+}
+
+// FeeStats represents a summary of the fees paid per operation, in stroops, by
+// the transactions included in recently closed ledgers.
+type FeeStats struct {
+	LastLedger          int32  `json:"last_ledger"`
+	LastLedgerBaseFee   int32  `json:"last_ledger_base_fee"`
+	LedgerCount         int32  `json:"ledger_count"`
+	LedgerCapacityUsage string `json:"ledger_capacity_usage"`
+	MinAcceptedFee      int64  `json:"min_accepted_fee"`
+	ModeAcceptedFee     int64  `json:"mode_accepted_fee"`
+	P10AcceptedFee      int64  `json:"p10_accepted_fee"`
+	P20AcceptedFee      int64  `json:"p20_accepted_fee"`
+	P30AcceptedFee      int64  `json:"p30_accepted_fee"`
+	P40AcceptedFee      int64  `json:"p40_accepted_fee"`
+	P50AcceptedFee      int64  `json:"p50_accepted_fee"`
+	P60AcceptedFee      int64  `json:"p60_accepted_fee"`
+	P70AcceptedFee      int64  `json:"p70_accepted_fee"`
+	P80AcceptedFee      int64  `json:"p80_accepted_fee"`
+	P90AcceptedFee      int64  `json:"p90_accepted_fee"`
+	P95AcceptedFee      int64  `json:"p95_accepted_fee"`
+	P99AcceptedFee      int64  `json:"p99_accepted_fee"`
+}
+
 // HistoryAccount is a simple resource, used for the account collection actions.
 // It provides only the "TotalOrderID" of the account and its account id.
 type HistoryAccount struct {
@@ -94,6 +208,25 @@ type HistoryAccount struct {
 	AccountID string `json:"account_id"`
 }
 
+// LedgerGaps represents the gaps found in horizon's history database by the
+// most recent search for them.
+type LedgerGaps struct {
+	Links struct {
+		Self hal.Link `json:"self"`
+	} `json:"_links"`
+	CheckedAt time.Time   `json:"checked_at"`
+	Gaps      []LedgerGap `json:"gaps"`
+}
+
+// LedgerGap represents a range of ledgers, inclusive, that is missing from
+// horizon's history database, is incomplete, or whose hashes do not form a
+// chain.
+type LedgerGap struct {
+	Start  int32  `json:"start"`
+	End    int32  `json:"end"`
+	Reason string `json:"reason"`
+}
+
 // Ledger represents a single closed ledger
 type Ledger struct {
 	Links struct {
@@ -119,11 +252,82 @@ type Ledger struct {
 	ProtocolVersion  int32     `json:"protocol_version"`
 }
 
+// LedgerEntry represents the state of a single ledger entry: an account, a
+// trustline, an offer or a data entry.  Only the attribute matching the type
+// of the entry is present.
+type LedgerEntry struct {
+	Type               string                `json:"type"`
+	LastModifiedLedger int32                 `json:"last_modified_ledger"`
+	Account            *LedgerEntryAccount   `json:"account,omitempty"`
+	Trustline          *LedgerEntryTrustline `json:"trustline,omitempty"`
+	Offer              *LedgerEntryOffer     `json:"offer,omitempty"`
+	Data               *LedgerEntryData      `json:"data,omitempty"`
+	XDR                string                `json:"xdr"`
+}
+
+// LedgerEntryAccount represents the body of an account ledger entry.
+type LedgerEntryAccount struct {
+	AccountID            string            `json:"account_id"`
+	Balance              string            `json:"balance"`
+	Sequence             string            `json:"sequence"`
+	SubentryCount        int32             `json:"subentry_count"`
+	InflationDestination string            `json:"inflation_destination,omitempty"`
+	HomeDomain           string            `json:"home_domain,omitempty"`
+	Thresholds           AccountThresholds `json:"thresholds"`
+	Flags                AccountFlags      `json:"flags"`
+	Signers              []Signer          `json:"signers"`
+}
+
+// LedgerEntryChange represents a ledger entry that was created, updated or
+// removed, along with its state before and after the change.
+type LedgerEntryChange struct {
+	Type      string       `json:"type"`
+	EntryType string       `json:"entry_type"`
+	Before    *LedgerEntry `json:"before"`
+	After     *LedgerEntry `json:"after"`
+}
+
+// LedgerEntryData represents the body of a data ledger entry.
+type LedgerEntryData struct {
+	AccountID string `json:"account_id"`
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+}
+
+// LedgerEntryOffer represents the body of an offer ledger entry.
+type LedgerEntryOffer struct {
+	ID      int64  `json:"id"`
+	Seller  string `json:"seller"`
+	Selling Asset  `json:"selling"`
+	Buying  Asset  `json:"buying"`
+	Amount  string `json:"amount"`
+	PriceR  Price  `json:"price_r"`
+	Price   string `json:"price"`
+	Passive bool   `json:"passive"`
+}
+
+// LedgerEntryTrustline represents the body of a trustline ledger entry.
+type LedgerEntryTrustline struct {
+	AccountID  string `json:"account_id"`
+	Asset      Asset  `json:"asset"`
+	Balance    string `json:"balance"`
+	Limit      string `json:"limit"`
+	Authorized bool   `json:"authorized"`
+}
+
+// NotFound marks an item of a batch lookup that could not be found, in place of
+// the item's resource.
+type NotFound struct {
+	ID       string `json:"id"`
+	NotFound bool   `json:"not_found"`
+}
+
 // Offer is the display form of an offer to trade currency.
 type Offer struct {
 	Links struct {
 		Self       hal.Link `json:"self"`
 		OfferMaker hal.Link `json:"offer_maker"`
+		History    hal.Link `json:"history"`
 	} `json:"_links"`
 
 	ID      int64  `json:"id"`
@@ -136,6 +340,43 @@ type Offer struct {
 	Price   string `json:"price"`
 }
 
+// OfferEvent represents a single change in the lifecycle of an offer, such as
+// its creation, a partial fill or its cancellation.
+type OfferEvent struct {
+	Links struct {
+		Offer     hal.Link `json:"offer"`
+		Seller    hal.Link `json:"seller"`
+		Operation hal.Link `json:"operation"`
+	} `json:"_links"`
+
+	ID              string    `json:"id"`
+	PT              string    `json:"paging_token"`
+	OfferID         int64     `json:"offer_id"`
+	Type            string    `json:"type"`
+	TypeI           int32     `json:"type_i"`
+	Seller          string    `json:"seller"`
+	Selling         Asset     `json:"selling"`
+	Buying          Asset     `json:"buying"`
+	Amount          string    `json:"amount"`
+	PriceR          Price     `json:"price_r"`
+	Price           string    `json:"price"`
+	LedgerCloseTime time.Time `json:"created_at"`
+}
+
+// OperationChanges represents the ledger entries changed by a single
+// operation.
+type OperationChanges struct {
+	Links struct {
+		Self        hal.Link `json:"self"`
+		Operation   hal.Link `json:"operation"`
+		Transaction hal.Link `json:"transaction"`
+	} `json:"_links"`
+
+	ID              string              `json:"id"`
+	TransactionHash string              `json:"transaction_hash"`
+	Changes         []LedgerEntryChange `json:"changes"`
+}
+
 // OrderBookSummary represents a snapshot summary of a given order book
 type OrderBookSummary struct {
 	Bids    []PriceLevel `json:"bids"`
@@ -144,6 +385,13 @@ type OrderBookSummary struct {
 	Buying  Asset        `json:"counter"`
 }
 
+// OrderBookSnapshot represents a summary of an order book as it was recorded
+// at the close of a past ledger.
+type OrderBookSnapshot struct {
+	OrderBookSummary
+	Ledger int32 `json:"ledger"`
+}
+
 // Path represents a single payment path.
 type Path struct {
 	SourceAssetType        string  `json:"source_asset_type"`
@@ -198,6 +446,39 @@ type Signer struct {
 	Type      string `json:"type"`
 }
 
+// SignerHistoryEntry represents the signers and thresholds of an account
+// immediately after an operation that changed them.
+type SignerHistoryEntry struct {
+	Links struct {
+		Operation hal.Link `json:"operation"`
+		Ledger    hal.Link `json:"ledger"`
+	} `json:"_links"`
+
+	ID             string            `json:"id"`
+	PT             string            `json:"paging_token"`
+	Ledger         int32             `json:"ledger"`
+	AccountRemoved bool              `json:"account_removed"`
+	Thresholds     AccountThresholds `json:"thresholds"`
+	Signers        []Signer          `json:"signers"`
+}
+
+// Ticker represents the latest price of an asset pair, the best prices
+// offered on its order book and its trading statistics over the past day.
+type Ticker struct {
+	Base          Asset  `json:"base"`
+	Counter       Asset  `json:"counter"`
+	LastPrice     string `json:"last_price"`
+	BestBid       string `json:"best_bid,omitempty"`
+	BestAsk       string `json:"best_ask,omitempty"`
+	TradeCount    int64  `json:"trade_count"`
+	BaseVolume    string `json:"base_volume"`
+	CounterVolume string `json:"counter_volume"`
+	Open          string `json:"open"`
+	High          string `json:"high"`
+	Low           string `json:"low"`
+	Close         string `json:"close"`
+}
+
 // Trade represents a horizon digested trade
 type Trade struct {
 	Links struct {
@@ -259,6 +540,7 @@ type TradeAggregation struct {
 	BaseVolume    string `json:"base_volume"`
 	CounterVolume string `json:"counter_volume"`
 	Average       string `json:"avg"`
+	Vwap          string `json:"vwap"`
 	High          string `json:"high"`
 	Low           string `json:"low"`
 	Open          string `json:"open"`
@@ -296,6 +578,21 @@ type Transaction struct {
 	ValidBefore     string    `json:"valid_before,omitempty"`
 }
 
+// TransactionChanges represents the ledger entries changed by a transaction:
+// those changed by charging its fee, and those changed by each of its
+// operations.
+type TransactionChanges struct {
+	Links struct {
+		Self        hal.Link `json:"self"`
+		Transaction hal.Link `json:"transaction"`
+	} `json:"_links"`
+
+	Hash       string              `json:"hash"`
+	Ledger     int32               `json:"ledger"`
+	FeeChanges []LedgerEntryChange `json:"fee_changes"`
+	Operations []OperationChanges  `json:"operations"`
+}
+
 // TransactionResultCodes represent a summary of result codes returned from
 // a single xdr TransactionResult
 type TransactionResultCodes struct {
@@ -303,6 +600,14 @@ type TransactionResultCodes struct {
 	OperationCodes  []string `json:"operations,omitempty"`
 }
 
+// TransactionSimulation represents the predicted result of submitting a
+// transaction, as determined without submitting it to the network.
+type TransactionSimulation struct {
+	Hash        string                 `json:"hash"`
+	Successful  bool                   `json:"successful"`
+	ResultCodes TransactionResultCodes `json:"result_codes"`
+}
+
 // TransactionSuccess represents the result of a successful transaction
 // submission.
 type TransactionSuccess struct {
@@ -335,6 +640,82 @@ func NewOperation(
 	return operations.New(ctx, row, ledger)
 }
 
+// EffectColumns are the columns of CSV exports of effects, covering the fields
+// of every type of effect.
+var EffectColumns = export.Columns(
+	effects.Base{},
+	effects.AccountCreated{},
+	effects.AccountCredited{},
+	effects.AccountDebited{},
+	effects.AccountThresholdsUpdated{},
+	effects.AccountHomeDomainUpdated{},
+	effects.AccountFlagsUpdated{},
+	effects.SignerCreated{},
+	effects.SignerRemoved{},
+	effects.SignerUpdated{},
+	effects.TrustlineCreated{},
+	effects.TrustlineRemoved{},
+	effects.TrustlineUpdated{},
+	effects.TrustlineAuthorized{},
+	effects.TrustlineDeauthorized{},
+	effects.Trade{},
+)
+
+// OperationColumns are the columns of CSV exports of operations, covering the
+// fields of every type of operation.
+var OperationColumns = export.Columns(
+	operations.CreateAccount{},
+	operations.Payment{},
+	operations.PathPayment{},
+	operations.ManageOffer{},
+	operations.CreatePassiveOffer{},
+	operations.SetOptions{},
+	operations.ChangeTrust{},
+	operations.AllowTrust{},
+	operations.AccountMerge{},
+	operations.Inflation{},
+	operations.ManageData{},
+)
+
+// PaymentColumns are the columns of CSV exports of payments, covering the
+// fields of every type of operation that is considered a payment.
+var PaymentColumns = export.Columns(
+	operations.CreateAccount{},
+	operations.Payment{},
+	operations.PathPayment{},
+	operations.AccountMerge{},
+)
+
+// TradeColumns are the columns of CSV exports of trades.
+var TradeColumns = export.Columns(Trade{})
+
+// TransactionColumns are the columns of CSV exports of transactions.
+var TransactionColumns = export.Columns(Transaction{})
+
+// EffectTypeFromName returns the effect type represented by `name` in
+// horizon's JSON responses.
+func EffectTypeFromName(name string) (history.EffectType, bool) {
+	for typ, n := range effects.TypeNames {
+		if n == name {
+			return typ, true
+		}
+	}
+
+	return 0, false
+}
+
+// OperationTypeFromName returns the operation type represented by `name` in
+// horizon's JSON responses.
+func OperationTypeFromName(name string) (xdr.OperationType, bool) {
+	for typ, n := range operations.TypeNames {
+		if n == name {
+			return typ, true
+		}
+	}
+
+	return 0, false
+}
+
 // KeyTypeFromAddress converts the version byte of the provided strkey encoded
 // value (for example an account id or a signer key) and returns the appropriate
 // horizon-specific type name.
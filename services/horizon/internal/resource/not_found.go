@@ -0,0 +1,12 @@
+package resource
+
+// Populate fills out the resource for the missing item identified by `id`.
+func (res *NotFound) Populate(id string) {
+	res.ID = id
+	res.NotFound = true
+}
+
+// PagingToken implementation for hal.Pageable
+func (res NotFound) PagingToken() string {
+	return ""
+}
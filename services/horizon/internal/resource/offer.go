@@ -1,9 +1,13 @@
 package resource
 
 import (
+	"fmt"
+	"math/big"
+
 	"github.com/stellar/go/amount"
 	"github.com/stellar/go/services/horizon/internal/assets"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/httpx"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
 	"golang.org/x/net/context"
@@ -31,9 +35,38 @@ func (this *Offer) Populate(ctx context.Context, row core.Offer) {
 	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
 	this.Links.Self = lb.Linkf("/offers/%d", row.OfferID)
 	this.Links.OfferMaker = lb.Linkf("/accounts/%s", row.SellerID)
+	this.Links.History = lb.Linkf("/offers/%d/history", row.OfferID)
 	return
 }
 
+// PopulateFromEvent fills out the offer using the state recorded by the most
+// recent event in its history, for offers that are no longer present in the
+// ledger.
+func (this *Offer) PopulateFromEvent(ctx context.Context, row history.OfferEvent) {
+	this.ID = row.OfferID
+	this.PT = fmt.Sprintf("%d", row.OfferID)
+	this.Seller = row.SellerAccount
+	this.Amount = amount.String(row.Amount)
+	this.PriceR.N = row.Pricen
+	this.PriceR.D = row.Priced
+	this.Price = big.NewRat(int64(row.Pricen), int64(row.Priced)).FloatString(7)
+	this.Buying = Asset{
+		Type:   row.BuyingAssetType,
+		Code:   row.BuyingAssetCode,
+		Issuer: row.BuyingAssetIssuer,
+	}
+	this.Selling = Asset{
+		Type:   row.SellingAssetType,
+		Code:   row.SellingAssetCode,
+		Issuer: row.SellingAssetIssuer,
+	}
+
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+	this.Links.Self = lb.Linkf("/offers/%d", row.OfferID)
+	this.Links.OfferMaker = lb.Linkf("/accounts/%s", row.SellerAccount)
+	this.Links.History = lb.Linkf("/offers/%d/history", row.OfferID)
+}
+
 func (this Offer) PagingToken() string {
 	return this.PT
 }
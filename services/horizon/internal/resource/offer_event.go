@@ -0,0 +1,54 @@
+package resource
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/httpx"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the details of an offer event using a row from the
+// history_offer_events table.
+func (res *OfferEvent) Populate(
+	ctx context.Context,
+	row history.OfferEvent,
+) {
+	res.ID = row.PagingToken()
+	res.PT = row.PagingToken()
+	res.OfferID = row.OfferID
+	res.Type = row.Type.String()
+	res.TypeI = int32(row.Type)
+	res.Seller = row.SellerAccount
+	res.Selling = Asset{
+		Type:   row.SellingAssetType,
+		Code:   row.SellingAssetCode,
+		Issuer: row.SellingAssetIssuer,
+	}
+	res.Buying = Asset{
+		Type:   row.BuyingAssetType,
+		Code:   row.BuyingAssetCode,
+		Issuer: row.BuyingAssetIssuer,
+	}
+	res.Amount = amount.String(row.Amount)
+	res.PriceR.N = row.Pricen
+	res.PriceR.D = row.Priced
+	res.Price = big.NewRat(int64(row.Pricen), int64(row.Priced)).FloatString(7)
+	res.LedgerCloseTime = row.LedgerCloseTime
+
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+	res.Links.Offer = lb.Linkf("/offers/%d", row.OfferID)
+	res.Links.Seller = lb.Link("/accounts", row.SellerAccount)
+	res.Links.Operation = lb.Link(
+		"/operations",
+		fmt.Sprintf("%d", row.HistoryOperationID),
+	)
+}
+
+// PagingToken implementation for hal.Pageable
+func (res OfferEvent) PagingToken() string {
+	return res.PT
+}
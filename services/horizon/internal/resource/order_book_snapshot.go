@@ -0,0 +1,74 @@
+package resource
+
+import (
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/xdr"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the resource from the snapshots of the offers selling
+// `selling` (`asks`) and of the offers selling `buying` (`bids`), keeping at
+// most `limit` price levels on each side.
+func (this *OrderBookSnapshot) Populate(
+	ctx context.Context,
+	selling xdr.Asset,
+	buying xdr.Asset,
+	asks history.OrderBookSnapshot,
+	bids history.OrderBookSnapshot,
+	limit uint64,
+) error {
+	err := this.Selling.Populate(ctx, selling)
+	if err != nil {
+		return err
+	}
+	err = this.Buying.Populate(ctx, buying)
+	if err != nil {
+		return err
+	}
+
+	askLevels, err := asks.Levels()
+	if err != nil {
+		return err
+	}
+	bidLevels, err := bids.Levels()
+	if err != nil {
+		return err
+	}
+
+	this.populateLevels(&this.Asks, snapshotLevels(askLevels, limit, false))
+	this.populateLevels(&this.Bids, snapshotLevels(bidLevels, limit, true))
+
+	this.Ledger = asks.LedgerSequence
+	if bids.LedgerSequence > this.Ledger {
+		this.Ledger = bids.LedgerSequence
+	}
+
+	return nil
+}
+
+// snapshotLevels converts at most `limit` of the recorded `levels` into price
+// levels, inverting their prices when `invert` is true so that the offers of
+// the opposite side of the book are viewed as bids.
+func snapshotLevels(
+	levels []history.OrderBookLevel,
+	limit uint64,
+	invert bool,
+) []core.OrderBookSummaryPriceLevel {
+	if uint64(len(levels)) > limit {
+		levels = levels[:limit]
+	}
+
+	result := make([]core.OrderBookSummaryPriceLevel, len(levels))
+	for i, level := range levels {
+		result[i].Pricen = level.Pricen
+		result[i].Priced = level.Priced
+		result[i].Amount = level.Amount
+
+		if invert {
+			result[i].Pricen, result[i].Priced = level.Priced, level.Pricen
+		}
+	}
+
+	return result
+}
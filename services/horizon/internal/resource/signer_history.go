@@ -0,0 +1,63 @@
+package resource
+
+import (
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/httpx"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the signers and thresholds of an account from the latest
+// state in its signer history.
+func (res *AccountSigners) Populate(
+	ctx context.Context,
+	address string,
+	ledger int32,
+	state history.SignerState,
+) {
+	res.AccountID = address
+	res.Ledger = ledger
+	res.Thresholds.PopulateFromState(state)
+	res.Signers = signersFromState(state)
+
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+	res.Links.Self = lb.Linkf("/accounts/%s/signers?at_ledger=%d", address, ledger)
+	res.Links.Account = lb.Link("/accounts", address)
+	res.Links.History = lb.Link("/accounts", address, "signer_history")
+	res.Links.History.Href += "{?cursor,limit,order}"
+	res.Links.History.PopulateTemplated()
+}
+
+// Populate fills out the details of a signer history entry.
+func (res *SignerHistoryEntry) Populate(
+	ctx context.Context,
+	state history.SignerState,
+) {
+	res.ID = state.PagingToken()
+	res.PT = state.PagingToken()
+	res.Ledger = state.LedgerSequence()
+	res.AccountRemoved = state.Removed
+	res.Thresholds.PopulateFromState(state)
+	res.Signers = signersFromState(state)
+
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+	res.Links.Operation = lb.Link("/operations", res.ID)
+	res.Links.Ledger = lb.Linkf("/ledgers/%d", res.Ledger)
+}
+
+// PagingToken implementation for hal.Pageable
+func (res SignerHistoryEntry) PagingToken() string {
+	return res.PT
+}
+
+// signersFromState returns the signers of a signer history state.
+func signersFromState(state history.SignerState) []Signer {
+	signers := make([]Signer, len(state.Signers))
+	for i, signer := range state.Signers {
+		signers[i].PublicKey = signer.PublicKey
+		signers[i].Weight = signer.Weight
+		signers[i].Key = signer.PublicKey
+		signers[i].Type = MustKeyTypeFromAddress(signer.PublicKey)
+	}
+	return signers
+}
@@ -0,0 +1,53 @@
+package resource
+
+import (
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/price"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the resource from a ticker row and the best bid and ask
+// on the pair's order book, either of which may be nil when that side of the
+// book is empty.
+func (res *Ticker) Populate(
+	ctx context.Context,
+	row history.Ticker,
+	bestBid *core.PriceLevel,
+	bestAsk *core.PriceLevel,
+) error {
+	res.Base = Asset{
+		Type:   row.BaseAssetType,
+		Code:   row.BaseAssetCode,
+		Issuer: row.BaseAssetIssuer,
+	}
+	res.Counter = Asset{
+		Type:   row.CounterAssetType,
+		Code:   row.CounterAssetCode,
+		Issuer: row.CounterAssetIssuer,
+	}
+
+	res.LastPrice = price.StringFromFloat64(row.LastPrice)
+	res.TradeCount = row.TradeCount
+	res.BaseVolume = amount.StringFromInt64(row.BaseVolume)
+	res.CounterVolume = amount.StringFromInt64(row.CounterVolume)
+	res.Open = price.StringFromFloat64(row.Open)
+	res.High = price.StringFromFloat64(row.High)
+	res.Low = price.StringFromFloat64(row.Low)
+	res.Close = price.StringFromFloat64(row.Close)
+
+	if bestBid != nil {
+		res.BestBid = bestBid.PriceAsString()
+	}
+	if bestAsk != nil {
+		res.BestAsk = bestAsk.PriceAsString()
+	}
+
+	return nil
+}
+
+// stub implementation to satisfy pageable interface
+func (res Ticker) PagingToken() string {
+	return ""
+}
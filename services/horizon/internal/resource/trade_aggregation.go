@@ -1,6 +1,8 @@
 package resource
 
 import (
+	"fmt"
+
 	"github.com/stellar/go/amount"
 	"github.com/stellar/go/price"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
@@ -18,6 +20,7 @@ func (res *TradeAggregation) Populate(
 	res.BaseVolume = amount.StringFromInt64(row.BaseVolume)
 	res.CounterVolume = amount.StringFromInt64(row.CounterVolume)
 	res.Average = price.StringFromFloat64(row.Average)
+	res.Vwap = price.StringFromFloat64(float64(row.CounterVolume) / float64(row.BaseVolume))
 	res.High = price.StringFromFloat64(row.High)
 	res.Low = price.StringFromFloat64(row.Low)
 	res.Open = price.StringFromFloat64(row.Open)
@@ -25,7 +28,8 @@ func (res *TradeAggregation) Populate(
 	return
 }
 
-// PagingToken implementation for hal.Pageable. Not actually used
+// PagingToken implementation for hal.Pageable. Used as the event id when
+// streaming trade aggregations.
 func (res TradeAggregation) PagingToken() string {
-	return string(res.Timestamp)
+	return fmt.Sprintf("%d", res.Timestamp)
 }
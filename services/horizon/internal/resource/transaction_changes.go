@@ -0,0 +1,108 @@
+package resource
+
+import (
+	"github.com/stellar/go/meta"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/httpx"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/services/horizon/internal/toid"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the resource from a row of the history_transactions
+// table, decoding the changes recorded in its fee and result meta.
+func (res *TransactionChanges) Populate(
+	ctx context.Context,
+	row history.Transaction,
+) error {
+	bundle, err := metaBundle(row)
+	if err != nil {
+		return err
+	}
+
+	res.Hash = row.TransactionHash
+	res.Ledger = row.LedgerSequence
+
+	res.FeeChanges, err = populateChanges(ctx, bundle, bundle.FeeMeta, -1)
+	if err != nil {
+		return err
+	}
+
+	ops := bundle.TransactionMeta.MustOperations()
+	res.Operations = make([]OperationChanges, len(ops))
+	for i := range ops {
+		err = res.Operations[i].populate(ctx, row, bundle, i)
+		if err != nil {
+			return err
+		}
+	}
+
+	lb := hal.LinkBuilder{Base: httpx.BaseURL(ctx)}
+	res.Links.Self = lb.Link("/transactions", res.Hash, "changes")
+	res.Links.Transaction = lb.Link("/transactions", res.Hash)
+	return nil
+}
+
+// Populate fills out the resource from a row of the history_transactions
+// table, decoding the changes recorded in its result meta for the operation at
+// index `opidx`.
+func (res *OperationChanges) Populate(
+	ctx context.Context,
+	row history.Transaction,
+	opidx int,
+) error {
+	bundle, err := metaBundle(row)
+	if err != nil {
+		return err
+	}
+
+	return res.populate(ctx, row, bundle, opidx)
+}
+
+func (res *OperationChanges) populate(
+	ctx context.Context,
+	row history.Transaction,
+	bundle *meta.Bundle,
+	opidx int,
+) (err error) {
+	id := toid.Parse(row.ID)
+	id.OperationOrder = int32(opidx + 1)
+	res.ID = id.String()
+	res.TransactionHash = row.TransactionHash
+
+	// failed transactions record no changes for their operations
+	ops := bundle.TransactionMeta.MustOperations()
+	if opidx < len(ops) {
+		res.Changes, err = populateChanges(ctx, bundle, ops[opidx].Changes, opidx)
+		if err != nil {
+			return
+		}
+	} else {
+		res.Changes = []LedgerEntryChange{}
+	}
+
+	lb := hal.LinkBuilder{Base: httpx.BaseURL(ctx)}
+	res.Links.Self = lb.Link("/operations", res.ID, "changes")
+	res.Links.Operation = lb.Link("/operations", res.ID)
+	res.Links.Transaction = lb.Link("/transactions", res.TransactionHash)
+	return
+}
+
+// metaBundle decodes the fee and result meta of `row`.
+func metaBundle(row history.Transaction) (*meta.Bundle, error) {
+	var bundle meta.Bundle
+
+	err := xdr.SafeUnmarshalBase64(row.TxFeeMeta, &bundle.FeeMeta)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode fee meta")
+	}
+
+	err = xdr.SafeUnmarshalBase64(row.TxMeta, &bundle.TransactionMeta)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode result meta")
+	}
+
+	return &bundle, nil
+}
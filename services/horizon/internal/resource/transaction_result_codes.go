@@ -1,6 +1,7 @@
 package resource
 
 import (
+	"github.com/stellar/go/services/horizon/internal/simulate"
 	"github.com/stellar/go/services/horizon/internal/txsub"
 	"golang.org/x/net/context"
 )
@@ -22,3 +23,11 @@ func (res *TransactionResultCodes) Populate(ctx context.Context,
 
 	return
 }
+
+// PopulateFromSimulation fills out the details from a simulated result
+func (res *TransactionResultCodes) PopulateFromSimulation(
+	result simulate.Result,
+) (err error) {
+	res.TransactionCode, res.OperationCodes, err = result.Codes()
+	return
+}
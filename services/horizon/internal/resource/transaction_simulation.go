@@ -0,0 +1,17 @@
+package resource
+
+import (
+	"github.com/stellar/go/services/horizon/internal/simulate"
+	"github.com/stellar/go/xdr"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the details of a simulated transaction
+func (res *TransactionSimulation) Populate(
+	ctx context.Context,
+	result simulate.Result,
+) error {
+	res.Hash = result.Hash
+	res.Successful = result.TransactionCode == xdr.TransactionResultCodeTxSuccess
+	return res.ResultCodes.PopulateFromSimulation(result)
+}
@@ -0,0 +1,114 @@
+// Package simulate predicts the result stellar-core would produce when
+// applying a transaction, by validating it against the current state of a
+// stellar-core database without submitting it to the network.
+//
+// The simulation covers the transaction level checks made by stellar-core
+// (time bounds, fees, sequence numbers, balances and signatures) and the
+// account, balance, trustline and authorization checks of the create_account,
+// payment, change_trust and allow_trust operations.  Other operations are only
+// checked for the existence and authorization of their source account.  The
+// effects of earlier operations in the same transaction are taken into account
+// when checking later ones, but state changes caused by other transactions
+// applied in the meantime are not, so a simulated result is a prediction
+// rather than a guarantee.
+package simulate
+
+import (
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/services/horizon/internal/codes"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/txsub"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// Simulator validates transactions against a stellar-core database.
+type Simulator struct {
+	Q                 *core.Q
+	NetworkPassphrase string
+}
+
+// Result is the predicted outcome of applying a transaction.
+type Result struct {
+	Hash            string
+	TransactionCode xdr.TransactionResultCode
+
+	// OperationCodes holds a result code for each operation when the
+	// transaction code is tx_success or tx_failed.  Each code is either an
+	// xdr.OperationResultCode, when the operation could not be attempted, or
+	// the result code specific to the operation's type.
+	OperationCodes []interface{}
+}
+
+// Simulate decodes the base64 encoded transaction envelope `env` and predicts
+// the result of applying it to the current ledger.  A
+// *txsub.MalformedTransactionError is returned when `env` cannot be decoded.
+func (s *Simulator) Simulate(env string) (result Result, err error) {
+	var tx xdr.TransactionEnvelope
+	err = xdr.SafeUnmarshalBase64(env, &tx)
+	if err != nil {
+		err = &txsub.MalformedTransactionError{EnvelopeXDR: env}
+		return
+	}
+
+	txb := build.TransactionBuilder{TX: &tx.Tx}
+	txb.Mutate(build.Network{s.NetworkPassphrase})
+
+	hash, err := txb.Hash()
+	if err != nil {
+		err = errors.Wrap(err, "hash failed")
+		return
+	}
+	result.Hash, err = txb.HashHex()
+	if err != nil {
+		err = errors.Wrap(err, "hash failed")
+		return
+	}
+
+	ls, err := newLedgerState(s.Q)
+	if err != nil {
+		return
+	}
+
+	sim := &simulation{
+		tx:       tx.Tx,
+		state:    ls,
+		checker:  newSignatureChecker(hash, tx.Signatures),
+		opResult: make([]interface{}, len(tx.Tx.Operations)),
+	}
+
+	result.TransactionCode, err = sim.run()
+	if err != nil {
+		return
+	}
+
+	if result.TransactionCode == xdr.TransactionResultCodeTxSuccess ||
+		result.TransactionCode == xdr.TransactionResultCodeTxFailed {
+		result.OperationCodes = sim.opResult
+	}
+
+	return
+}
+
+// Codes returns the string representations of the result's codes, as used by
+// horizon's transaction submission responses.
+func (r Result) Codes() (tx string, ops []string, err error) {
+	tx, err = codes.String(r.TransactionCode)
+	if err != nil {
+		return
+	}
+
+	if r.OperationCodes == nil {
+		return
+	}
+
+	ops = make([]string, len(r.OperationCodes))
+	for i, code := range r.OperationCodes {
+		ops[i], err = codes.String(code)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,110 @@
+package simulate
+
+import (
+	"testing"
+
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/services/horizon/internal/txsub"
+)
+
+const (
+	scottSeed  = "SBZWG33UOQQCAIBAEAQCAIBAEAQCAIBAEAQCAIBAEAQCAIBAEAQCAPSA"
+	bartekSeed = "SBRGC4TUMVVSAIBAEAQCAIBAEAQCAIBAEAQCAIBAEAQCAIBAEAQCBDHV"
+	andrew     = "GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON"
+	usdGateway = "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4"
+)
+
+func TestSimulate(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	sim := &Simulator{
+		Q:                 &core.Q{Session: tt.CoreSession()},
+		NetworkPassphrase: build.TestNetwork.Passphrase,
+	}
+
+	// envelope builds a transaction from scott's account, signed by `signers`.
+	envelope := func(seq uint64, op build.TransactionMutator, signers ...string) string {
+		tx := build.Transaction(
+			build.SourceAccount{scottSeed},
+			build.Sequence{seq},
+			build.TestNetwork,
+			op,
+		)
+		env := tx.Sign(signers...)
+		b64, err := env.Base64()
+		tt.Require.NoError(err)
+		return b64
+	}
+
+	payment := func(amount string) build.TransactionMutator {
+		return build.Payment(build.Destination{andrew}, build.NativeAmount{amount})
+	}
+
+	cases := []struct {
+		Name string
+		Env  string
+		TX   string
+		Ops  []string
+	}{
+		{"success", envelope(8589934594, payment("5"), scottSeed), "tx_success", []string{"op_success"}},
+		{"bad sequence", envelope(8589934593, payment("5"), scottSeed), "tx_bad_seq", nil},
+		{"wrong signer", envelope(8589934594, payment("5"), bartekSeed), "tx_bad_auth", nil},
+		{"extra signer", envelope(8589934594, payment("5"), scottSeed, bartekSeed), "tx_bad_auth_extra", nil},
+		{"extra signer and failed operation", envelope(8589934594, payment("1000"), scottSeed, bartekSeed), "tx_bad_auth_extra", nil},
+		{"underfunded", envelope(8589934594, payment("1000"), scottSeed), "tx_failed", []string{"op_underfunded"}},
+		{
+			"no destination",
+			envelope(8589934594, build.Payment(
+				build.Destination{usdGateway},
+				build.NativeAmount{"5"},
+			), scottSeed),
+			"tx_failed",
+			[]string{"op_no_destination"},
+		},
+		{
+			"no issuer",
+			envelope(8589934594, build.Trust("USD", usdGateway), scottSeed),
+			"tx_failed",
+			[]string{"op_no_issuer"},
+		},
+	}
+
+	for _, c := range cases {
+		result, err := sim.Simulate(c.Env)
+		if !tt.Assert.NoError(err, c.Name) {
+			continue
+		}
+
+		tx, ops, err := result.Codes()
+		tt.Require.NoError(err)
+		tt.Assert.Equal(c.TX, tx, c.Name)
+		tt.Assert.Equal(c.Ops, ops, c.Name)
+	}
+
+	// later operations see the effects of earlier ones
+	tx := build.Transaction(
+		build.SourceAccount{scottSeed},
+		build.Sequence{8589934594},
+		build.TestNetwork,
+		build.CreateAccount(build.Destination{usdGateway}, build.NativeAmount{"20"}),
+		build.Payment(build.Destination{usdGateway}, build.NativeAmount{"5"}),
+		build.Payment(build.Destination{andrew}, build.NativeAmount{"60"}),
+	)
+	env := tx.Sign(scottSeed)
+	b64, err := env.Base64()
+	tt.Require.NoError(err)
+
+	result, err := sim.Simulate(b64)
+	tt.Require.NoError(err)
+	txCode, ops, err := result.Codes()
+	tt.Require.NoError(err)
+	tt.Assert.Equal("tx_failed", txCode)
+	tt.Assert.Equal([]string{"op_success", "op_success", "op_underfunded"}, ops)
+
+	// malformed envelopes
+	_, err = sim.Simulate("not an envelope")
+	tt.Assert.IsType(&txsub.MalformedTransactionError{}, err)
+}
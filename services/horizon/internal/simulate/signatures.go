@@ -0,0 +1,106 @@
+package simulate
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+)
+
+// signatureChecker checks the signatures of a transaction against the signers
+// of the accounts it uses, following the rules applied by stellar-core, and
+// tracks which signatures have been used.
+type signatureChecker struct {
+	hash       [32]byte
+	signatures []xdr.DecoratedSignature
+	used       []bool
+}
+
+func newSignatureChecker(hash [32]byte, signatures []xdr.DecoratedSignature) *signatureChecker {
+	return &signatureChecker{
+		hash:       hash,
+		signatures: signatures,
+		used:       make([]bool, len(signatures)),
+	}
+}
+
+// check returns true if the transaction is signed by enough of `signers` to
+// meet the `needed` threshold.  At least one signer must match, even when the
+// threshold is zero.
+func (c *signatureChecker) check(signers []core.Signer, needed byte) bool {
+	var total int32
+
+	for _, signer := range signers {
+		if !c.matches(signer.Publickey) {
+			continue
+		}
+
+		total += signer.Weight
+		if total >= int32(needed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allUsed returns true if every signature matched at least one signer.
+func (c *signatureChecker) allUsed() bool {
+	for _, used := range c.used {
+		if !used {
+			return false
+		}
+	}
+	return true
+}
+
+// matches returns true if the signer identified by `key` has authorized the
+// transaction, marking the matching signature as used.
+func (c *signatureChecker) matches(key string) bool {
+	version, err := strkey.Version(key)
+	if err != nil {
+		return false
+	}
+
+	raw, err := strkey.Decode(version, key)
+	if err != nil {
+		return false
+	}
+
+	switch version {
+	case strkey.VersionByteHashTx:
+		// pre-authorized transactions require no signature
+		return bytes.Equal(raw, c.hash[:])
+	case strkey.VersionByteHashX:
+		for i, sig := range c.signatures {
+			if !bytes.Equal(sig.Hint[:], raw[len(raw)-4:]) {
+				continue
+			}
+			preimage := sha256.Sum256(sig.Signature)
+			if bytes.Equal(preimage[:], raw) {
+				c.used[i] = true
+				return true
+			}
+		}
+	case strkey.VersionByteAccountID:
+		kp, err := keypair.Parse(key)
+		if err != nil {
+			return false
+		}
+		hint := kp.Hint()
+		for i, sig := range c.signatures {
+			if !bytes.Equal(sig.Hint[:], hint[:]) {
+				continue
+			}
+			if kp.Verify(c.hash[:], sig.Signature) == nil {
+				c.used[i] = true
+				return true
+			}
+		}
+	}
+
+	return false
+}
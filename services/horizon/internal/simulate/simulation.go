@@ -0,0 +1,435 @@
+package simulate
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/stellar/go/services/horizon/internal/codes"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// simulation holds the state of a single transaction's simulation.
+type simulation struct {
+	tx       xdr.Transaction
+	state    *ledgerState
+	checker  *signatureChecker
+	opResult []interface{}
+}
+
+// run performs the transaction level checks made by stellar-core, in the order
+// it makes them, and then simulates each operation.
+func (sim *simulation) run() (xdr.TransactionResultCode, error) {
+	ls := sim.state
+	tx := sim.tx
+
+	if tx.TimeBounds != nil {
+		if tx.TimeBounds.MinTime > ls.closeTime() {
+			return xdr.TransactionResultCodeTxTooEarly, nil
+		}
+		if tx.TimeBounds.MaxTime != 0 && tx.TimeBounds.MaxTime < ls.closeTime() {
+			return xdr.TransactionResultCodeTxTooLate, nil
+		}
+	}
+
+	if len(tx.Operations) == 0 {
+		return xdr.TransactionResultCodeTxMissingOperation, nil
+	}
+
+	if uint64(tx.Fee) < uint64(ls.header.BaseFee)*uint64(len(tx.Operations)) {
+		return xdr.TransactionResultCodeTxInsufficientFee, nil
+	}
+
+	source, err := ls.account(tx.SourceAccount.Address())
+	if err != nil {
+		return 0, err
+	}
+	if source == nil {
+		return xdr.TransactionResultCodeTxNoAccount, nil
+	}
+
+	seq, err := strconv.ParseInt(source.Seqnum, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "parse sequence failed")
+	}
+	if int64(tx.SeqNum) != seq+1 {
+		return xdr.TransactionResultCodeTxBadSeq, nil
+	}
+
+	signers, err := ls.accountSigners(source)
+	if err != nil {
+		return 0, err
+	}
+	if !sim.checker.check(signers, source.Thresholds[thresholdLow]) {
+		return xdr.TransactionResultCodeTxBadAuth, nil
+	}
+
+	fee := xdr.Int64(tx.Fee)
+	if ls.availableBalance(source) < fee {
+		return xdr.TransactionResultCodeTxInsufficientBalance, nil
+	}
+	source.Balance -= fee
+
+	// stellar-core authorizes every operation, consuming the signatures of
+	// their source accounts, and then requires that every signature was used
+	// before it applies any of them.
+	failed := false
+	sources := make([]*core.Account, len(tx.Operations))
+	for i, op := range tx.Operations {
+		sources[i], sim.opResult[i], err = sim.authorize(op)
+		if err != nil {
+			return 0, err
+		}
+		if sources[i] == nil {
+			failed = true
+		}
+	}
+
+	if !failed && !sim.checker.allUsed() {
+		return xdr.TransactionResultCodeTxBadAuthExtra, nil
+	}
+
+	for i, op := range tx.Operations {
+		if sources[i] == nil {
+			continue
+		}
+
+		code, err := sim.operation(sources[i], op)
+		if err != nil {
+			return 0, err
+		}
+		sim.opResult[i] = code
+
+		str, err := codes.String(code)
+		if err != nil {
+			return 0, err
+		}
+		if str != codes.OpSuccess {
+			failed = true
+		}
+	}
+
+	if failed {
+		return xdr.TransactionResultCodeTxFailed, nil
+	}
+
+	return xdr.TransactionResultCodeTxSuccess, nil
+}
+
+// The indexes of the thresholds in xdr.Thresholds
+const (
+	thresholdLow  = 1
+	thresholdMed  = 2
+	thresholdHigh = 3
+)
+
+// thresholdFor returns the index of the threshold that must be met to
+// authorize `op`.
+func thresholdFor(op xdr.Operation) int {
+	switch op.Body.Type {
+	case xdr.OperationTypeAllowTrust, xdr.OperationTypeInflation:
+		return thresholdLow
+	case xdr.OperationTypeAccountMerge:
+		return thresholdHigh
+	case xdr.OperationTypeSetOptions:
+		so := op.Body.MustSetOptionsOp()
+		if so.MasterWeight != nil || so.LowThreshold != nil ||
+			so.MedThreshold != nil || so.HighThreshold != nil || so.Signer != nil {
+			return thresholdHigh
+		}
+	}
+
+	return thresholdMed
+}
+
+// authorize checks the source account and authorization of `op`, returning
+// the source account if it is authorized, or the result code of the operation
+// if it is not.
+func (sim *simulation) authorize(op xdr.Operation) (*core.Account, interface{}, error) {
+	sourceID := sim.tx.SourceAccount
+	if op.SourceAccount != nil {
+		sourceID = *op.SourceAccount
+	}
+
+	source, err := sim.state.account(sourceID.Address())
+	if err != nil {
+		return nil, nil, err
+	}
+	if source == nil {
+		return nil, xdr.OperationResultCodeOpNoAccount, nil
+	}
+
+	signers, err := sim.state.accountSigners(source)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !sim.checker.check(signers, source.Thresholds[thresholdFor(op)]) {
+		return nil, xdr.OperationResultCodeOpBadAuth, nil
+	}
+
+	return source, nil, nil
+}
+
+// operation simulates `op`, which has been authorized by `source`, returning
+// its result code.
+func (sim *simulation) operation(source *core.Account, op xdr.Operation) (interface{}, error) {
+	sourceID := sim.tx.SourceAccount
+	if op.SourceAccount != nil {
+		sourceID = *op.SourceAccount
+	}
+
+	switch op.Body.Type {
+	case xdr.OperationTypeCreateAccount:
+		return sim.createAccount(source, op.Body.MustCreateAccountOp())
+	case xdr.OperationTypePayment:
+		return sim.payment(source, op.Body.MustPaymentOp())
+	case xdr.OperationTypeChangeTrust:
+		return sim.changeTrust(source, op.Body.MustChangeTrustOp())
+	case xdr.OperationTypeAllowTrust:
+		return sim.allowTrust(source, sourceID, op.Body.MustAllowTrustOp())
+
+	// The remaining operations are not simulated beyond the checks above.
+	case xdr.OperationTypePathPayment:
+		return xdr.PathPaymentResultCodePathPaymentSuccess, nil
+	case xdr.OperationTypeManageOffer, xdr.OperationTypeCreatePassiveOffer:
+		return xdr.ManageOfferResultCodeManageOfferSuccess, nil
+	case xdr.OperationTypeSetOptions:
+		return xdr.SetOptionsResultCodeSetOptionsSuccess, nil
+	case xdr.OperationTypeAccountMerge:
+		return xdr.AccountMergeResultCodeAccountMergeSuccess, nil
+	case xdr.OperationTypeInflation:
+		return xdr.InflationResultCodeInflationSuccess, nil
+	case xdr.OperationTypeManageData:
+		return xdr.ManageDataResultCodeManageDataSuccess, nil
+	}
+
+	return nil, fmt.Errorf("unknown operation type: %d", op.Body.Type)
+}
+
+func (sim *simulation) createAccount(
+	source *core.Account,
+	op xdr.CreateAccountOp,
+) (interface{}, error) {
+	ls := sim.state
+
+	if op.StartingBalance <= 0 {
+		return xdr.CreateAccountResultCodeCreateAccountMalformed, nil
+	}
+
+	address := op.Destination.Address()
+	dest, err := ls.account(address)
+	if err != nil {
+		return nil, err
+	}
+	if dest != nil {
+		return xdr.CreateAccountResultCodeCreateAccountAlreadyExist, nil
+	}
+
+	if op.StartingBalance < ls.minimumBalance(0) {
+		return xdr.CreateAccountResultCodeCreateAccountLowReserve, nil
+	}
+
+	if ls.availableBalance(source) < op.StartingBalance {
+		return xdr.CreateAccountResultCodeCreateAccountUnderfunded, nil
+	}
+
+	source.Balance -= op.StartingBalance
+	ls.createAccount(address, op.StartingBalance)
+	return xdr.CreateAccountResultCodeCreateAccountSuccess, nil
+}
+
+func (sim *simulation) payment(
+	source *core.Account,
+	op xdr.PaymentOp,
+) (interface{}, error) {
+	ls := sim.state
+
+	if op.Amount <= 0 {
+		return xdr.PaymentResultCodePaymentMalformed, nil
+	}
+
+	dest, err := ls.account(op.Destination.Address())
+	if err != nil {
+		return nil, err
+	}
+	if dest == nil {
+		return xdr.PaymentResultCodePaymentNoDestination, nil
+	}
+
+	if op.Asset.Type == xdr.AssetTypeAssetTypeNative {
+		if ls.availableBalance(source) < op.Amount {
+			return xdr.PaymentResultCodePaymentUnderfunded, nil
+		}
+
+		source.Balance -= op.Amount
+		dest.Balance += op.Amount
+		return xdr.PaymentResultCodePaymentSuccess, nil
+	}
+
+	var typ, code, issuer string
+	err = op.Asset.Extract(&typ, &code, &issuer)
+	if err != nil {
+		return xdr.PaymentResultCodePaymentMalformed, nil
+	}
+
+	issuerAccount, err := ls.account(issuer)
+	if err != nil {
+		return nil, err
+	}
+	if issuerAccount == nil {
+		return xdr.PaymentResultCodePaymentNoIssuer, nil
+	}
+
+	// Issuers send and receive their own assets without a trustline.
+	var destLine, sourceLine *core.Trustline
+
+	if dest.Accountid != issuer {
+		destLine, err = ls.trustline(dest.Accountid, op.Asset)
+		if err != nil {
+			return nil, err
+		}
+		if destLine == nil {
+			return xdr.PaymentResultCodePaymentNoTrust, nil
+		}
+		if !destLine.IsAuthorized() {
+			return xdr.PaymentResultCodePaymentNotAuthorized, nil
+		}
+		if destLine.Tlimit-destLine.Balance < op.Amount {
+			return xdr.PaymentResultCodePaymentLineFull, nil
+		}
+	}
+
+	if source.Accountid != issuer {
+		sourceLine, err = ls.trustline(source.Accountid, op.Asset)
+		if err != nil {
+			return nil, err
+		}
+		if sourceLine == nil {
+			return xdr.PaymentResultCodePaymentSrcNoTrust, nil
+		}
+		if !sourceLine.IsAuthorized() {
+			return xdr.PaymentResultCodePaymentSrcNotAuthorized, nil
+		}
+		if sourceLine.Balance < op.Amount {
+			return xdr.PaymentResultCodePaymentUnderfunded, nil
+		}
+	}
+
+	if destLine != nil {
+		destLine.Balance += op.Amount
+	}
+	if sourceLine != nil {
+		sourceLine.Balance -= op.Amount
+	}
+	return xdr.PaymentResultCodePaymentSuccess, nil
+}
+
+func (sim *simulation) changeTrust(
+	source *core.Account,
+	op xdr.ChangeTrustOp,
+) (interface{}, error) {
+	ls := sim.state
+
+	if op.Line.Type == xdr.AssetTypeAssetTypeNative || op.Limit < 0 {
+		return xdr.ChangeTrustResultCodeChangeTrustMalformed, nil
+	}
+
+	var (
+		typ    xdr.AssetType
+		code   string
+		issuer string
+	)
+	err := op.Line.Extract(&typ, &code, &issuer)
+	if err != nil || issuer == source.Accountid {
+		return xdr.ChangeTrustResultCodeChangeTrustMalformed, nil
+	}
+
+	issuerAccount, err := ls.account(issuer)
+	if err != nil {
+		return nil, err
+	}
+	if issuerAccount == nil {
+		return xdr.ChangeTrustResultCodeChangeTrustNoIssuer, nil
+	}
+
+	line, err := ls.trustline(source.Accountid, op.Line)
+	if err != nil {
+		return nil, err
+	}
+
+	if line != nil {
+		if op.Limit < line.Balance {
+			return xdr.ChangeTrustResultCodeChangeTrustInvalidLimit, nil
+		}
+
+		if op.Limit == 0 {
+			source.Numsubentries--
+			return xdr.ChangeTrustResultCodeChangeTrustSuccess,
+				ls.setTrustline(source.Accountid, op.Line, nil)
+		}
+
+		line.Tlimit = op.Limit
+		return xdr.ChangeTrustResultCodeChangeTrustSuccess, nil
+	}
+
+	if op.Limit == 0 {
+		return xdr.ChangeTrustResultCodeChangeTrustInvalidLimit, nil
+	}
+
+	if source.Balance < ls.minimumBalance(source.Numsubentries+1) {
+		return xdr.ChangeTrustResultCodeChangeTrustLowReserve, nil
+	}
+
+	line = &core.Trustline{
+		Accountid: source.Accountid,
+		Assettype: typ,
+		Assetcode: code,
+		Issuer:    issuer,
+		Tlimit:    op.Limit,
+	}
+	if !issuerAccount.IsAuthRequired() {
+		line.Flags = int32(xdr.TrustLineFlagsAuthorizedFlag)
+	}
+
+	source.Numsubentries++
+	return xdr.ChangeTrustResultCodeChangeTrustSuccess,
+		ls.setTrustline(source.Accountid, op.Line, line)
+}
+
+func (sim *simulation) allowTrust(
+	source *core.Account,
+	sourceID xdr.AccountId,
+	op xdr.AllowTrustOp,
+) (interface{}, error) {
+	ls := sim.state
+
+	trustor := op.Trustor.Address()
+	if op.Asset.Type == xdr.AssetTypeAssetTypeNative || trustor == source.Accountid {
+		return xdr.AllowTrustResultCodeAllowTrustMalformed, nil
+	}
+
+	if !source.IsAuthRequired() {
+		return xdr.AllowTrustResultCodeAllowTrustTrustNotRequired, nil
+	}
+
+	if !op.Authorize && !source.IsAuthRevocable() {
+		return xdr.AllowTrustResultCodeAllowTrustCantRevoke, nil
+	}
+
+	line, err := ls.trustline(trustor, op.Asset.ToAsset(sourceID))
+	if err != nil {
+		return nil, err
+	}
+	if line == nil {
+		return xdr.AllowTrustResultCodeAllowTrustNoTrustLine, nil
+	}
+
+	if op.Authorize {
+		line.Flags |= int32(xdr.TrustLineFlagsAuthorizedFlag)
+	} else {
+		line.Flags &^= int32(xdr.TrustLineFlagsAuthorizedFlag)
+	}
+
+	return xdr.AllowTrustResultCodeAllowTrustSuccess, nil
+}
@@ -0,0 +1,175 @@
+package simulate
+
+import (
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// ledgerState is a view of the latest ledger in a stellar-core database,
+// overlaid with the changes made by the operations simulated so far.  Entries
+// are loaded lazily and cached, with a nil entry recording one that does not
+// exist.
+type ledgerState struct {
+	q          *core.Q
+	header     xdr.LedgerHeader
+	accounts   map[string]*core.Account
+	signers    map[string][]core.Signer
+	trustlines map[string]map[string]*core.Trustline
+}
+
+func newLedgerState(q *core.Q) (*ledgerState, error) {
+	var seq int32
+	err := q.LatestLedger(&seq)
+	if err != nil {
+		return nil, errors.Wrap(err, "load latest ledger failed")
+	}
+
+	var header core.LedgerHeader
+	err = q.LedgerHeaderBySequence(&header, seq)
+	if err != nil {
+		return nil, errors.Wrap(err, "load ledger header failed")
+	}
+
+	return &ledgerState{
+		q:          q,
+		header:     header.Data,
+		accounts:   map[string]*core.Account{},
+		signers:    map[string][]core.Signer{},
+		trustlines: map[string]map[string]*core.Trustline{},
+	}, nil
+}
+
+// closeTime returns the close time of the latest ledger.
+func (ls *ledgerState) closeTime() xdr.Uint64 {
+	return ls.header.ScpValue.CloseTime
+}
+
+// minimumBalance returns the minimum balance of an account with
+// `subentries` sub entries.
+func (ls *ledgerState) minimumBalance(subentries int32) xdr.Int64 {
+	return xdr.Int64(2+subentries) * xdr.Int64(ls.header.BaseReserve)
+}
+
+// availableBalance returns the native balance of `account` above its minimum
+// balance.
+func (ls *ledgerState) availableBalance(account *core.Account) xdr.Int64 {
+	return account.Balance - ls.minimumBalance(account.Numsubentries)
+}
+
+// account returns the account at `address`, or nil if it does not exist.
+func (ls *ledgerState) account(address string) (*core.Account, error) {
+	if account, ok := ls.accounts[address]; ok {
+		return account, nil
+	}
+
+	account := &core.Account{}
+	err := ls.q.AccountByAddress(account, address)
+	if ls.q.NoRows(err) {
+		account, err = nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "load account failed")
+	}
+
+	ls.accounts[address] = account
+	return account, nil
+}
+
+// createAccount records the creation of an account.
+func (ls *ledgerState) createAccount(address string, balance xdr.Int64) {
+	ls.accounts[address] = &core.Account{
+		Accountid:  address,
+		Balance:    balance,
+		Thresholds: xdr.Thresholds{1, 0, 0, 0},
+	}
+	ls.signers[address] = []core.Signer{}
+	ls.trustlines[address] = map[string]*core.Trustline{}
+}
+
+// accountSigners returns the weighted signers of `account`, including its
+// master key when its weight is greater than zero.
+func (ls *ledgerState) accountSigners(account *core.Account) ([]core.Signer, error) {
+	signers, ok := ls.signers[account.Accountid]
+	if !ok {
+		err := ls.q.SignersByAddress(&signers, account.Accountid)
+		if err != nil {
+			return nil, errors.Wrap(err, "load signers failed")
+		}
+		ls.signers[account.Accountid] = signers
+	}
+
+	result := make([]core.Signer, 0, len(signers)+1)
+	if account.Thresholds[0] > 0 {
+		result = append(result, core.Signer{
+			Accountid: account.Accountid,
+			Publickey: account.Accountid,
+			Weight:    int32(account.Thresholds[0]),
+		})
+	}
+
+	return append(result, signers...), nil
+}
+
+// trustline returns the trustline of `address` to `asset`, or nil if it does
+// not exist.
+func (ls *ledgerState) trustline(address string, asset xdr.Asset) (*core.Trustline, error) {
+	lines, ok := ls.trustlines[address]
+	if !ok {
+		var rows []core.Trustline
+		err := ls.q.TrustlinesByAddress(&rows, address)
+		if err != nil {
+			return nil, errors.Wrap(err, "load trustlines failed")
+		}
+
+		lines = map[string]*core.Trustline{}
+		for i := range rows {
+			lines[trustlineKey(rows[i].Assettype, rows[i].Assetcode, rows[i].Issuer)] = &rows[i]
+		}
+		ls.trustlines[address] = lines
+	}
+
+	key, err := assetKey(asset)
+	if err != nil {
+		return nil, err
+	}
+
+	return lines[key], nil
+}
+
+// setTrustline records the creation, update or, when `line` is nil, the
+// removal of the trustline of `address` to `asset`.  The trustlines of
+// `address` must have been loaded.
+func (ls *ledgerState) setTrustline(address string, asset xdr.Asset, line *core.Trustline) error {
+	key, err := assetKey(asset)
+	if err != nil {
+		return err
+	}
+
+	if line == nil {
+		delete(ls.trustlines[address], key)
+		return nil
+	}
+
+	ls.trustlines[address][key] = line
+	return nil
+}
+
+func assetKey(asset xdr.Asset) (string, error) {
+	var (
+		t xdr.AssetType
+		c string
+		i string
+	)
+
+	err := asset.Extract(&t, &c, &i)
+	if err != nil {
+		return "", err
+	}
+
+	return trustlineKey(t, c, i), nil
+}
+
+func trustlineKey(t xdr.AssetType, code string, issuer string) string {
+	return t.String() + "/" + code + "/" + issuer
+}
@@ -38,6 +38,14 @@ func RequestHelperStreaming(r *http.Request) {
 	r.Header.Set("Accept", "text/event-stream")
 }
 
+func RequestHelperCSV(r *http.Request) {
+	r.Header.Set("Accept", "text/csv")
+}
+
+func RequestHelperNDJSON(r *http.Request) {
+	r.Header.Set("Accept", "application/x-ndjson")
+}
+
 func NewRequestHelper(router *web.Mux) RequestHelper {
 	return &requestHelper{router}
 }
@@ -18,6 +18,18 @@ ALTER TABLE IF EXISTS ONLY public.history_trades DROP CONSTRAINT IF EXISTS histo
 ALTER TABLE IF EXISTS ONLY public.history_trades DROP CONSTRAINT IF EXISTS history_trades_counter_account_id_fkey;
 ALTER TABLE IF EXISTS ONLY public.history_trades DROP CONSTRAINT IF EXISTS history_trades_base_asset_id_fkey;
 ALTER TABLE IF EXISTS ONLY public.history_trades DROP CONSTRAINT IF EXISTS history_trades_base_account_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_trade_aggregations DROP CONSTRAINT IF EXISTS history_trade_aggregations_counter_asset_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_trade_aggregations DROP CONSTRAINT IF EXISTS history_trade_aggregations_base_asset_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_order_book_snapshots DROP CONSTRAINT IF EXISTS history_order_book_snapshots_selling_asset_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_order_book_snapshots DROP CONSTRAINT IF EXISTS history_order_book_snapshots_buying_asset_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_offer_events DROP CONSTRAINT IF EXISTS history_offer_events_selling_asset_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_offer_events DROP CONSTRAINT IF EXISTS history_offer_events_seller_account_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_offer_events DROP CONSTRAINT IF EXISTS history_offer_events_buying_asset_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_balance_snapshots DROP CONSTRAINT IF EXISTS history_balance_snapshots_history_asset_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_balance_snapshots DROP CONSTRAINT IF EXISTS history_balance_snapshots_history_account_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_account_creations DROP CONSTRAINT IF EXISTS history_account_creations_merged_into_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_account_creations DROP CONSTRAINT IF EXISTS history_account_creations_history_account_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_account_creations DROP CONSTRAINT IF EXISTS history_account_creations_created_by_id_fkey;
 ALTER TABLE IF EXISTS ONLY public.asset_stats DROP CONSTRAINT IF EXISTS asset_stats_id_fkey;
 DROP INDEX IF EXISTS public.trade_effects_by_order_book;
 DROP INDEX IF EXISTS public.index_history_transactions_on_id;
@@ -38,14 +50,28 @@ DROP INDEX IF EXISTS public.htrd_pid;
 DROP INDEX IF EXISTS public.htrd_pair_time_lookup;
 DROP INDEX IF EXISTS public.htrd_counter_lookup;
 DROP INDEX IF EXISTS public.htrd_by_offer;
+DROP INDEX IF EXISTS public.htrd_agg_by_time;
+DROP INDEX IF EXISTS public.htrd_agg_by_pair;
 DROP INDEX IF EXISTS public.htp_by_htid;
 DROP INDEX IF EXISTS public.hs_transaction_by_id;
 DROP INDEX IF EXISTS public.hs_ledger_by_id;
 DROP INDEX IF EXISTS public.hop_by_hoid;
+DROP INDEX IF EXISTS public.hoe_pid;
+DROP INDEX IF EXISTS public.hoe_by_seller;
+DROP INDEX IF EXISTS public.hoe_by_offer;
+DROP INDEX IF EXISTS public.hobs_by_pair;
 DROP INDEX IF EXISTS public.hist_tx_p_id;
 DROP INDEX IF EXISTS public.hist_op_p_id;
+DROP INDEX IF EXISTS public.hist_op_by_type;
 DROP INDEX IF EXISTS public.hist_e_id;
+DROP INDEX IF EXISTS public.hist_e_by_type;
 DROP INDEX IF EXISTS public.hist_e_by_order;
+DROP INDEX IF EXISTS public.hbs_by_ledger;
+DROP INDEX IF EXISTS public.hbs_by_account;
+DROP INDEX IF EXISTS public.hac_by_operation;
+DROP INDEX IF EXISTS public.hac_by_creator;
+DROP INDEX IF EXISTS public.hac_by_account;
+DROP INDEX IF EXISTS public.by_memo;
 DROP INDEX IF EXISTS public.by_ledger;
 DROP INDEX IF EXISTS public.by_hash;
 DROP INDEX IF EXISTS public.by_account;
@@ -64,15 +90,20 @@ DROP TABLE IF EXISTS public.history_transactions;
 DROP SEQUENCE IF EXISTS public.history_transaction_participants_id_seq;
 DROP TABLE IF EXISTS public.history_transaction_participants;
 DROP TABLE IF EXISTS public.history_trades;
+DROP TABLE IF EXISTS public.history_trade_aggregations;
+DROP TABLE IF EXISTS public.history_order_book_snapshots;
 DROP TABLE IF EXISTS public.history_operations;
 DROP SEQUENCE IF EXISTS public.history_operation_participants_id_seq;
 DROP TABLE IF EXISTS public.history_operation_participants;
+DROP TABLE IF EXISTS public.history_offer_events;
 DROP TABLE IF EXISTS public.history_ledgers;
 DROP TABLE IF EXISTS public.history_effects;
+DROP TABLE IF EXISTS public.history_balance_snapshots;
 DROP SEQUENCE IF EXISTS public.history_assets_id_seq;
 DROP TABLE IF EXISTS public.history_assets;
 DROP TABLE IF EXISTS public.history_accounts;
 DROP SEQUENCE IF EXISTS public.history_accounts_id_seq;
+DROP TABLE IF EXISTS public.history_account_creations;
 DROP TABLE IF EXISTS public.gorp_migrations;
 DROP TABLE IF EXISTS public.asset_stats;
 DROP AGGREGATE IF EXISTS public.last(anyelement);
@@ -188,6 +219,19 @@ CREATE SEQUENCE history_accounts_id_seq
     CACHE 1;
 
 
+--
+-- Name: history_account_creations; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE history_account_creations (
+    history_operation_id bigint NOT NULL,
+    history_account_id bigint NOT NULL,
+    created_by_id bigint NOT NULL,
+    merged_into_id bigint,
+    merged_by_operation_id bigint
+);
+
+
 --
 -- Name: history_accounts; Type: TABLE; Schema: public; Owner: -
 --
@@ -229,6 +273,19 @@ CREATE SEQUENCE history_assets_id_seq
 ALTER SEQUENCE history_assets_id_seq OWNED BY history_assets.id;
 
 
+--
+-- Name: history_balance_snapshots; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE history_balance_snapshots (
+    history_account_id bigint NOT NULL,
+    history_asset_id bigint NOT NULL,
+    ledger_sequence integer NOT NULL,
+    balance bigint NOT NULL,
+    CONSTRAINT history_balance_snapshots_balance_check CHECK ((balance >= 0))
+);
+
+
 --
 -- Name: history_effects; Type: TABLE; Schema: public; Owner: -
 --
@@ -266,6 +323,27 @@ CREATE TABLE history_ledgers (
 );
 
 
+--
+-- Name: history_offer_events; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE history_offer_events (
+    history_operation_id bigint NOT NULL,
+    "order" integer NOT NULL,
+    offer_id bigint NOT NULL,
+    type smallint NOT NULL,
+    ledger_closed_at timestamp without time zone NOT NULL,
+    seller_account_id bigint NOT NULL,
+    selling_asset_id bigint NOT NULL,
+    buying_asset_id bigint NOT NULL,
+    amount bigint NOT NULL,
+    pricen integer NOT NULL,
+    priced integer NOT NULL,
+    price double precision NOT NULL,
+    CONSTRAINT history_offer_events_amount_check CHECK ((amount >= 0))
+);
+
+
 --
 -- Name: history_operation_participants; Type: TABLE; Schema: public; Owner: -
 --
@@ -310,6 +388,39 @@ CREATE TABLE history_operations (
 );
 
 
+--
+-- Name: history_order_book_snapshots; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE history_order_book_snapshots (
+    ledger_sequence integer NOT NULL,
+    selling_asset_id bigint NOT NULL,
+    buying_asset_id bigint NOT NULL,
+    levels jsonb NOT NULL
+);
+
+
+--
+-- Name: history_trade_aggregations; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE history_trade_aggregations (
+    resolution bigint NOT NULL,
+    base_asset_id bigint NOT NULL,
+    counter_asset_id bigint NOT NULL,
+    "timestamp" bigint NOT NULL,
+    count bigint NOT NULL,
+    base_volume bigint NOT NULL,
+    counter_volume bigint NOT NULL,
+    price_sum double precision NOT NULL,
+    inverse_price_sum double precision NOT NULL,
+    high double precision NOT NULL,
+    low double precision NOT NULL,
+    open double precision NOT NULL,
+    close double precision NOT NULL
+);
+
+
 --
 -- Name: history_trades; Type: TABLE; Schema: public; Owner: -
 --
@@ -430,6 +541,24 @@ INSERT INTO gorp_migrations VALUES ('6_create_assets_table.sql', '2017-11-29 18:
 INSERT INTO gorp_migrations VALUES ('7_modify_trades_table.sql', '2017-11-29 18:22:17.06929-08');
 INSERT INTO gorp_migrations VALUES ('8_add_aggregators.sql', '2017-11-29 18:22:17.071867-08');
 INSERT INTO gorp_migrations VALUES ('8_create_asset_stats_table.sql', '2017-11-29 18:22:17.074882-08');
+INSERT INTO gorp_migrations VALUES ('9_create_offer_events_table.sql', '2017-12-20 10:14:52.113281-08');
+INSERT INTO gorp_migrations VALUES ('10_index_operations_and_effects_by_type.sql', '2018-01-09 11:02:37.418733-08');
+INSERT INTO gorp_migrations VALUES ('11_index_transactions_by_memo.sql', '2018-01-11 16:47:05.902114-08');
+INSERT INTO gorp_migrations VALUES ('12_create_order_book_snapshots_table.sql', '2018-01-16 10:12:41.513307-08');
+INSERT INTO gorp_migrations VALUES ('13_create_trade_aggregations_table.sql', '2018-01-18 14:31:09.225861-08');
+INSERT INTO gorp_migrations VALUES ('14_index_trade_aggregations_by_time.sql', '2018-01-22 09:57:16.304552-08');
+INSERT INTO gorp_migrations VALUES ('15_create_account_creations_table.sql', '2018-01-24 15:03:27.719048-08');
+INSERT INTO gorp_migrations VALUES ('16_create_balance_snapshots_table.sql', '2018-01-26 11:42:08.127366-08');
+
+
+--
+-- Data for Name: history_account_creations; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+INSERT INTO history_account_creations VALUES (8589938689, 2, 1, NULL, NULL);
+INSERT INTO history_account_creations VALUES (8589942785, 3, 1, NULL, NULL);
+INSERT INTO history_account_creations VALUES (8589946881, 4, 1, NULL, NULL);
+INSERT INTO history_account_creations VALUES (8589950977, 5, 1, NULL, NULL);
 
 
 --
@@ -465,6 +594,12 @@ INSERT INTO history_assets VALUES (2, 'credit_alphanum4', 'USD', 'GAB7GMQPJ5YY2E
 SELECT pg_catalog.setval('history_assets_id_seq', 2, true);
 
 
+--
+-- Data for Name: history_balance_snapshots; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
 --
 -- Data for Name: history_effects; Type: TABLE DATA; Schema: public; Owner: -
 --
@@ -495,6 +630,12 @@ INSERT INTO history_ledgers VALUES (3, 'def75875e3d6d1165108ce08da1de5324f37f9d0
 INSERT INTO history_ledgers VALUES (4, '3770b05adb80e3a56c82593ef36e7e2357e18bcf2abe5faa82886bb3976dbcc9', 'def75875e3d6d1165108ce08da1de5324f37f9d0d6d4f17c0616845eb2d63c28', 0, 0, '2017-11-30 02:22:39', '2017-11-30 02:22:39.339414', '2017-11-30 02:22:39.339414', 17179869184, 11, 1000000000000000000, 600, 100, 100000000, 10000, 8);
 
 
+--
+-- Data for Name: history_offer_events; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
 --
 -- Data for Name: history_operation_participants; Type: TABLE DATA; Schema: public; Owner: -
 --
@@ -530,6 +671,18 @@ INSERT INTO history_operations VALUES (12884905985, 12884905984, 1, 6, '{"limit"
 INSERT INTO history_operations VALUES (12884910081, 12884910080, 1, 6, '{"limit": "922337203685.4775807", "trustee": "GAB7GMQPJ5YY2E4UJMLNAZPDEUKPK4AAIPRXIZHKZGUIRC6FP2LAQSDN", "trustor": "GCSX4PDUZP3BL522ZVMFXCEJ55NKEOHEMII7PSMJZNAAESJ444GSSJMO", "asset_code": "USD", "asset_type": "credit_alphanum4", "asset_issuer": "GAB7GMQPJ5YY2E4UJMLNAZPDEUKPK4AAIPRXIZHKZGUIRC6FP2LAQSDN"}', 'GCSX4PDUZP3BL522ZVMFXCEJ55NKEOHEMII7PSMJZNAAESJ444GSSJMO');
 
 
+--
+-- Data for Name: history_order_book_snapshots; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
+--
+-- Data for Name: history_trade_aggregations; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
 --
 -- Data for Name: history_trades; Type: TABLE DATA; Schema: public; Owner: -
 --
@@ -654,6 +807,48 @@ CREATE INDEX by_hash ON history_transactions USING btree (transaction_hash);
 CREATE INDEX by_ledger ON history_transactions USING btree (ledger_sequence, application_order);
 
 
+--
+-- Name: by_memo; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX by_memo ON history_transactions USING btree (memo_type, memo, id);
+
+
+--
+-- Name: hac_by_account; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX hac_by_account ON history_account_creations USING btree (history_account_id, history_operation_id);
+
+
+--
+-- Name: hac_by_creator; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX hac_by_creator ON history_account_creations USING btree (created_by_id, history_operation_id);
+
+
+--
+-- Name: hac_by_operation; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX hac_by_operation ON history_account_creations USING btree (history_operation_id);
+
+
+--
+-- Name: hbs_by_account; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX hbs_by_account ON history_balance_snapshots USING btree (history_account_id, history_asset_id, ledger_sequence);
+
+
+--
+-- Name: hbs_by_ledger; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX hbs_by_ledger ON history_balance_snapshots USING btree (ledger_sequence);
+
+
 --
 -- Name: hist_e_by_order; Type: INDEX; Schema: public; Owner: -
 --
@@ -661,6 +856,13 @@ CREATE INDEX by_ledger ON history_transactions USING btree (ledger_sequence, app
 CREATE UNIQUE INDEX hist_e_by_order ON history_effects USING btree (history_operation_id, "order");
 
 
+--
+-- Name: hist_e_by_type; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX hist_e_by_type ON history_effects USING btree (type, history_operation_id, "order");
+
+
 --
 -- Name: hist_e_id; Type: INDEX; Schema: public; Owner: -
 --
@@ -668,6 +870,13 @@ CREATE UNIQUE INDEX hist_e_by_order ON history_effects USING btree (history_oper
 CREATE UNIQUE INDEX hist_e_id ON history_effects USING btree (history_account_id, history_operation_id, "order");
 
 
+--
+-- Name: hist_op_by_type; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX hist_op_by_type ON history_operations USING btree (type, id);
+
+
 --
 -- Name: hist_op_p_id; Type: INDEX; Schema: public; Owner: -
 --
@@ -682,6 +891,34 @@ CREATE UNIQUE INDEX hist_op_p_id ON history_operation_participants USING btree (
 CREATE UNIQUE INDEX hist_tx_p_id ON history_transaction_participants USING btree (history_account_id, history_transaction_id);
 
 
+--
+-- Name: hobs_by_pair; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX hobs_by_pair ON history_order_book_snapshots USING btree (selling_asset_id, buying_asset_id, ledger_sequence);
+
+
+--
+-- Name: hoe_by_offer; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX hoe_by_offer ON history_offer_events USING btree (offer_id, history_operation_id, "order");
+
+
+--
+-- Name: hoe_by_seller; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX hoe_by_seller ON history_offer_events USING btree (seller_account_id);
+
+
+--
+-- Name: hoe_pid; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX hoe_pid ON history_offer_events USING btree (history_operation_id, "order");
+
+
 --
 -- Name: hop_by_hoid; Type: INDEX; Schema: public; Owner: -
 --
@@ -710,6 +947,20 @@ CREATE UNIQUE INDEX hs_transaction_by_id ON history_transactions USING btree (id
 CREATE INDEX htp_by_htid ON history_transaction_participants USING btree (history_transaction_id);
 
 
+--
+-- Name: htrd_agg_by_pair; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX htrd_agg_by_pair ON history_trade_aggregations USING btree (base_asset_id, counter_asset_id, resolution, "timestamp");
+
+
+--
+-- Name: htrd_agg_by_time; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX htrd_agg_by_time ON history_trade_aggregations USING btree (resolution, "timestamp");
+
+
 --
 -- Name: htrd_by_offer; Type: INDEX; Schema: public; Owner: -
 --
@@ -851,6 +1102,102 @@ ALTER TABLE ONLY asset_stats
     ADD CONSTRAINT asset_stats_id_fkey FOREIGN KEY (id) REFERENCES history_assets(id) ON UPDATE RESTRICT ON DELETE CASCADE;
 
 
+--
+-- Name: history_account_creations history_account_creations_created_by_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_account_creations
+    ADD CONSTRAINT history_account_creations_created_by_id_fkey FOREIGN KEY (created_by_id) REFERENCES history_accounts(id);
+
+
+--
+-- Name: history_account_creations history_account_creations_history_account_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_account_creations
+    ADD CONSTRAINT history_account_creations_history_account_id_fkey FOREIGN KEY (history_account_id) REFERENCES history_accounts(id);
+
+
+--
+-- Name: history_account_creations history_account_creations_merged_into_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_account_creations
+    ADD CONSTRAINT history_account_creations_merged_into_id_fkey FOREIGN KEY (merged_into_id) REFERENCES history_accounts(id);
+
+
+--
+-- Name: history_balance_snapshots history_balance_snapshots_history_account_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_balance_snapshots
+    ADD CONSTRAINT history_balance_snapshots_history_account_id_fkey FOREIGN KEY (history_account_id) REFERENCES history_accounts(id);
+
+
+--
+-- Name: history_balance_snapshots history_balance_snapshots_history_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_balance_snapshots
+    ADD CONSTRAINT history_balance_snapshots_history_asset_id_fkey FOREIGN KEY (history_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_offer_events history_offer_events_buying_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_offer_events
+    ADD CONSTRAINT history_offer_events_buying_asset_id_fkey FOREIGN KEY (buying_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_offer_events history_offer_events_seller_account_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_offer_events
+    ADD CONSTRAINT history_offer_events_seller_account_id_fkey FOREIGN KEY (seller_account_id) REFERENCES history_accounts(id);
+
+
+--
+-- Name: history_offer_events history_offer_events_selling_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_offer_events
+    ADD CONSTRAINT history_offer_events_selling_asset_id_fkey FOREIGN KEY (selling_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_order_book_snapshots history_order_book_snapshots_buying_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_order_book_snapshots
+    ADD CONSTRAINT history_order_book_snapshots_buying_asset_id_fkey FOREIGN KEY (buying_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_order_book_snapshots history_order_book_snapshots_selling_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_order_book_snapshots
+    ADD CONSTRAINT history_order_book_snapshots_selling_asset_id_fkey FOREIGN KEY (selling_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_trade_aggregations history_trade_aggregations_base_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_trade_aggregations
+    ADD CONSTRAINT history_trade_aggregations_base_asset_id_fkey FOREIGN KEY (base_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_trade_aggregations history_trade_aggregations_counter_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_trade_aggregations
+    ADD CONSTRAINT history_trade_aggregations_counter_asset_id_fkey FOREIGN KEY (counter_asset_id) REFERENCES history_assets(id);
+
+
 --
 -- Name: history_trades history_trades_base_account_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
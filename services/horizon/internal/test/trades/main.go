@@ -44,7 +44,12 @@ func IngestTestTrade(
 	trade.AssetBought = assetBought
 	trade.AssetSold = assetSold
 
-	return q.InsertTrade(opCounter, 0, buyer, trade, timestamp)
+	err := q.InsertTrade(opCounter, 0, buyer, trade, timestamp)
+	if err != nil {
+		return err
+	}
+
+	return q.RefreshTradeAggregations(opCounter, opCounter+1)
 }
 
 //PopulateTestTrades generates and ingests trades between two assets according to given parameters
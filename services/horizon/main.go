@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 	"runtime"
+	"strings"
 
 	"github.com/PuerkitoBio/throttled"
 	"github.com/sirupsen/logrus"
@@ -25,6 +26,8 @@ func main() {
 func init() {
 	viper.SetDefault("port", 8000)
 	viper.SetDefault("history-retention-count", 0)
+	viper.SetDefault("fee-stats-ledger-count", 5)
+	viper.SetDefault("export-limit", 100000)
 
 	viper.BindEnv("port", "PORT")
 	viper.BindEnv("db-url", "DATABASE_URL")
@@ -45,6 +48,15 @@ func init() {
 	viper.BindEnv("history-retention-count", "HISTORY_RETENTION_COUNT")
 	viper.BindEnv("history-stale-threshold", "HISTORY_STALE_THRESHOLD")
 	viper.BindEnv("skip-cursor-update", "SKIP_CURSOR_UPDATE")
+	viper.BindEnv("fee-stats-ledger-count", "FEE_STATS_LEDGER_COUNT")
+	viper.BindEnv("export-limit", "EXPORT_LIMIT")
+	viper.BindEnv("ingest-events-file", "INGEST_EVENTS_FILE")
+	viper.BindEnv("ingest-webhook-url", "INGEST_WEBHOOK_URL")
+	viper.BindEnv("gap-check-interval", "GAP_CHECK_INTERVAL")
+	viper.BindEnv("gap-heal-limit", "GAP_HEAL_LIMIT")
+	viper.BindEnv("ingest-filter-accounts", "INGEST_FILTER_ACCOUNTS")
+	viper.BindEnv("ingest-filter-assets", "INGEST_FILTER_ASSETS")
+	viper.BindEnv("ingest-filter-operation-types", "INGEST_FILTER_OPERATION_TYPES")
 
 	rootCmd = &cobra.Command{
 		Use:   "horizon",
@@ -158,6 +170,60 @@ func init() {
 		"the maximum number of ledgers the history db is allowed to be out of date from the connected stellar-core db before horizon considers history stale",
 	)
 
+	rootCmd.Flags().Uint(
+		"fee-stats-ledger-count",
+		5,
+		"the number of recent ledgers over which the fee statistics served by /fee_stats are computed",
+	)
+
+	rootCmd.Flags().Uint(
+		"export-limit",
+		100000,
+		"the maximum number of records written by a single csv or ndjson export.  0 signifies exports are unlimited",
+	)
+
+	rootCmd.Flags().String(
+		"ingest-events-file",
+		"",
+		"a file that ingested ledgers, transactions, operations, effects and trades are appended to as lines of json",
+	)
+
+	rootCmd.Flags().String(
+		"ingest-webhook-url",
+		"",
+		"a url that ingested ledgers, transactions, operations, effects and trades are posted to as json",
+	)
+
+	rootCmd.Flags().Duration(
+		"gap-check-interval",
+		0,
+		"how often to search the history db for gaps.  0 disables the search",
+	)
+
+	rootCmd.Flags().Int(
+		"gap-heal-limit",
+		0,
+		"the most ledgers to reingest from stellar-core to heal the gaps found by each search.  0 disables healing",
+	)
+
+	rootCmd.Flags().String(
+		"ingest-filter-accounts",
+		"",
+		"comma separated list of accounts; only transactions one of them participates in are ingested",
+	)
+
+	rootCmd.Flags().String(
+		"ingest-filter-assets",
+		"",
+		"comma separated list of assets, as native or CODE:ISSUER; only transactions with an operation involving one of them are ingested",
+	)
+
+	rootCmd.Flags().String(
+		"ingest-filter-operation-types",
+		"",
+		"comma separated list of operation types, e.g. payment,path_payment; only transactions with an operation of one of them are ingested",
+	)
+
 	rootCmd.AddCommand(dbCmd)
 
 	viper.BindPFlags(rootCmd.Flags())
@@ -222,5 +288,27 @@ func initConfig() {
 		HistoryRetentionCount:  uint(viper.GetInt("history-retention-count")),
 		StaleThreshold:         uint(viper.GetInt("history-stale-threshold")),
 		SkipCursorUpdate:       viper.GetBool("skip-cursor-update"),
+		FeeStatsLedgerCount:    uint(viper.GetInt("fee-stats-ledger-count")),
+		ExportLimit:            uint(viper.GetInt("export-limit")),
+		IngestEventsFile:       viper.GetString("ingest-events-file"),
+		IngestWebhookURL:       viper.GetString("ingest-webhook-url"),
+		GapCheckInterval:       viper.GetDuration("gap-check-interval"),
+		GapHealLimit:           int32(viper.GetInt("gap-heal-limit")),
+		FilterAccounts:         splitList(viper.GetString("ingest-filter-accounts")),
+		FilterAssets:           splitList(viper.GetString("ingest-filter-assets")),
+		FilterOperationTypes:   splitList(viper.GetString("ingest-filter-operation-types")),
+	}
+}
+
+// splitList splits a comma separated list of values, ignoring surrounding
+// whitespace and empty values.
+func splitList(raw string) []string {
+	var result []string
+	for _, v := range strings.Split(raw, ",") {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			result = append(result, v)
+		}
 	}
+	return result
 }
@@ -12,7 +12,7 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
-	archivist "github.com/stellar/go/tools/stellar-archivist/internal"
+	archivist "github.com/stellar/go/historyarchive"
 )
 
 func status(a string, opts *Options) {